@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/private/engine"
 )
 
 // SignerFn is a signer function callback when a contract requires a method to
@@ -38,7 +39,9 @@ type SignerFn func(types.Signer, common.Address, *types.Transaction) (*types.Tra
 //
 // Additional arguments in order to support transaction privacy
 type PrivateTxArgs struct {
-	PrivateFor []string `json:"privateFor"`
+	PrivateFor   []string               `json:"privateFor"`
+	PrivacyFlag  engine.PrivacyFlagType `json:"privacyFlag,omitempty"`
+	MandatoryFor []string               `json:"mandatoryFor,omitempty"`
 }
 
 // CallOpts is the collection of options to fine tune a contract call request.
@@ -63,8 +66,21 @@ type TransactOpts struct {
 	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
 
 	// Quorum
-	PrivateFrom string   // The public key of the Tessera/Constellation identity to send this tx from.
-	PrivateFor  []string // The public keys of the Tessera/Constellation identities this tx is intended for.
+	PrivateFrom  string                 // The public key of the Tessera/Constellation identity to send this tx from.
+	PrivateFor   []string               // The public keys of the Tessera/Constellation identities this tx is intended for.
+	PrivacyFlag  engine.PrivacyFlagType // The privacy enforcement level of this tx (0 = standard private).
+	MandatoryFor []string               // The public keys, all in PrivateFor, which must be party to every later tx (requires the mandatory recipients flag).
+}
+
+// Quorum
+//
+// PrivateTxArgs returns the privacy arguments of the transaction to send
+func (opts *TransactOpts) PrivateTxArgs() PrivateTxArgs {
+	return PrivateTxArgs{
+		PrivateFor:   opts.PrivateFor,
+		PrivacyFlag:  opts.PrivacyFlag,
+		MandatoryFor: opts.MandatoryFor,
+	}
 }
 
 // FilterOpts is the collection of options to fine tune filtering for events
@@ -229,7 +245,8 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 			}
 		}
 		// If the contract surely has code (or code is not needed), estimate the transaction
-		msg := ethereum.CallMsg{From: opts.From, To: contract, GasPrice: gasPrice, Value: value, Data: input}
+		// Quorum: a private transaction is estimated on the private state
+		msg := ethereum.CallMsg{From: opts.From, To: contract, GasPrice: gasPrice, Value: value, Data: input, PrivateFrom: opts.PrivateFrom, PrivateFor: opts.PrivateFor}
 		gasLimit, err = c.transactor.EstimateGas(ensureContext(opts.Context), msg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to estimate gas needed: %v", err)
@@ -252,7 +269,7 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 		if err != nil {
 			return nil, err
 		}
-		rawTx = c.createPrivateTransaction(rawTx, payload)
+		rawTx = NewPrivateTransaction(rawTx, payload)
 	}
 
 	// Choose signer to sign transaction
@@ -269,7 +286,7 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 		return nil, err
 	}
 
-	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx, PrivateTxArgs{PrivateFor: opts.PrivateFor}); err != nil {
+	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx, opts.PrivateTxArgs()); err != nil {
 		return nil, err
 	}
 
@@ -389,13 +406,15 @@ func (c *BoundContract) UnpackLogIntoMap(out map[string]interface{}, event strin
 }
 
 // Quorum
-// createPrivateTransaction replaces the payload of private transaction to the hash from Tessera/Constellation
-func (c *BoundContract) createPrivateTransaction(tx *types.Transaction, payload []byte) *types.Transaction {
+// NewPrivateTransaction replaces the payload of the unsigned transaction with the hash
+// of the payload stored in Tessera/Constellation, see ContractTransactor.PreparePrivateTransaction,
+// and marks it as private so that it is signed with types.QuorumPrivateTxSigner.
+func NewPrivateTransaction(tx *types.Transaction, payload []byte) *types.Transaction {
 	var privateTx *types.Transaction
 	if tx.To() == nil {
 		privateTx = types.NewContractCreation(tx.Nonce(), tx.Value(), tx.Gas(), tx.GasPrice(), payload)
 	} else {
-		privateTx = types.NewTransaction(tx.Nonce(), c.address, tx.Value(), tx.Gas(), tx.GasPrice(), payload)
+		privateTx = types.NewTransaction(tx.Nonce(), *tx.To(), tx.Value(), tx.Gas(), tx.GasPrice(), payload)
 	}
 	privateTx.SetPrivate()
 	return privateTx
@@ -174,7 +174,7 @@ func TestGethDoesntStartWithoutPrivateTransactionManagerVariableSet(t *testing.T
 		"--unlock", "f466859ead1932d743d622cb74fc058882e8648a")
 	geth.ExpectExit()
 
-	expectedText := "the PRIVATE_CONFIG environment variable must be specified for Quorum"
+	expectedText := "the private transaction manager must be specified for Quorum"
 	result := strings.TrimSpace(geth.StderrText())
 	if !strings.Contains(result, expectedText) {
 		geth.Fatalf("bad stderr text. want '%s', got '%s'", expectedText, result)
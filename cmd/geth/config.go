@@ -30,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine/notinuse"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
 	"github.com/naoina/toml"
 	"gopkg.in/urfave/cli.v1"
@@ -154,6 +156,14 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		cfg.Eth.OverrideIstanbul = new(big.Int).SetUint64(ctx.GlobalUint64(utils.OverrideIstanbulFlag.Name))
 	}
 
+	ptm, err := utils.MakePrivateTransactionManager(ctx, stack)
+	if err != nil {
+		utils.Fatalf("Failed to create the private transaction manager: %v", err)
+	}
+	if err := stack.SetPrivateTransactionManager(ptm); err != nil {
+		utils.Fatalf("Failed to set the private transaction manager: %v", err)
+	}
+
 	ethChan := utils.RegisterEthService(stack, &cfg.Eth)
 
 	// plugin service must be after eth service so that eth service will be stopped gradually if any of the plugin
@@ -174,8 +184,7 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		utils.RegisterDashboardService(stack, &cfg.Dashboard, gitCommit)
 	}
 
-	ipcPath := quorumGetPrivateTransactionManager()
-	if ipcPath != "" {
+	if quorumIsPrivateTransactionManagerInUse(ptm) {
 		utils.RegisterExtensionService(stack, ethChan)
 	}
 
@@ -248,17 +257,12 @@ func quorumValidateConsensus(stack *node.Node, isRaft bool) {
 	}
 }
 
-// quorumValidatePrivateTransactionManager returns whether the "PRIVATE_CONFIG"
-// environment variable is set
-func quorumValidatePrivateTransactionManager() bool {
-	return os.Getenv("PRIVATE_CONFIG") != ""
-}
-
-//
-func quorumGetPrivateTransactionManager() string {
-	cfgPath := os.Getenv("PRIVATE_CONFIG")
-	if cfgPath != "" && cfgPath != "ignore" {
-		return cfgPath
+// quorumIsPrivateTransactionManagerInUse returns whether private transactions
+// are handled by a private transaction manager rather than ignored
+func quorumIsPrivateTransactionManagerInUse(ptm private.PrivateTransactionManager) bool {
+	if ptm == nil {
+		return false
 	}
-	return ""
+	_, ignored := ptm.(*notinuse.PrivateTransactionManager)
+	return !ignored
 }
@@ -156,6 +156,7 @@ var (
 		// Quorum
 		utils.QuorumImmutabilityThreshold,
 		utils.EnableNodePermissionFlag,
+		utils.EnableRPCPermissionFlag,
 		utils.RaftModeFlag,
 		utils.RaftBlockTimeFlag,
 		utils.RaftJoinExistingFlag,
@@ -169,6 +170,24 @@ var (
 		utils.PluginLocalVerifyFlag,
 		utils.PluginPublicKeyFlag,
 		utils.AllowedFutureBlockTimeFlag,
+		utils.PtmConfigFlag,
+		utils.PtmUrlFlag,
+		utils.PtmSocketFlag,
+		utils.PtmTimeoutFlag,
+		utils.PtmDialTimeoutFlag,
+		utils.PtmTLSRootCAFlag,
+		utils.PtmTLSClientCertFlag,
+		utils.PtmTLSClientKeyFlag,
+		utils.PtmTLSInsecureSkipVerifyFlag,
+		utils.PtmRetriesFlag,
+		utils.PtmRetryBackoffFlag,
+		utils.PtmCircuitBreakerThresholdFlag,
+		utils.PtmCircuitBreakerCooldownFlag,
+		utils.PtmUpcheckIntervalFlag,
+		utils.PtmCacheSizeFlag,
+		utils.PtmCacheTTLFlag,
+		utils.PtmCachePersistFlag,
+		utils.MultitenancyPrivateStatesFlag,
 		// End-Quorum
 	}
 
@@ -230,6 +249,10 @@ func init() {
 		removedbCommand,
 		dumpCommand,
 		inspectCommand,
+		// See privatestatecmd.go:
+		privateStateCommand,
+		// See permissioncmd.go:
+		permissionCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -334,10 +357,6 @@ func geth(ctx *cli.Context) error {
 	}
 	prepare(ctx)
 
-	if !quorumValidatePrivateTransactionManager() {
-		return errors.New("the PRIVATE_CONFIG environment variable must be specified for Quorum")
-	}
-
 	// raft mode does not support --exitwhensynced
 	if ctx.GlobalBool(utils.ExitWhenSyncedFlag.Name) && ctx.GlobalBool(utils.RaftModeFlag.Name) {
 		return errors.New("raft consensus does not support --exitwhensynced")
@@ -345,6 +364,11 @@ func geth(ctx *cli.Context) error {
 
 	node := makeFullNode(ctx)
 	defer node.Close()
+
+	if node.PrivateTransactionManager() == nil {
+		return errors.New("the private transaction manager must be specified for Quorum, use the --ptm.* flags or the privacy plugin")
+	}
+
 	startNode(ctx, node)
 
 	// Check if a valid consensus is used
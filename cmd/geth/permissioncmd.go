@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/permission"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	permissionBlockFlag = cli.Uint64Flag{
+		Name:  "block",
+		Usage: "Number of the block to export the permission tree at, the head of the chain if not set",
+	}
+	permissionGuardianFlag = cli.StringFlag{
+		Name:  "guardian",
+		Usage: "Account deploying the permission contracts, which becomes the guardian of the upgradable contract",
+	}
+
+	permissionCommand = cli.Command{
+		Name:     "permission",
+		Usage:    "Export and import the permission tree",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Commands to copy the orgs, roles, accounts, nodes and contract access rules of
+the permission model from a network to the genesis of a new network.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "export",
+				Usage:  "Export the permission tree at a block to JSON",
+				Action: utils.MigrateFlags(exportPermissions),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.CacheFlag,
+					permissionBlockFlag,
+				},
+				Description: `
+    geth permission export [--block <number>]
+
+prints the permission tree held by the permission contracts at the given block,
+along with the policy of the network, as a snapshot to be given to the import
+command. The contracts are found with the permission-config.json file of the
+data directory. The node must not be running.`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Load a permission snapshot into the genesis of a new network",
+				ArgsUsage: "<snapshot file> <genesis file> <output dir>",
+				Action:    utils.MigrateFlags(importPermissions),
+				Flags: []cli.Flag{
+					permissionGuardianFlag,
+				},
+				Description: `
+    geth permission import --guardian <address> <snapshot file> <genesis file> <output dir>
+
+deploys the permission contracts and replays the snapshot created by the export
+command into them, then writes to the output directory the genesis file with
+the contracts added to its allocation, and the permission-config.json file
+pointing at them. The nodes of the new network start with the permission tree
+of the snapshot instead of booting up the network from permission-config.json.
+Operations pending approval in the snapshot must be completed first.`,
+			},
+		},
+	}
+)
+
+func exportPermissions(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	permConfig, err := permission.ParsePermissionConfig(stack.DataDir())
+	if err != nil {
+		utils.Fatalf("Failed to read the permission config: %v", err)
+	}
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+	defer chain.Stop()
+
+	header := chain.CurrentHeader()
+	if ctx.IsSet(permissionBlockFlag.Name) {
+		if header = chain.GetHeaderByNumber(ctx.Uint64(permissionBlockFlag.Name)); header == nil {
+			utils.Fatalf("Block %d not found", ctx.Uint64(permissionBlockFlag.Name))
+		}
+	}
+	publicState, _, err := chain.StateAt(header.Root)
+	if err != nil {
+		utils.Fatalf("Failed to open the state of block %d: %v", header.Number, err)
+	}
+	snapshot, err := permission.ExportPermissions(chain.Config(), header, publicState, &permConfig)
+	if err != nil {
+		utils.Fatalf("Failed to export the permission tree: %v", err)
+	}
+	return printJSON(snapshot)
+}
+
+func importPermissions(ctx *cli.Context) error {
+	if len(ctx.Args()) != 3 {
+		utils.Fatalf("This command requires the snapshot file, the genesis file and the output directory as arguments.")
+	}
+	guardian := ctx.String(permissionGuardianFlag.Name)
+	if !common.IsHexAddress(guardian) {
+		utils.Fatalf("The --%s flag is required", permissionGuardianFlag.Name)
+	}
+	data, err := ioutil.ReadFile(ctx.Args().Get(0))
+	if err != nil {
+		utils.Fatalf("Failed to read the snapshot: %v", err)
+	}
+	var snapshot permission.PermissionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		utils.Fatalf("Invalid snapshot: %v", err)
+	}
+	if data, err = ioutil.ReadFile(ctx.Args().Get(1)); err != nil {
+		utils.Fatalf("Failed to read genesis file: %v", err)
+	}
+	genesis := new(core.Genesis)
+	if err := json.Unmarshal(data, genesis); err != nil {
+		utils.Fatalf("invalid genesis file: %v", err)
+	}
+	if genesis.Config == nil {
+		utils.Fatalf("invalid genesis file: missing config")
+	}
+	genesis.Config.IsQuorum = getIsQuorum(bytes.NewReader(data))
+
+	alloc, permConfig, err := permission.ImportPermissions(&snapshot, genesis, common.HexToAddress(guardian))
+	if err != nil {
+		utils.Fatalf("Failed to import the permission snapshot: %v", err)
+	}
+	if genesis.Alloc == nil {
+		genesis.Alloc = make(core.GenesisAlloc)
+	}
+	for addr, account := range alloc {
+		genesis.Alloc[addr] = account
+	}
+
+	outDir := ctx.Args().Get(2)
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		utils.Fatalf("Failed to create the output directory: %v", err)
+	}
+	for name, v := range map[string]interface{}{
+		"genesis.json":                 genesis,
+		params.PERMISSION_MODEL_CONFIG: permConfig,
+	} {
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			utils.Fatalf("Failed to encode %s: %v", name, err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(outDir, name), out, 0644); err != nil {
+			utils.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return nil
+}
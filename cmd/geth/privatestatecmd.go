@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	privateStateFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Number of the first block to execute again",
+	}
+	privateStateBlockFlag = cli.Uint64Flag{
+		Name:  "block",
+		Usage: "Number of the block to inspect the private state at, the head of the chain if not set",
+	}
+	privateStateStorageFlag = cli.BoolFlag{
+		Name:  "storage",
+		Usage: "Include the storage slots in the report",
+	}
+	privateStateIdentifierFlag = cli.StringFlag{
+		Name:  "psi",
+		Usage: "Identifier of the private state to inspect",
+		Value: string(types.DefaultPrivateStateIdentifier),
+	}
+
+	privateStateCommand = cli.Command{
+		Name:     "private-state",
+		Usage:    "Manage the private state",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Commands to repair and inspect the private state kept by the node.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "rebuild",
+				Usage:  "Rebuild the private state from a block up to the head of the chain",
+				Action: utils.MigrateFlags(rebuildPrivateState),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.CacheFlag,
+					utils.GCModeFlag,
+					privateStateFromFlag,
+				},
+				Description: `
+    geth [--ptm.* flags] [--multitenancy.privatestates <states>] private-state rebuild --from <block>
+
+executes again the blocks of the chain from the given one up to the head, in
+order to rewrite the private state after it got corrupted, e.g. because the
+private transaction manager was unreachable while the blocks were imported.
+The private state before the first block is trusted.
+
+The public state is only read, which requires the node to have been run with
+--gcmode=archive. The private state of contracts extended to the node is not
+rebuilt as it isn't received from the private transaction manager.
+The node must not be running.`,
+			},
+			{
+				Name:      "hash",
+				Usage:     "Print the hash of the private storage of a contract",
+				ArgsUsage: "<address>",
+				Action:    utils.MigrateFlags(hashPrivateState),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.CacheFlag,
+					privateStateBlockFlag,
+					privateStateStorageFlag,
+					privateStateIdentifierFlag,
+				},
+				Description: `
+    geth private-state hash [--block <number>] [--storage] <address>
+
+prints a report of the private storage of a contract, including a deterministic
+hash of it, which participants to the contract compare to check that they agree
+on its state. The report of each participant must be taken at the same block.
+The report includes the storage slots with --storage, which can then be given
+to the diff command of another participant.
+The node must not be running.`,
+			},
+			{
+				Name:      "diff",
+				Usage:     "Compare the private storage of a contract with the report of another participant",
+				ArgsUsage: "<report file>",
+				Action:    utils.MigrateFlags(diffPrivateState),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.CacheFlag,
+					privateStateBlockFlag,
+					privateStateIdentifierFlag,
+				},
+				Description: `
+    geth private-state diff [--block <number>] <report file>
+
+compares the private storage of a contract with the report created by another
+participant with geth private-state hash --storage, or the eth_getPrivateStorageHash
+RPC, at the same block, and prints the storage slots whose value differ.
+The node must not be running.`,
+			},
+		},
+	}
+)
+
+func rebuildPrivateState(ctx *cli.Context) error {
+	if !ctx.IsSet(privateStateFromFlag.Name) {
+		utils.Fatalf("The --%s flag is required", privateStateFromFlag.Name)
+	}
+	stack, cfg := makeConfigNode(ctx)
+	defer stack.Close()
+
+	ptm, err := utils.MakePrivateTransactionManager(ctx, stack)
+	if err != nil {
+		utils.Fatalf("Failed to create the private transaction manager: %v", err)
+	}
+	if ptm == nil {
+		utils.Fatalf("A private transaction manager is required, see the --ptm.* flags")
+	}
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+	defer chain.Stop()
+
+	chain.SetPrivateTransactionManager(ptm)
+	chain.SetPrivateStates(cfg.Eth.PrivateStates)
+
+	start := time.Now()
+	if err := chain.RebuildPrivateState(ctx.Uint64(privateStateFromFlag.Name)); err != nil {
+		utils.Fatalf("Failed to rebuild the private state: %v", err)
+	}
+	fmt.Printf("Private state rebuilt in %v\n", time.Since(start))
+	return nil
+}
+
+// privateStorageReport creates the report of the private storage of the
+// contract at the block given by the flags
+func privateStorageReport(ctx *cli.Context, addr common.Address, includeStorage bool) *state.StorageReport {
+	stack, cfg := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+	defer chain.Stop()
+	chain.SetPrivateStates(cfg.Eth.PrivateStates)
+
+	header := chain.CurrentHeader()
+	if ctx.IsSet(privateStateBlockFlag.Name) {
+		if header = chain.GetHeaderByNumber(ctx.Uint64(privateStateBlockFlag.Name)); header == nil {
+			utils.Fatalf("Block %d not found", ctx.Uint64(privateStateBlockFlag.Name))
+		}
+	}
+	_, privateState, err := chain.StateAtOf(header.Root, types.PrivateStateIdentifier(ctx.String(privateStateIdentifierFlag.Name)))
+	if err != nil {
+		utils.Fatalf("Failed to open the private state of block %d: %v", header.Number, err)
+	}
+	storageTrie := privateState.StorageTrie(addr)
+	if storageTrie == nil {
+		utils.Fatalf("Contract %s not found in the private state of block %d", addr.Hex(), header.Number)
+	}
+	report, err := state.NewStorageReport(addr, privateState.GetCodeHash(addr), storageTrie, includeStorage)
+	if err != nil {
+		utils.Fatalf("Failed to read the private storage of %s: %v", addr.Hex(), err)
+	}
+	return report
+}
+
+func hashPrivateState(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 || !common.IsHexAddress(ctx.Args().First()) {
+		utils.Fatalf("This command requires the address of a contract as argument.")
+	}
+	report := privateStorageReport(ctx, common.HexToAddress(ctx.Args().First()), ctx.Bool(privateStateStorageFlag.Name))
+	return printJSON(report)
+}
+
+func diffPrivateState(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires the report file of another participant as argument.")
+	}
+	data, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to read the report: %v", err)
+	}
+	var remote state.StorageReport
+	if err := json.Unmarshal(data, &remote); err != nil {
+		utils.Fatalf("Invalid report: %v", err)
+	}
+	if remote.Storage == nil {
+		utils.Fatalf("The report doesn't include the storage slots, see the --%s flag of the hash command", privateStateStorageFlag.Name)
+	}
+	local := privateStorageReport(ctx, remote.Address, true)
+	if local.StorageRoot == remote.StorageRoot {
+		fmt.Printf("The private storage of %s matches, its hash is %s\n", remote.Address.Hex(), local.StorageRoot.Hex())
+		return nil
+	}
+	return printJSON(state.DiffStorage(local, &remote))
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -274,6 +274,7 @@ var AppHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			utils.QuorumImmutabilityThreshold,
 			utils.EnableNodePermissionFlag,
+			utils.EnableRPCPermissionFlag,
 			utils.PluginSettingsFlag,
 			utils.PluginSkipVerifyFlag,
 			utils.PluginLocalVerifyFlag,
@@ -281,6 +282,29 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.AllowedFutureBlockTimeFlag,
 		},
 	},
+	{
+		Name: "PRIVATE TRANSACTION MANAGER",
+		Flags: []cli.Flag{
+			utils.PtmConfigFlag,
+			utils.PtmUrlFlag,
+			utils.PtmSocketFlag,
+			utils.PtmTimeoutFlag,
+			utils.PtmDialTimeoutFlag,
+			utils.PtmTLSRootCAFlag,
+			utils.PtmTLSClientCertFlag,
+			utils.PtmTLSClientKeyFlag,
+			utils.PtmTLSInsecureSkipVerifyFlag,
+			utils.PtmRetriesFlag,
+			utils.PtmRetryBackoffFlag,
+			utils.PtmCircuitBreakerThresholdFlag,
+			utils.PtmCircuitBreakerCooldownFlag,
+			utils.PtmUpcheckIntervalFlag,
+			utils.PtmCacheSizeFlag,
+			utils.PtmCacheTTLFlag,
+			utils.PtmCachePersistFlag,
+			utils.MultitenancyPrivateStatesFlag,
+		},
+	},
 	{
 		Name: quorumAccountFlagGroup,
 		Flags: []cli.Flag{
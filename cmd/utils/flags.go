@@ -45,6 +45,7 @@ import (
 	istanbulBackend "github.com/ethereum/go-ethereum/consensus/istanbul/backend"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/dashboard"
@@ -69,7 +70,9 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/permission"
 	"github.com/ethereum/go-ethereum/plugin"
+	"github.com/ethereum/go-ethereum/plugin/privacy"
 	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/privatetransactionmanager"
 	"github.com/ethereum/go-ethereum/raft"
 	"github.com/ethereum/go-ethereum/rpc"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
@@ -829,6 +832,10 @@ var (
 		Name:  "permissioned",
 		Usage: "If enabled, the node will allow only a defined list of nodes to connect",
 	}
+	EnableRPCPermissionFlag = cli.BoolFlag{
+		Name:  "permissioned.rpc",
+		Usage: "If enabled, authenticated RPC callers are also authorized against the org status and role of the permission account granted to them",
+	}
 	AllowedFutureBlockTimeFlag = cli.Uint64Flag{
 		Name:  "allowedfutureblocktime",
 		Usage: "Max time (in seconds) from current time allowed for blocks, before they're considered future blocks",
@@ -856,6 +863,80 @@ var (
 		Name:  "plugins.account.config",
 		Usage: "Value will be passed to an account plugin if being used.  See the account plugin implementation's documentation for further details",
 	}
+	// Private transaction manager settings
+	PtmConfigFlag = cli.StringFlag{
+		Name:  "ptm.config",
+		Usage: "Path to the TOML configuration file of the private transaction manager client. Other --ptm.* flags override its values",
+	}
+	PtmUrlFlag = cli.StringFlag{
+		Name:  "ptm.url",
+		Usage: "URL of the private transaction manager (http:// or https://)",
+	}
+	PtmSocketFlag = cli.StringFlag{
+		Name:  "ptm.socket",
+		Usage: "Path to the IPC socket of the private transaction manager",
+	}
+	PtmTimeoutFlag = cli.UintFlag{
+		Name:  "ptm.timeout",
+		Usage: "Timeout in seconds for requests to the private transaction manager or the privacy plugin (0 = default)",
+	}
+	PtmDialTimeoutFlag = cli.UintFlag{
+		Name:  "ptm.dialtimeout",
+		Usage: "Timeout in seconds for connecting to the private transaction manager (0 = default)",
+	}
+	PtmTLSRootCAFlag = cli.StringFlag{
+		Name:  "ptm.tls.rootca",
+		Usage: "Path to the root CA certificate used to verify the private transaction manager",
+	}
+	PtmTLSClientCertFlag = cli.StringFlag{
+		Name:  "ptm.tls.clientcert",
+		Usage: "Path to the client certificate used for mutual TLS, requires --ptm.tls.clientkey",
+	}
+	PtmTLSClientKeyFlag = cli.StringFlag{
+		Name:  "ptm.tls.clientkey",
+		Usage: "Path to the client private key used for mutual TLS",
+	}
+	PtmTLSInsecureSkipVerifyFlag = cli.BoolFlag{
+		Name:  "ptm.tls.insecureskipverify",
+		Usage: "If enabled, the certificate of the private transaction manager is NOT verified",
+	}
+	PtmRetriesFlag = cli.UintFlag{
+		Name:  "ptm.retries",
+		Usage: "Number of times a request failing with a transport error or a server error is retried",
+	}
+	PtmRetryBackoffFlag = cli.UintFlag{
+		Name:  "ptm.retrybackoff",
+		Usage: "Delay in milliseconds before the first retry, doubled for every following one (0 = default)",
+	}
+	PtmCircuitBreakerThresholdFlag = cli.UintFlag{
+		Name:  "ptm.circuitbreaker.threshold",
+		Usage: "Number of consecutive failed requests after which requests fail fast (0 = disabled)",
+	}
+	PtmCircuitBreakerCooldownFlag = cli.UintFlag{
+		Name:  "ptm.circuitbreaker.cooldown",
+		Usage: "Time in seconds requests fail fast before the private transaction manager is tried again (0 = default)",
+	}
+	PtmUpcheckIntervalFlag = cli.UintFlag{
+		Name:  "ptm.upcheckinterval",
+		Usage: "Interval in seconds at which the private transaction manager is probed (0 = disabled)",
+	}
+	PtmCacheSizeFlag = cli.UintFlag{
+		Name:  "ptm.cache.size",
+		Usage: "Megabytes of memory used to cache private payloads (0 = default)",
+	}
+	PtmCacheTTLFlag = cli.UintFlag{
+		Name:  "ptm.cache.ttl",
+		Usage: "Time in seconds private payloads are kept in the memory cache (0 = default)",
+	}
+	PtmCachePersistFlag = cli.BoolFlag{
+		Name:  "ptm.cache.persist",
+		Usage: "Keep received private payloads in the chain database so they aren't fetched again on re-import",
+	}
+	// Multitenancy settings
+	MultitenancyPrivateStatesFlag = cli.StringFlag{
+		Name:  "multitenancy.privatestates",
+		Usage: "Private states kept besides the default one, each of them with the private transaction manager keys of its tenant (e.g. tenantA=key1,key2;tenantB=key3). Callers are granted one with a psi://<name> authority",
+	}
 	// Istanbul settings
 	IstanbulRequestTimeoutFlag = cli.Uint64Flag{
 		Name:  "istanbul.requesttimeout",
@@ -1314,6 +1395,9 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(EnableNodePermissionFlag.Name) {
 		cfg.EnableNodePermission = ctx.GlobalBool(EnableNodePermissionFlag.Name)
 	}
+	if ctx.GlobalIsSet(EnableRPCPermissionFlag.Name) {
+		cfg.EnableRPCPermission = ctx.GlobalBool(EnableRPCPermissionFlag.Name)
+	}
 
 }
 
@@ -1543,6 +1627,49 @@ func setRaft(ctx *cli.Context, cfg *eth.Config) {
 	cfg.RaftMode = ctx.GlobalBool(RaftModeFlag.Name)
 }
 
+func setPrivateStates(ctx *cli.Context, cfg *eth.Config) {
+	if !ctx.GlobalIsSet(MultitenancyPrivateStatesFlag.Name) {
+		return
+	}
+	privateStates, err := parsePrivateStates(ctx.GlobalString(MultitenancyPrivateStatesFlag.Name))
+	if err != nil {
+		Fatalf("Invalid --%s: %v", MultitenancyPrivateStatesFlag.Name, err)
+	}
+	cfg.PrivateStates = privateStates
+}
+
+// parsePrivateStates parses private states formatted as name=key1,key2;name=key3
+func parsePrivateStates(value string) (map[types.PrivateStateIdentifier][]string, error) {
+	privateStates := make(map[types.PrivateStateIdentifier][]string)
+	for _, entry := range strings.Split(value, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		psi := types.PrivateStateIdentifier(strings.TrimSpace(parts[0]))
+		switch {
+		case len(parts) != 2 || psi == "":
+			return nil, fmt.Errorf("%q is not of the form name=key1,key2", entry)
+		case psi == types.DefaultPrivateStateIdentifier || psi == types.EmptyPrivateStateIdentifier:
+			return nil, fmt.Errorf("private state name %s is reserved", psi)
+		}
+		if _, ok := privateStates[psi]; ok {
+			return nil, fmt.Errorf("private state %s is configured more than once", psi)
+		}
+		var keys []string
+		for _, key := range strings.Split(parts[1], ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("private state %s has no key", psi)
+		}
+		privateStates[psi] = keys
+	}
+	return privateStates, nil
+}
+
 // CheckExclusive verifies that only a single instance of the provided flags was
 // set by the user. Each flag might optionally be followed by a string type to
 // specialize it further.
@@ -1619,6 +1746,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	// Quorum
 	setIstanbul(ctx, cfg)
 	setRaft(ctx, cfg)
+	setPrivateStates(ctx, cfg)
 
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
@@ -1819,6 +1947,102 @@ func RegisterPluginService(stack *node.Node, cfg *node.Config, skipVerify bool,
 	}
 }
 
+// Quorum
+//
+// MakePrivateTransactionManager creates the private transaction manager of the
+// node. In order of precedence it is provided by the privacy plugin, configured
+// with the --ptm.* flags or configured with the deprecated PRIVATE_CONFIG
+// environment variable. It returns nil if none of them is set.
+func MakePrivateTransactionManager(ctx *cli.Context, stack *node.Node) (private.PrivateTransactionManager, error) {
+	if plugins := stack.Config().Plugins; plugins != nil {
+		if _, ok := plugins.GetPluginDefinition(plugin.PrivacyPluginInterfaceName); ok {
+			log.Info("Using private transaction manager provided by the privacy plugin")
+			timeout := time.Duration(ctx.GlobalUint(PtmTimeoutFlag.Name)) * time.Second
+			return privacyPluginTransactionManager(stack, timeout), nil
+		}
+	}
+	cfg, err := makePtmConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cfg != nil {
+		return privatetransactionmanager.NewFromConfig(cfg)
+	}
+	if os.Getenv("PRIVATE_CONFIG") != "" {
+		log.Warn("The PRIVATE_CONFIG environment variable is deprecated, use the --ptm.* flags instead")
+		return private.FromEnvironmentOrNil("PRIVATE_CONFIG"), nil
+	}
+	return nil, nil
+}
+
+// makePtmConfig returns the private transaction manager client configuration
+// given by the --ptm.* flags, nil if none of them is set
+func makePtmConfig(ctx *cli.Context) (*privatetransactionmanager.Config, error) {
+	cfg := &privatetransactionmanager.Config{}
+	isSet := false
+	if file := ctx.GlobalString(PtmConfigFlag.Name); file != "" {
+		loaded, err := privatetransactionmanager.LoadConfig(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load %s: %v", file, err)
+		}
+		cfg, isSet = loaded, true
+	}
+	setString := func(flag cli.StringFlag, v *string) {
+		if ctx.GlobalIsSet(flag.Name) {
+			*v, isSet = ctx.GlobalString(flag.Name), true
+		}
+	}
+	setUint := func(flag cli.UintFlag, v *uint) {
+		if ctx.GlobalIsSet(flag.Name) {
+			*v, isSet = ctx.GlobalUint(flag.Name), true
+		}
+	}
+	setString(PtmUrlFlag, &cfg.HttpUrl)
+	setString(PtmSocketFlag, &cfg.Socket)
+	setString(PtmTLSRootCAFlag, &cfg.TLSRootCA)
+	setString(PtmTLSClientCertFlag, &cfg.TLSClientCert)
+	setString(PtmTLSClientKeyFlag, &cfg.TLSClientKey)
+	setUint(PtmTimeoutFlag, &cfg.RequestTimeout)
+	setUint(PtmDialTimeoutFlag, &cfg.DialTimeout)
+	setUint(PtmRetriesFlag, &cfg.MaxRetries)
+	setUint(PtmRetryBackoffFlag, &cfg.RetryBackoff)
+	setUint(PtmCircuitBreakerThresholdFlag, &cfg.CircuitBreakerThreshold)
+	setUint(PtmCircuitBreakerCooldownFlag, &cfg.CircuitBreakerCooldown)
+	setUint(PtmUpcheckIntervalFlag, &cfg.UpcheckInterval)
+	setUint(PtmCacheSizeFlag, &cfg.CacheSize)
+	setUint(PtmCacheTTLFlag, &cfg.CacheTTL)
+	if ctx.GlobalIsSet(PtmTLSInsecureSkipVerifyFlag.Name) {
+		cfg.TLSInsecureSkipVerify, isSet = ctx.GlobalBool(PtmTLSInsecureSkipVerifyFlag.Name), true
+	}
+	if ctx.GlobalIsSet(PtmCachePersistFlag.Name) {
+		cfg.CachePersist, isSet = ctx.GlobalBool(PtmCachePersistFlag.Name), true
+	}
+	if !isSet {
+		return nil, nil
+	}
+	return cfg, nil
+}
+
+// privacyPluginTransactionManager returns a private transaction manager backed
+// by the privacy plugin, whose calls are cancelled after timeout. Plugins are
+// only available once the node is started so the plugin is looked up on every
+// call.
+func privacyPluginTransactionManager(stack *node.Node, timeout time.Duration) private.PrivateTransactionManager {
+	return &privacy.ReloadablePrivateTransactionManager{
+		DeferFunc: func() (privacy.PrivateTransactionManager, error) {
+			pm := stack.PluginManager()
+			if pm == nil {
+				return nil, errors.New("plugin manager is not started")
+			}
+			pp := new(plugin.PrivacyPluginTemplate)
+			if err := pm.GetPluginTemplate(plugin.PrivacyPluginInterfaceName, pp); err != nil {
+				return nil, err
+			}
+			return pp.Get(timeout)
+		},
+	}
+}
+
 // Configure smart-contract-based permissioning service
 func RegisterPermissionService(stack *node.Node) {
 	if err := stack.Register(func(sctx *node.ServiceContext) (node.Service, error) {
@@ -1889,7 +2113,7 @@ func RegisterRaftService(stack *node.Node, ctx *cli.Context, nodeCfg *node.Confi
 
 func RegisterExtensionService(stack *node.Node, ethChan chan *eth.Ethereum) {
 	registerFunc := func(ctx *node.ServiceContext) (node.Service, error) {
-		factory, err := extension.NewServicesFactory(stack, private.P, <-ethChan)
+		factory, err := extension.NewServicesFactory(stack, stack.PrivateTransactionManager(), <-ethChan)
 		if err != nil {
 			return nil, err
 		}
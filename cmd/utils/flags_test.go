@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/urfave/cli.v1"
@@ -106,6 +107,66 @@ func verifyErrorMessage(t *testing.T, ctx *cli.Context, cfg *node.Config, expect
 	assert.EqualError(t, err, expectedMsg)
 }
 
+func TestMakePtmConfig_whenNotSet(t *testing.T) {
+	arbitraryCLIContext := cli.NewContext(nil, &flag.FlagSet{}, nil)
+
+	cfg, err := makePtmConfig(arbitraryCLIContext)
+
+	assert.NoError(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestMakePtmConfig_whenFlagsOverrideConfigFile(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "q-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.WriteString("socket = \"tm.ipc\"\nworkdir = \"/qdata\"\nrequestTimeout = 10\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = tmpFile.Close()
+	fs := &flag.FlagSet{}
+	fs.String(PtmConfigFlag.Name, "", "")
+	fs.String(PtmUrlFlag.Name, "", "")
+	fs.String(PtmTLSRootCAFlag.Name, "", "")
+	fs.Uint(PtmTimeoutFlag.Name, 0, "")
+	arbitraryCLIContext := cli.NewContext(nil, fs, nil)
+	assert.NoError(t, arbitraryCLIContext.GlobalSet(PtmConfigFlag.Name, tmpFile.Name()))
+	assert.NoError(t, arbitraryCLIContext.GlobalSet(PtmUrlFlag.Name, "https://tessera:9101"))
+	assert.NoError(t, arbitraryCLIContext.GlobalSet(PtmTLSRootCAFlag.Name, "/certs/ca.pem"))
+	assert.NoError(t, arbitraryCLIContext.GlobalSet(PtmTimeoutFlag.Name, "20"))
+
+	cfg, err := makePtmConfig(arbitraryCLIContext)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tm.ipc", cfg.Socket)
+	assert.Equal(t, "/qdata", cfg.WorkDir)
+	assert.Equal(t, "https://tessera:9101", cfg.HttpUrl)
+	assert.Equal(t, "/certs/ca.pem", cfg.TLSRootCA)
+	assert.Equal(t, uint(20), cfg.RequestTimeout)
+}
+
+func TestParsePrivateStates_whenTypical(t *testing.T) {
+	privateStates, err := parsePrivateStates("tenantA=key1, key2;tenantB=key3;")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[types.PrivateStateIdentifier][]string{
+		"tenantA": {"key1", "key2"},
+		"tenantB": {"key3"},
+	}, privateStates)
+}
+
+func TestParsePrivateStates_whenInvalid(t *testing.T) {
+	for _, value := range []string{"tenantA", "tenantA=", "=key1", "private=key1", "tenantA=key1;tenantA=key2"} {
+		_, err := parsePrivateStates(value)
+
+		assert.Error(t, err, value)
+	}
+}
+
 func Test_SplitTagsFlag(t *testing.T) {
 	tests := []struct {
 		name string
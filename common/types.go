@@ -52,6 +52,36 @@ type EncryptedPayloadHash [EncryptedPayloadHashLength]byte
 // Using map to enable fast lookup
 type EncryptedPayloadHashes map[EncryptedPayloadHash]struct{}
 
+// Base64sToEncryptedPayloadHashes decodes a list of base64 encoded hashes
+func Base64sToEncryptedPayloadHashes(b64s []string) (EncryptedPayloadHashes, error) {
+	hashes := make(EncryptedPayloadHashes)
+	for _, b64 := range b64s {
+		data, err := Base64ToEncryptedPayloadHash(b64)
+		if err != nil {
+			return nil, err
+		}
+		hashes.Add(data)
+	}
+	return hashes, nil
+}
+
+func (h EncryptedPayloadHashes) ToBase64s() []string {
+	a := make([]string, 0, len(h))
+	for eph := range h {
+		a = append(a, eph.ToBase64())
+	}
+	return a
+}
+
+func (h EncryptedPayloadHashes) Add(eph EncryptedPayloadHash) {
+	h[eph] = struct{}{}
+}
+
+func (h EncryptedPayloadHashes) NotExist(eph EncryptedPayloadHash) bool {
+	_, ok := h[eph]
+	return !ok
+}
+
 // BytesToEncryptedPayloadHash sets b to EncryptedPayloadHash.
 // If b is larger than len(h), b will be cropped from the left.
 func BytesToEncryptedPayloadHash(b []byte) EncryptedPayloadHash {
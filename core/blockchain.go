@@ -41,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	lru "github.com/hashicorp/golang-lru"
@@ -174,24 +175,51 @@ type BlockChain struct {
 	processor  Processor  // Block transaction processor interface
 	vmConfig   vm.Config
 
-	badBlocks       *lru.Cache                         // Bad block cache
-	shouldPreserve  func(*types.Block) bool            // Function used to determine whether should preserve the given block.
-	terminateInsert func(common.Hash, uint64) bool     // Testing hook used to terminate ancient receipt chain insertion.
-	setPrivateState func([]*types.Log, *state.StateDB) // Function to check extension and set private state
+	badBlocks       *lru.Cache                               // Bad block cache
+	shouldPreserve  func(*types.Block) bool                  // Function used to determine whether should preserve the given block.
+	terminateInsert func(common.Hash, uint64) bool           // Testing hook used to terminate ancient receipt chain insertion.
+	setPrivateState func([]*types.Log, *state.StateDB) error // Function to check extension and set private state
+	ptm             private.PrivateTransactionManager        // Quorum: private transaction manager used to process private transactions
 
-	privateStateCache state.Database // Private state database to reuse between imports (contains state cache)
+	privateStateCache state.Database                            // Private state database to reuse between imports (contains state cache)
+	privateStates     map[types.PrivateStateIdentifier][]string // Quorum: private states kept besides the default one with the keys of their tenant
+	rawPayloadSenders *lru.Cache                                // Quorum: keys of the tenants which stored the most recent raw private payloads
+	privateLogsFeed   event.Feed                                // Quorum: logs as seen from the private states other than the default one
 }
 
 // function pointer for updating private state
-func (bc *BlockChain) PopulateSetPrivateState(ps func([]*types.Log, *state.StateDB)) {
+func (bc *BlockChain) PopulateSetPrivateState(ps func([]*types.Log, *state.StateDB) error) {
 	bc.setPrivateState = ps
 }
 
-// function to update the private state as a part contract state extension
-func (bc *BlockChain) CheckAndSetPrivateState(txLogs []*types.Log, privateState *state.StateDB) {
+// Quorum
+//
+// SetPrivateTransactionManager sets the private transaction manager used to
+// retrieve the payloads of private transactions. It must be set before any
+// private transaction is processed.
+func (bc *BlockChain) SetPrivateTransactionManager(ptm private.PrivateTransactionManager) {
+	bc.ptm = ptm
+}
+
+// Quorum
+//
+// PrivateTransactionManager returns the private transaction manager of the
+// chain, nil if none is configured. A nil chain, as used when generating
+// blocks, has none.
+func (bc *BlockChain) PrivateTransactionManager() private.PrivateTransactionManager {
+	if bc == nil {
+		return nil
+	}
+	return bc.ptm
+}
+
+// function to update the private state as a part contract state extension,
+// an error means the logs couldn't be checked and the block can't be processed
+func (bc *BlockChain) CheckAndSetPrivateState(txLogs []*types.Log, privateState *state.StateDB) error {
 	if bc.setPrivateState != nil {
-		bc.setPrivateState(txLogs, privateState)
+		return bc.setPrivateState(txLogs, privateState)
 	}
+	return nil
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -212,6 +240,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 	txLookupCache, _ := lru.New(txLookupCacheLimit)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
 	badBlocks, _ := lru.New(badBlockLimit)
+	rawPayloadSenders, _ := lru.New(rawPayloadSendersLimit)
 
 	bc := &BlockChain{
 		chainConfig:       chainConfig,
@@ -231,6 +260,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		vmConfig:          vmConfig,
 		badBlocks:         badBlocks,
 		privateStateCache: state.NewDatabase(db),
+		rawPayloadSenders: rawPayloadSenders,
 	}
 	bc.validator = NewBlockValidator(chainConfig, bc, engine)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc, engine)
@@ -1347,6 +1377,12 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	}
 	// Make sure no inconsistent state is leaked during insertion
 	// Quorum
+	// Execute the block on the private states of the tenants of the node
+	// before anything is written, so that a failure leaves no partial block
+	privateStateResults, err := bc.processPrivateStates(block)
+	if err != nil {
+		return NonStatTy, err
+	}
 	// Write private state changes to database
 	privateRoot, err := privateState.Commit(bc.chainConfig.IsEIP158(block.Number()))
 	if err != nil {
@@ -1361,6 +1397,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	if err := privateTriedb.Commit(privateRoot, false); err != nil {
 		return NonStatTy, err
 	}
+	if err := bc.writePrivateStates(block, privateStateResults); err != nil {
+		return NonStatTy, err
+	}
 	// /Quorum
 
 	currentBlock := bc.CurrentBlock()
@@ -1759,6 +1798,12 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, []
 				}(time.Now())
 			}
 		}
+		// Quorum: receive the payloads of the block in one batch before
+		// processing it. The payloads received while it was the followup of
+		// the previous block are cached, and the ones still in flight are
+		// waited for rather than fetched again.
+		prefetchPrivatePayloads(bc.PrivateTransactionManager(), bc.chainConfig, block)
+
 		// Process block using the parent state as reference point
 		substart := time.Now()
 		receipts, privateReceipts, logs, usedGas, err := bc.processor.Process(block, statedb, privateState, bc.vmConfig)
@@ -2009,6 +2054,10 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 		deletedLogs []*types.Log
 		rebirthLogs []*types.Log
 
+		// Quorum: logs as seen from the private states other than the default one
+		deletedPrivateLogs = make(map[types.PrivateStateIdentifier][]*types.Log)
+		rebirthPrivateLogs = make(map[types.PrivateStateIdentifier][]*types.Log)
+
 		// collectLogs collects the logs that were generated during the
 		// processing of the block that corresponds with the given hash.
 		// These logs are later announced as deleted or reborn
@@ -2029,6 +2078,14 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 					}
 				}
 			}
+			// Quorum
+			for psi, logs := range bc.privateStateLogs(hash, removed) {
+				if removed {
+					deletedPrivateLogs[psi] = append(deletedPrivateLogs[psi], logs...)
+				} else {
+					rebirthPrivateLogs[psi] = append(rebirthPrivateLogs[psi], logs...)
+				}
+			}
 		}
 	)
 	// Reduce the longer chain to the same number as the shorter one
@@ -2135,6 +2192,13 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 		if len(rebirthLogs) > 0 {
 			bc.logsFeed.Send(rebirthLogs)
 		}
+		// Quorum
+		for psi, logs := range deletedPrivateLogs {
+			bc.privateLogsFeed.Send(PrivateStateLogsEvent{PSI: psi, Logs: logs, Removed: true})
+		}
+		for psi, logs := range rebirthPrivateLogs {
+			bc.privateLogsFeed.Send(PrivateStateLogsEvent{PSI: psi, Logs: logs})
+		}
 		if len(oldChain) > 0 {
 			for _, block := range oldChain {
 				bc.chainSideFeed.Send(ChainSideEvent{Block: block})
@@ -2155,6 +2219,10 @@ func (bc *BlockChain) PostChainEvents(events []interface{}, logs []*types.Log) {
 	for _, event := range events {
 		switch ev := event.(type) {
 		case ChainEvent:
+			// Quorum
+			for psi, logs := range bc.privateStateLogs(ev.Hash, false) {
+				bc.privateLogsFeed.Send(PrivateStateLogsEvent{PSI: psi, Logs: logs})
+			}
 			bc.chainFeed.Send(ev)
 
 		case ChainHeadEvent:
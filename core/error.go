@@ -39,4 +39,28 @@ var (
 	// Quorum
 	// ErrAbortBlocksProcessing is returned if bc.insertChain is interrupted under raft mode
 	ErrAbortBlocksProcessing = errors.New("abort during blocks processing")
+
+	// ErrNoPrivateTransactionManager is returned if a private transaction is
+	// processed without a private transaction manager being configured.
+	ErrNoPrivateTransactionManager = errors.New("private transaction manager is not configured")
+
+	// ErrPrivacyFlagMismatch is returned if a private transaction affects a
+	// contract created with a different privacy flag.
+	ErrPrivacyFlagMismatch = errors.New("privacy flag doesn't match the affected contract")
+
+	// ErrNotContractParty is returned if a party protected transaction doesn't
+	// prove that its sender is a party to an affected contract.
+	ErrNotContractParty = errors.New("sender is not a party to the affected contract")
+
+	// ErrParticipantsMismatch is returned if the participants of a state
+	// validated transaction differ from those of an affected contract.
+	ErrParticipantsMismatch = errors.New("participants don't match the affected contract")
+
+	// ErrNoMandatoryRecipients is returned if a transaction sent with the
+	// mandatory recipients privacy flag doesn't list any mandatory recipient.
+	ErrNoMandatoryRecipients = errors.New("mandatory recipients privacy flag requires mandatory recipients")
+
+	// ErrMandatoryRecipientsMissing is returned if a mandatory recipient of
+	// an affected contract isn't party to the transaction.
+	ErrMandatoryRecipientsMissing = errors.New("mandatory recipient of the affected contract is not party to the transaction")
 )
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Quorum
+//
+// RebuildPrivateState executes again the canonical blocks from the given
+// number up to the head in order to rewrite their private state roots, receipts
+// and blooms, e.g. after the private transaction manager was unreachable while
+// they were imported. The private state of the parent of the first block is
+// trusted. The public state is only read, which requires the public state of
+// every block to be available, as is the case for archive nodes.
+func (bc *BlockChain) RebuildPrivateState(from uint64) error {
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+
+	if from == 0 {
+		// the genesis block has no transaction to execute
+		from = 1
+	}
+	head := bc.CurrentBlock().NumberU64()
+	if from > head {
+		return fmt.Errorf("block %d is after the head of the chain %d", from, head)
+	}
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for number := from; number <= head; number++ {
+		block := bc.GetBlockByNumber(number)
+		if block == nil {
+			return fmt.Errorf("block %d is missing", number)
+		}
+		parent := bc.GetBlock(block.ParentHash(), number-1)
+		if parent == nil {
+			return fmt.Errorf("parent of block %d is missing", number)
+		}
+		publicState, err := state.New(parent.Root(), bc.stateCache)
+		if err != nil {
+			return fmt.Errorf("public state of block %d is not available, rebuilding requires an archive node: %v", number-1, err)
+		}
+		privateState, err := state.New(rawdb.GetPrivateStateRoot(bc.db, parent.Root()), bc.privateStateCache)
+		if err != nil {
+			return fmt.Errorf("private state of block %d is not available: %v", number-1, err)
+		}
+		prefetchPrivatePayloads(bc.PrivateTransactionManager(), bc.chainConfig, block)
+
+		receipts, privateReceipts, _, usedGas, err := bc.processor.Process(block, publicState, privateState, bc.vmConfig)
+		if err != nil {
+			return fmt.Errorf("block %d: %v", number, err)
+		}
+		// the execution must result in the public state which was imported
+		if err := bc.validator.ValidateState(block, parent, publicState, receipts, usedGas); err != nil {
+			return fmt.Errorf("block %d: %v", number, err)
+		}
+		privateStateResults, err := bc.processPrivateStates(block)
+		if err != nil {
+			return fmt.Errorf("block %d: %v", number, err)
+		}
+		privateRoot, err := privateState.Commit(bc.chainConfig.IsEIP158(block.Number()))
+		if err != nil {
+			return err
+		}
+		if err := bc.privateStateCache.TrieDB().Commit(privateRoot, false); err != nil {
+			return err
+		}
+		if err := rawdb.WritePrivateStateRoot(bc.db, block.Root(), privateRoot); err != nil {
+			return err
+		}
+		rawdb.WriteReceipts(bc.db, block.Hash(), number, mergeReceipts(receipts, privateReceipts))
+		if err := rawdb.WritePrivateBlockBloom(bc.db, number, privateReceipts); err != nil {
+			return err
+		}
+		if err := bc.writePrivateStates(block, privateStateResults); err != nil {
+			return err
+		}
+		if time.Since(logged) > statsReportLimit || number == head {
+			log.Info("Rebuilt private state", "number", number, "head", head, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	bc.receiptsCache.Purge()
+	return nil
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func newArchiveTestChain(t *testing.T, n int) (*BlockChain, []common.Hash) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		genesis = new(Genesis).MustCommit(db)
+		archive = &CacheConfig{TrieCleanLimit: 256, TrieDirtyLimit: 256, TrieTimeLimit: 5 * time.Minute, TrieDirtyDisabled: true}
+	)
+	chain, err := NewBlockChain(db, archive, params.AllEthashProtocolChanges, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, _ := GenerateChain(params.AllEthashProtocolChanges, genesis, ethash.NewFaker(), db, n, nil)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	roots := make([]common.Hash, len(blocks))
+	for i, block := range blocks {
+		roots[i] = block.Root()
+	}
+	return chain, roots
+}
+
+func TestRebuildPrivateState(t *testing.T) {
+	chain, roots := newArchiveTestChain(t, 4)
+	defer chain.Stop()
+	want := rawdb.GetPrivateStateRoot(chain.db, roots[2])
+	if err := rawdb.WritePrivateStateRoot(chain.db, roots[2], common.HexToHash("0xbad")); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, chain.RebuildPrivateState(2))
+
+	assert.Equal(t, want, rawdb.GetPrivateStateRoot(chain.db, roots[2]))
+}
+
+// insertPrivateTestBlock executes the transactions on top of the head of the
+// chain, as a minter would, and inserts the resulting block. Blocks can't be
+// generated with GenerateChain as it applies private transactions to the
+// public state.
+func insertPrivateTestBlock(t *testing.T, chain *BlockChain, txs types.Transactions) *types.Block {
+	parent := chain.CurrentBlock()
+	publicState, privateState, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := makeHeader(chain, parent, publicState, chain.Engine())
+	receipts, _, _, usedGas, err := chain.Processor().Process(types.NewBlock(header, txs, nil, nil), publicState, privateState, vm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.GasUsed = usedGas
+	header.Root = publicState.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+	block := types.NewBlock(header, txs, nil, receipts)
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestRebuildPrivateState_whenPrivateTransaction(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		_       = (&Genesis{Config: params.QuorumTestChainConfig}).MustCommit(db)
+		archive = &CacheConfig{TrieCleanLimit: 256, TrieDirtyLimit: 256, TrieTimeLimit: 5 * time.Minute, TrieDirtyDisabled: true}
+		key, _  = crypto.GenerateKey()
+		// stores 42 in the first slot of the created contract
+		initCode = common.FromHex("602a60005500")
+	)
+	chain, err := NewBlockChain(db, archive, params.QuorumTestChainConfig, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+	chain.SetPrivateTransactionManager(&StubPrivateTransactionManager{
+		responses: map[string][]interface{}{
+			"Receive": {initCode, nil},
+		},
+	})
+	tx, err := types.SignTx(types.NewContractCreation(0, big.NewInt(0), 100000, big.NewInt(0), common.BytesToEncryptedPayloadHash(initCode).Bytes()), types.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.SetPrivate()
+	block := insertPrivateTestBlock(t, chain, types.Transactions{tx})
+	insertPrivateTestBlock(t, chain, nil)
+	contract := crypto.CreateAddress(crypto.PubkeyToAddress(key.PublicKey), 0)
+	want := rawdb.GetPrivateStateRoot(db, block.Root())
+	wantReceipts := rawdb.ReadReceipts(db, block.Hash(), block.NumberU64(), chain.Config())
+	if assert.Len(t, wantReceipts, 1) {
+		assert.Equal(t, types.ReceiptStatusSuccessful, wantReceipts[0].Status)
+		assert.Equal(t, contract, wantReceipts[0].ContractAddress)
+	}
+	if err := rawdb.WritePrivateStateRoot(db, block.Root(), common.HexToHash("0xbad")); err != nil {
+		t.Fatal(err)
+	}
+	rawdb.DeleteReceipts(db, block.Hash(), block.NumberU64())
+
+	assert.NoError(t, chain.RebuildPrivateState(block.NumberU64()))
+
+	assert.Equal(t, want, rawdb.GetPrivateStateRoot(db, block.Root()))
+	assert.Equal(t, wantReceipts, rawdb.ReadReceipts(db, block.Hash(), block.NumberU64(), chain.Config()))
+	_, privateState, err := chain.StateAt(block.Root())
+	if assert.NoError(t, err) {
+		assert.Equal(t, common.BigToHash(big.NewInt(42)), privateState.GetState(contract, common.Hash{}))
+	}
+}
+
+func TestRebuildPrivateState_whenAfterHead(t *testing.T) {
+	chain, _ := newArchiveTestChain(t, 2)
+	defer chain.Stop()
+
+	assert.EqualError(t, chain.RebuildPrivateState(3), "block 3 is after the head of the chain 2")
+}
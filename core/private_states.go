@@ -0,0 +1,367 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// rawPayloadSendersLimit is the number of raw private payloads stored by the
+// tenants whose sender key is kept until they are sent
+const rawPayloadSendersLimit = 1024
+
+var (
+	// ErrNoPrivateStateKeys is returned when sending a private payload from a
+	// private state which has no keys in the private transaction manager
+	ErrNoPrivateStateKeys = errors.New("private state has no private transaction manager keys")
+	// ErrRawPayloadNotStored is returned when sending a raw private payload
+	// which wasn't stored from the private state sending it
+	ErrRawPayloadNotStored = errors.New("raw private payload not stored from the private state")
+)
+
+// Quorum
+//
+// SetPrivateStates configures the private states kept besides the default one,
+// each of them being the private state of a tenant of the node identified by
+// its keys in the private transaction manager. It must be set before any block
+// is processed.
+func (bc *BlockChain) SetPrivateStates(privateStates map[types.PrivateStateIdentifier][]string) {
+	bc.privateStates = privateStates
+}
+
+// Quorum
+//
+// HasPrivateState returns whether the private state is kept by the node
+func (bc *BlockChain) HasPrivateState(psi types.PrivateStateIdentifier) bool {
+	if psi == types.DefaultPrivateStateIdentifier || psi == types.EmptyPrivateStateIdentifier {
+		return true
+	}
+	_, ok := bc.privateStates[psi]
+	return ok
+}
+
+// Quorum
+//
+// PrivateTransactionManagerOf returns the private transaction manager as seen
+// from the given private state. Except for the default private state, it only
+// reveals the payloads of the private transactions sent to the keys of the
+// tenant, hence none for the empty private state, and only sends payloads from
+// these keys. nil if not configured.
+func (bc *BlockChain) PrivateTransactionManagerOf(psi types.PrivateStateIdentifier) private.PrivateTransactionManager {
+	if psi == types.DefaultPrivateStateIdentifier || bc.ptm == nil {
+		return bc.ptm
+	}
+	return &privateStatePrivateTransactionManager{PrivateTransactionManager: bc.ptm, keys: bc.privateStates[psi], rawSenders: bc.rawPayloadSenders}
+}
+
+// Quorum
+//
+// StateAtOf returns a new mutable public state and the given private state
+// based on a particular point in time
+func (bc *BlockChain) StateAtOf(root common.Hash, psi types.PrivateStateIdentifier) (*state.StateDB, *state.StateDB, error) {
+	if !bc.HasPrivateState(psi) {
+		return nil, nil, fmt.Errorf("private state %s is not kept by this node", psi)
+	}
+	publicStateDb, err := state.New(root, bc.stateCache)
+	if err != nil {
+		return nil, nil, err
+	}
+	var privateRoot common.Hash
+	if psi != types.EmptyPrivateStateIdentifier {
+		privateRoot = rawdb.GetPrivateStateRootOf(bc.db, psi, root)
+	}
+	privateStateDb, err := state.New(privateRoot, bc.privateStateCache)
+	if err != nil {
+		return nil, nil, err
+	}
+	return publicStateDb, privateStateDb, nil
+}
+
+// Quorum
+//
+// GetReceiptsByHashOf retrieves the receipts of all the transactions of a block
+// as seen from the given private state. The private transactions of the empty
+// private state only have their public receipt.
+func (bc *BlockChain) GetReceiptsByHashOf(hash common.Hash, psi types.PrivateStateIdentifier) types.Receipts {
+	switch psi {
+	case types.DefaultPrivateStateIdentifier:
+		return bc.GetReceiptsByHash(hash)
+	case types.EmptyPrivateStateIdentifier:
+		block := bc.GetBlockByHash(hash)
+		receipts := bc.GetReceiptsByHash(hash)
+		if block == nil || receipts == nil {
+			return nil
+		}
+		return publicReceipts(block.Transactions(), receipts)
+	}
+	number := rawdb.ReadHeaderNumber(bc.db, hash)
+	if number == nil {
+		return nil
+	}
+	return rawdb.ReadPrivateStateReceipts(bc.db, psi, hash, *number, bc.chainConfig)
+}
+
+// publicReceipts replaces the receipts of the private transactions with the
+// public receipts they would have for a node which isn't a party to them
+func publicReceipts(txs types.Transactions, receipts types.Receipts) types.Receipts {
+	result := make(types.Receipts, len(receipts))
+	for i, receipt := range receipts {
+		if i >= len(txs) || !txs[i].IsPrivate() {
+			result[i] = receipt
+			continue
+		}
+		public := *receipt
+		public.Status = types.ReceiptStatusSuccessful
+		public.Logs = []*types.Log{}
+		public.Bloom = types.Bloom{}
+		result[i] = &public
+	}
+	return result
+}
+
+// PrivateStateLogsEvent is posted with the logs of the blocks added to or,
+// when Removed is set, removed from the canonical chain as seen from a private
+// state other than the default one
+type PrivateStateLogsEvent struct {
+	PSI     types.PrivateStateIdentifier
+	Logs    []*types.Log
+	Removed bool
+}
+
+// SubscribePrivateStateLogsEvent registers a subscription of PrivateStateLogsEvent.
+func (bc *BlockChain) SubscribePrivateStateLogsEvent(ch chan<- PrivateStateLogsEvent) event.Subscription {
+	return bc.scope.Track(bc.privateLogsFeed.Subscribe(ch))
+}
+
+// privateStateLogs returns the logs of the block as seen from the empty
+// private state and from each of the configured private states, leaving out
+// the private states it has no logs for
+func (bc *BlockChain) privateStateLogs(hash common.Hash, removed bool) map[types.PrivateStateIdentifier][]*types.Log {
+	if !bc.chainConfig.IsQuorum {
+		return nil
+	}
+	psis := []types.PrivateStateIdentifier{types.EmptyPrivateStateIdentifier}
+	for psi := range bc.privateStates {
+		psis = append(psis, psi)
+	}
+	result := make(map[types.PrivateStateIdentifier][]*types.Log)
+	for _, psi := range psis {
+		for _, receipt := range bc.GetReceiptsByHashOf(hash, psi) {
+			for _, log := range receipt.Logs {
+				l := *log
+				l.Removed = removed
+				result[psi] = append(result[psi], &l)
+			}
+		}
+	}
+	return result
+}
+
+// privateStateResult is the outcome of the execution of a block on a private
+// state other than the default one
+type privateStateResult struct {
+	state           *state.StateDB
+	receipts        types.Receipts
+	privateReceipts types.Receipts
+}
+
+// processPrivateStates executes the block on each of the configured private
+// states. As the private transactions may read the public state, the whole
+// block is executed again on a throwaway copy of the parent public state for
+// each of them.
+func (bc *BlockChain) processPrivateStates(block *types.Block) (map[types.PrivateStateIdentifier]*privateStateResult, error) {
+	if !bc.chainConfig.IsQuorum || len(bc.privateStates) == 0 {
+		return nil, nil
+	}
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	results := make(map[types.PrivateStateIdentifier]*privateStateResult, len(bc.privateStates))
+	for psi, keys := range bc.privateStates {
+		publicState, err := state.New(parent.Root, bc.stateCache)
+		if err != nil {
+			return nil, err
+		}
+		privateState, err := state.New(rawdb.GetPrivateStateRootOf(bc.db, psi, parent.Root), bc.privateStateCache)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			chain   = &privateStateChain{BlockChain: bc}
+			header  = block.Header()
+			usedGas = new(uint64)
+			gp      = new(GasPool).AddGas(block.GasLimit())
+			result  = &privateStateResult{state: privateState}
+		)
+		chain.ptm = NewPrivateStatePrivateTransactionManager(bc.ptm, keys)
+		for i, tx := range block.Transactions() {
+			publicState.Prepare(tx.Hash(), block.Hash(), i)
+			privateState.Prepare(tx.Hash(), block.Hash(), i)
+
+			receipt, privateReceipt, err := ApplyTransaction(bc.chainConfig, chain, nil, gp, publicState, privateState, header, tx, usedGas, bc.vmConfig)
+			if err != nil {
+				return nil, fmt.Errorf("private state %s: %v", psi, err)
+			}
+			result.receipts = append(result.receipts, receipt)
+			if privateReceipt != nil {
+				result.privateReceipts = append(result.privateReceipts, privateReceipt)
+				if err := bc.CheckAndSetPrivateState(privateReceipt.Logs, privateState); err != nil {
+					return nil, fmt.Errorf("private state %s: %v", psi, err)
+				}
+			}
+		}
+		results[psi] = result
+	}
+	return results, nil
+}
+
+// writePrivateStates commits the private states the block was executed on and
+// stores their receipts and bloom
+func (bc *BlockChain) writePrivateStates(block *types.Block, results map[types.PrivateStateIdentifier]*privateStateResult) error {
+	privateTriedb := bc.privateStateCache.TrieDB()
+	for psi, result := range results {
+		root, err := result.state.Commit(bc.chainConfig.IsEIP158(block.Number()))
+		if err != nil {
+			return err
+		}
+		if err := rawdb.WritePrivateStateRootOf(bc.db, psi, block.Root(), root); err != nil {
+			return err
+		}
+		if err := privateTriedb.Commit(root, false); err != nil {
+			return err
+		}
+		rawdb.WritePrivateStateReceipts(bc.db, psi, block.Hash(), block.NumberU64(), mergeReceipts(result.receipts, result.privateReceipts))
+		if err := rawdb.WritePrivateBlockBloomOf(bc.db, psi, block.NumberU64(), result.privateReceipts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// privateStateChain is the chain as seen when executing transactions on a
+// private state other than the default one
+type privateStateChain struct {
+	*BlockChain
+	ptm private.PrivateTransactionManager
+}
+
+func (c *privateStateChain) PrivateTransactionManager() private.PrivateTransactionManager {
+	return c.ptm
+}
+
+// privateStatePrivateTransactionManager only reveals the payloads of the
+// private transactions which are sent to at least one of the keys of a tenant,
+// and only sends payloads from these keys
+type privateStatePrivateTransactionManager struct {
+	private.PrivateTransactionManager
+	keys []string
+	// rawSenders holds the key each raw payload was stored from by the
+	// tenants, so that a tenant only sends the raw payloads it stored
+	rawSenders *lru.Cache
+}
+
+// NewPrivateStatePrivateTransactionManager returns the private transaction
+// manager of a tenant given its keys, nil if ptm is nil. It doesn't send the
+// raw payloads stored from the private transaction manager of another
+// tenant.
+func NewPrivateStatePrivateTransactionManager(ptm private.PrivateTransactionManager, keys []string) private.PrivateTransactionManager {
+	if ptm == nil {
+		return nil
+	}
+	return &privateStatePrivateTransactionManager{PrivateTransactionManager: ptm, keys: keys}
+}
+
+// senderKey returns the key of the tenant to send a private payload from,
+// the first key of the tenant if from is empty
+func (t *privateStatePrivateTransactionManager) senderKey(from string) (string, error) {
+	if len(t.keys) == 0 {
+		return "", ErrNoPrivateStateKeys
+	}
+	if from == "" {
+		return t.keys[0], nil
+	}
+	if !t.isKey(from) {
+		return "", fmt.Errorf("privateFrom %s is not a key of the private state", from)
+	}
+	return from, nil
+}
+
+func (t *privateStatePrivateTransactionManager) isKey(key string) bool {
+	for _, k := range t.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// storedRaw returns whether the raw payload was stored from a key of the tenant
+func (t *privateStatePrivateTransactionManager) storedRaw(txHash common.EncryptedPayloadHash) bool {
+	if t.rawSenders == nil {
+		return false
+	}
+	from, ok := t.rawSenders.Get(txHash)
+	return ok && t.isKey(from.(string))
+}
+
+func (t *privateStatePrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
+	from, err := t.senderKey(from)
+	if err != nil {
+		return common.EncryptedPayloadHash{}, err
+	}
+	return t.PrivateTransactionManager.Send(data, from, to, extra)
+}
+
+func (t *privateStatePrivateTransactionManager) StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error) {
+	from, err := t.senderKey(from)
+	if err != nil {
+		return common.EncryptedPayloadHash{}, err
+	}
+	txHash, err := t.PrivateTransactionManager.StoreRaw(data, from)
+	if err == nil && t.rawSenders != nil {
+		t.rawSenders.Add(txHash, from)
+	}
+	return txHash, err
+}
+
+func (t *privateStatePrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	if !t.storedRaw(txHash) {
+		return nil, ErrRawPayloadNotStored
+	}
+	return t.PrivateTransactionManager.SendSignedTx(txHash, to, extra)
+}
+
+func (t *privateStatePrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	data, extra, err := t.PrivateTransactionManager.Receive(txHash)
+	if err != nil || data == nil {
+		return data, extra, err
+	}
+	// the raw payloads stored by the tenant aren't sent to anyone yet
+	if t.storedRaw(txHash) {
+		return data, extra, nil
+	}
+	participants, err := t.PrivateTransactionManager.GetParticipants(txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, participant := range participants {
+		if t.isKey(participant) {
+			return data, extra, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func (t *privateStatePrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return engine.ReceiveConcurrently(txHashes, engine.DefaultBatchConcurrency, t.Receive)
+}
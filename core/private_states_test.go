@@ -0,0 +1,124 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/private/engine"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPrivateStateTestPrivateTransactionManager(keys ...string) (*privateStatePrivateTransactionManager, common.EncryptedPayloadHash) {
+	txHash := common.BytesToEncryptedPayloadHash([]byte("payload"))
+	stub := &StubPrivateTransactionManager{
+		responses: map[string][]interface{}{
+			"Receive": {[]byte("private payload"), nil},
+		},
+		participants: map[common.EncryptedPayloadHash][]string{
+			txHash: {"sender", "recipient"},
+		},
+	}
+	return &privateStatePrivateTransactionManager{PrivateTransactionManager: stub, keys: keys}, txHash
+}
+
+func TestPrivateStatePrivateTransactionManager_whenParty(t *testing.T) {
+	ptm, txHash := newPrivateStateTestPrivateTransactionManager("other", "recipient")
+
+	data, _, err := ptm.Receive(txHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "private payload", string(data))
+}
+
+func TestPrivateStatePrivateTransactionManager_whenNotParty(t *testing.T) {
+	ptm, txHash := newPrivateStateTestPrivateTransactionManager("other")
+
+	data, _, err := ptm.Receive(txHash)
+
+	assert.NoError(t, err)
+	assert.Nil(t, data, "the payload must not be revealed to a tenant which isn't a party")
+}
+
+// sendingPrivateTransactionManager records the key the payloads are sent from
+type sendingPrivateTransactionManager struct {
+	StubPrivateTransactionManager
+	from string
+}
+
+func (spm *sendingPrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
+	spm.from = from
+	return common.BytesToEncryptedPayloadHash(data), nil
+}
+
+func (spm *sendingPrivateTransactionManager) StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error) {
+	spm.from = from
+	return common.BytesToEncryptedPayloadHash(data), nil
+}
+
+func (spm *sendingPrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	return txHash.Bytes(), nil
+}
+
+func TestPrivateStatePrivateTransactionManager_whenSending(t *testing.T) {
+	stub := &sendingPrivateTransactionManager{}
+	ptm := &privateStatePrivateTransactionManager{PrivateTransactionManager: stub, keys: []string{"tenant1", "tenant2"}}
+
+	_, err := ptm.Send([]byte("payload"), "", []string{"recipient"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant1", stub.from, "the payload must be sent from the first key of the tenant by default")
+
+	_, err = ptm.Send([]byte("payload"), "tenant2", []string{"recipient"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant2", stub.from)
+
+	_, err = ptm.Send([]byte("payload"), "other", []string{"recipient"}, nil)
+	assert.Error(t, err, "the payload must not be sent from the key of another tenant")
+	_, err = ptm.StoreRaw([]byte("payload"), "other")
+	assert.Error(t, err, "the payload must not be stored from the key of another tenant")
+
+	empty := &privateStatePrivateTransactionManager{PrivateTransactionManager: stub}
+	_, err = empty.Send([]byte("payload"), "", []string{"recipient"}, nil)
+	assert.Equal(t, ErrNoPrivateStateKeys, err)
+}
+
+func TestPrivateStatePrivateTransactionManager_whenSendingRaw(t *testing.T) {
+	var (
+		stub          = &sendingPrivateTransactionManager{}
+		rawSenders, _ = lru.New(rawPayloadSendersLimit)
+		tenant        = &privateStatePrivateTransactionManager{PrivateTransactionManager: stub, keys: []string{"tenant"}, rawSenders: rawSenders}
+		other         = &privateStatePrivateTransactionManager{PrivateTransactionManager: stub, keys: []string{"other"}, rawSenders: rawSenders}
+	)
+
+	txHash, err := tenant.StoreRaw([]byte("payload"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant", stub.from)
+
+	_, err = other.SendSignedTx(txHash, []string{"recipient"}, nil)
+	assert.Equal(t, ErrRawPayloadNotStored, err, "another tenant must not send the raw payload")
+	_, err = tenant.SendSignedTx(common.BytesToEncryptedPayloadHash([]byte("unknown")), []string{"recipient"}, nil)
+	assert.Equal(t, ErrRawPayloadNotStored, err)
+	_, err = tenant.SendSignedTx(txHash, []string{"recipient"}, nil)
+	assert.NoError(t, err)
+}
+
+func TestPublicReceipts(t *testing.T) {
+	publicTx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(0), nil)
+	privateTx := types.NewTransaction(1, common.Address{}, big.NewInt(0), 21000, big.NewInt(0), nil)
+	privateTx.SetPrivate()
+	logs := []*types.Log{{Address: common.Address{1}}}
+	receipts := types.Receipts{
+		{TxHash: publicTx.Hash(), Status: types.ReceiptStatusSuccessful, Logs: logs},
+		{TxHash: privateTx.Hash(), Status: types.ReceiptStatusFailed, Logs: logs},
+	}
+
+	public := publicReceipts(types.Transactions{publicTx, privateTx}, receipts)
+
+	assert.Equal(t, receipts[0], public[0])
+	assert.Equal(t, privateTx.Hash(), public[1].TxHash)
+	assert.Equal(t, types.ReceiptStatusSuccessful, public[1].Status)
+	assert.Empty(t, public[1].Logs)
+	assert.Equal(t, logs, receipts[1].Logs, "the private receipt must not be modified")
+}
@@ -21,19 +21,33 @@
 package rawdb
 
 import (
+	"bytes"
+	"encoding/binary"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var (
 	privateRootPrefix           = []byte("P")
 	privateBloomPrefix          = []byte("Pb")
+	privatePayloadPrefix        = []byte("Pp") // privatePayloadPrefix + encrypted payload hash -> encoded payload
+	privateStateRootPrefix      = []byte("Pr") // privateStateRootPrefix + psi + block root -> private state root
+	privateStateBloomPrefix     = []byte("Pl") // privateStateBloomPrefix + num (uint64 big endian) + psi -> private bloom
+	privateStatesBloomPrefix    = []byte("Pa") // privateStatesBloomPrefix + num (uint64 big endian) -> bloom of all the private states
+	privateStateReceiptsPrefix  = []byte("Pc") // privateStateReceiptsPrefix + num (uint64 big endian) + hash + psi -> receipts
 	quorumEIP155ActivatedPrefix = []byte("quorum155active")
+	extensionHistoryPrefix      = []byte("quorumExtensionHistory")   // extensionHistoryPrefix + management contract address -> extension history
+	permissionEventPrefix       = []byte("quorumPermissionEvent")    // permissionEventPrefix + num (uint64 big endian) + log index (uint64 big endian) -> permission event
+	permissionEventIndexPrefix  = []byte("quorumPermissionEventIdx") // permissionEventIndexPrefix + index key + num (uint64 big endian) + log index (uint64 big endian) -> empty
 )
 
-//returns whether we have a chain configuration that can't be updated
-//after the EIP155 HF has happened
+// returns whether we have a chain configuration that can't be updated
+// after the EIP155 HF has happened
 func GetIsQuorumEIP155Activated(db ethdb.KeyValueReader) bool {
 	data, _ := db.Get(quorumEIP155ActivatedPrefix)
 	return len(data) == 1
@@ -68,3 +82,258 @@ func GetPrivateBlockBloom(db ethdb.Database, number uint64) (bloom types.Bloom)
 	}
 	return bloom
 }
+
+// ReadPrivatePayload retrieves the encoded private payload stored for the given
+// encrypted payload hash, nil if there is none
+func ReadPrivatePayload(db ethdb.KeyValueReader, hash common.EncryptedPayloadHash) []byte {
+	data, _ := db.Get(append(privatePayloadPrefix, hash[:]...))
+	return data
+}
+
+// WritePrivatePayload stores the encoded private payload of the given encrypted
+// payload hash
+func WritePrivatePayload(db ethdb.KeyValueWriter, hash common.EncryptedPayloadHash, data []byte) {
+	if err := db.Put(append(privatePayloadPrefix, hash[:]...), data); err != nil {
+		log.Crit("Failed to store private payload", "err", err)
+	}
+}
+
+func privateStateRootKey(psi types.PrivateStateIdentifier, blockRoot common.Hash) []byte {
+	return append(append(append([]byte{}, privateStateRootPrefix...), psi...), blockRoot[:]...)
+}
+
+// GetPrivateStateRootOf retrieves the root of the given private state at the
+// given block root. The default private state is the one of GetPrivateStateRoot.
+func GetPrivateStateRootOf(db ethdb.Database, psi types.PrivateStateIdentifier, blockRoot common.Hash) common.Hash {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return GetPrivateStateRoot(db, blockRoot)
+	}
+	root, _ := db.Get(privateStateRootKey(psi, blockRoot))
+	return common.BytesToHash(root)
+}
+
+// WritePrivateStateRootOf stores the root of the given private state at the
+// given block root
+func WritePrivateStateRootOf(db ethdb.Database, psi types.PrivateStateIdentifier, blockRoot, root common.Hash) error {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return WritePrivateStateRoot(db, blockRoot, root)
+	}
+	return db.Put(privateStateRootKey(psi, blockRoot), root[:])
+}
+
+// GetPrivateBlockBloomOf retrieves the bloom of the given private state for the
+// given block number
+func GetPrivateBlockBloomOf(db ethdb.Database, psi types.PrivateStateIdentifier, number uint64) (bloom types.Bloom) {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return GetPrivateBlockBloom(db, number)
+	}
+	data, _ := db.Get(append(append(append([]byte{}, privateStateBloomPrefix...), encodeBlockNumber(number)...), psi...))
+	if len(data) > 0 {
+		bloom = types.BytesToBloom(data)
+	}
+	return bloom
+}
+
+// WritePrivateBlockBloomOf creates a bloom filter for the receipts of the given
+// private state and saves it to the database. The bloom is also added to the
+// one of all the private states of the block, see GetPrivateStatesBlockBloom.
+func WritePrivateBlockBloomOf(db ethdb.Database, psi types.PrivateStateIdentifier, number uint64, receipts types.Receipts) error {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return WritePrivateBlockBloom(db, number, receipts)
+	}
+	rbloom := types.CreateBloom(receipts)
+	if err := db.Put(append(append(append([]byte{}, privateStateBloomPrefix...), encodeBlockNumber(number)...), psi...), rbloom[:]); err != nil {
+		return err
+	}
+	all := GetPrivateStatesBlockBloom(db, number)
+	all.OrBloom(rbloom.Bytes())
+	return db.Put(append(append([]byte{}, privateStatesBloomPrefix...), encodeBlockNumber(number)...), all[:])
+}
+
+// GetPrivateStatesBlockBloom retrieves the bloom of all the private states other
+// than the default one for the given block number, which is a superset of each
+// of their blooms
+func GetPrivateStatesBlockBloom(db ethdb.Database, number uint64) (bloom types.Bloom) {
+	data, _ := db.Get(append(append([]byte{}, privateStatesBloomPrefix...), encodeBlockNumber(number)...))
+	if len(data) > 0 {
+		bloom = types.BytesToBloom(data)
+	}
+	return bloom
+}
+
+func privateStateReceiptsKey(psi types.PrivateStateIdentifier, hash common.Hash, number uint64) []byte {
+	key := append(append([]byte{}, privateStateReceiptsPrefix...), encodeBlockNumber(number)...)
+	return append(append(key, hash[:]...), psi...)
+}
+
+// ReadPrivateStateReceipts retrieves the receipts of a block as seen from the
+// given private state, including their metadata fields. The receipts of the
+// default private state are the ones of ReadReceipts.
+func ReadPrivateStateReceipts(db ethdb.Database, psi types.PrivateStateIdentifier, hash common.Hash, number uint64, config *params.ChainConfig) types.Receipts {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return ReadReceipts(db, hash, number, config)
+	}
+	data, _ := db.Get(privateStateReceiptsKey(psi, hash, number))
+	if len(data) == 0 {
+		return nil
+	}
+	storageReceipts := []*types.ReceiptForStorage{}
+	if err := rlp.DecodeBytes(data, &storageReceipts); err != nil {
+		log.Error("Invalid private state receipt array RLP", "psi", psi, "hash", hash, "err", err)
+		return nil
+	}
+	receipts := make(types.Receipts, len(storageReceipts))
+	for i, storageReceipt := range storageReceipts {
+		receipts[i] = (*types.Receipt)(storageReceipt)
+	}
+	body := ReadBody(db, hash, number)
+	if body == nil {
+		log.Error("Missing body but have private state receipt", "psi", psi, "hash", hash, "number", number)
+		return nil
+	}
+	if err := receipts.DeriveFields(config, hash, number, body.Transactions); err != nil {
+		log.Error("Failed to derive private state receipts fields", "psi", psi, "hash", hash, "number", number, "err", err)
+		return nil
+	}
+	return receipts
+}
+
+// WritePrivateStateReceipts stores the receipts of a block as seen from the
+// given private state
+func WritePrivateStateReceipts(db ethdb.Database, psi types.PrivateStateIdentifier, hash common.Hash, number uint64, receipts types.Receipts) {
+	if psi == types.DefaultPrivateStateIdentifier {
+		WriteReceipts(db, hash, number, receipts)
+		return
+	}
+	storageReceipts := make([]*types.ReceiptForStorage, len(receipts))
+	for i, receipt := range receipts {
+		storageReceipts[i] = (*types.ReceiptForStorage)(receipt)
+	}
+	bytes, err := rlp.EncodeToBytes(storageReceipts)
+	if err != nil {
+		log.Crit("Failed to encode private state receipts", "err", err)
+	}
+	if err := db.Put(privateStateReceiptsKey(psi, hash, number), bytes); err != nil {
+		log.Crit("Failed to store private state receipts", "err", err)
+	}
+}
+
+// ReadExtensionHistory retrieves the encoded history of the contract extension
+// run by the given management contract, nil if there is none
+func ReadExtensionHistory(db ethdb.KeyValueReader, managementContract common.Address) []byte {
+	data, _ := db.Get(append(append([]byte{}, extensionHistoryPrefix...), managementContract[:]...))
+	return data
+}
+
+// ReadAllExtensionHistories retrieves the encoded histories of all the contract
+// extensions
+func ReadAllExtensionHistories(db ethdb.Iteratee) [][]byte {
+	var histories [][]byte
+	it := db.NewIteratorWithPrefix(extensionHistoryPrefix)
+	defer it.Release()
+
+	for it.Next() {
+		if key := it.Key(); len(key) == len(extensionHistoryPrefix)+common.AddressLength {
+			histories = append(histories, common.CopyBytes(it.Value()))
+		}
+	}
+	return histories
+}
+
+// WriteExtensionHistory stores the encoded history of the contract extension
+// run by the given management contract
+func WriteExtensionHistory(db ethdb.KeyValueWriter, managementContract common.Address, data []byte) error {
+	return db.Put(append(append([]byte{}, extensionHistoryPrefix...), managementContract[:]...), data)
+}
+
+func permissionEventKey(number uint64, logIndex uint) []byte {
+	key := append(append([]byte{}, permissionEventPrefix...), encodeBlockNumber(number)...)
+	return append(key, encodeBlockNumber(uint64(logIndex))...)
+}
+
+// ReadAllPermissionEvents retrieves the encoded permission events in the
+// order they were emitted
+func ReadAllPermissionEvents(db ethdb.Iteratee) [][]byte {
+	var events [][]byte
+	it := db.NewIteratorWithPrefix(permissionEventPrefix)
+	defer it.Release()
+
+	for it.Next() {
+		if key := it.Key(); len(key) == len(permissionEventPrefix)+16 {
+			events = append(events, common.CopyBytes(it.Value()))
+		}
+	}
+	return events
+}
+
+// ReadPermissionEvent retrieves the encoded permission event emitted by the
+// log at the given index of the block
+func ReadPermissionEvent(db ethdb.KeyValueReader, number uint64, logIndex uint) []byte {
+	data, _ := db.Get(permissionEventKey(number, logIndex))
+	return data
+}
+
+// PermissionEventPosition locates a permission event by the block and the
+// index of the log emitting it
+type PermissionEventPosition struct {
+	Number   uint64
+	LogIndex uint
+}
+
+func permissionEventIndexKey(key []byte, number uint64, logIndex uint) []byte {
+	k := append(append([]byte{}, permissionEventIndexPrefix...), key...)
+	k = append(k, encodeBlockNumber(number)...)
+	return append(k, encodeBlockNumber(uint64(logIndex))...)
+}
+
+// ReadPermissionEventIndex retrieves the positions of the permission events
+// indexed under the keys between from and to included, in key order and in
+// the order they were emitted for each key
+func ReadPermissionEventIndex(db ethdb.Iteratee, from, to []byte) []PermissionEventPosition {
+	var positions []PermissionEventPosition
+	it := db.NewIteratorWithStart(append(append([]byte{}, permissionEventIndexPrefix...), from...))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, permissionEventIndexPrefix) || len(key) < len(permissionEventIndexPrefix)+16 {
+			break
+		}
+		indexKey := key[len(permissionEventIndexPrefix) : len(key)-16]
+		if bytes.Compare(indexKey, to) > 0 {
+			break
+		}
+		if bytes.Compare(indexKey, from) < 0 {
+			continue
+		}
+		positions = append(positions, PermissionEventPosition{
+			Number:   binary.BigEndian.Uint64(key[len(key)-16 : len(key)-8]),
+			LogIndex: uint(binary.BigEndian.Uint64(key[len(key)-8:])),
+		})
+	}
+	return positions
+}
+
+// WritePermissionEventIndex indexes the permission event emitted by the log at
+// the given index of the block under the given key
+func WritePermissionEventIndex(db ethdb.KeyValueWriter, key []byte, number uint64, logIndex uint) error {
+	return db.Put(permissionEventIndexKey(key, number, logIndex), []byte{})
+}
+
+// DeletePermissionEventIndex removes the permission event emitted by the log
+// at the given index of the block from the given index key
+func DeletePermissionEventIndex(db ethdb.KeyValueWriter, key []byte, number uint64, logIndex uint) error {
+	return db.Delete(permissionEventIndexKey(key, number, logIndex))
+}
+
+// WritePermissionEvent stores the encoded permission event emitted by the log
+// at the given index of the block
+func WritePermissionEvent(db ethdb.KeyValueWriter, number uint64, logIndex uint, data []byte) error {
+	return db.Put(permissionEventKey(number, logIndex), data)
+}
+
+// DeletePermissionEvent removes the permission event emitted by the log at the
+// given index of the block, once the block is no longer canonical
+func DeletePermissionEvent(db ethdb.KeyValueWriter, number uint64, logIndex uint) error {
+	return db.Delete(permissionEventKey(number, logIndex))
+}
@@ -18,6 +18,9 @@ package rawdb
 
 import (
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // Tests that setting the flag for Quorum EIP155 activation read values correctly
@@ -37,3 +40,79 @@ func TestIsQuorumEIP155Active(t *testing.T) {
 		t.Fatal("Quorum EIP155 active read to be unset, but was set beforehand")
 	}
 }
+
+// Tests that the private states besides the default one are stored separately
+func TestPrivateStateRootOf(t *testing.T) {
+	db := NewMemoryDatabase()
+	blockRoot := common.HexToHash("0x01")
+	defaultRoot, tenantRoot := common.HexToHash("0x02"), common.HexToHash("0x03")
+
+	if err := WritePrivateStateRootOf(db, types.DefaultPrivateStateIdentifier, blockRoot, defaultRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePrivateStateRootOf(db, "tenantA", blockRoot, tenantRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	if root := GetPrivateStateRoot(db, blockRoot); root != defaultRoot {
+		t.Fatalf("default private state root mismatch: have %x, want %x", root, defaultRoot)
+	}
+	if root := GetPrivateStateRootOf(db, "tenantA", blockRoot); root != tenantRoot {
+		t.Fatalf("private state root mismatch: have %x, want %x", root, tenantRoot)
+	}
+	if root := GetPrivateStateRootOf(db, "tenantB", blockRoot); root != (common.Hash{}) {
+		t.Fatalf("unknown private state root must be empty, have %x", root)
+	}
+}
+
+// Tests that the blooms of the private states are combined for indexing
+func TestPrivateBlockBloomOf(t *testing.T) {
+	db := NewMemoryDatabase()
+	receiptA := &types.Receipt{Logs: []*types.Log{{Address: common.HexToAddress("0x0a")}}}
+	receiptB := &types.Receipt{Logs: []*types.Log{{Address: common.HexToAddress("0x0b")}}}
+
+	if err := WritePrivateBlockBloomOf(db, "tenantA", 1, types.Receipts{receiptA}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePrivateBlockBloomOf(db, "tenantB", 1, types.Receipts{receiptB}); err != nil {
+		t.Fatal(err)
+	}
+
+	bloomA := GetPrivateBlockBloomOf(db, "tenantA", 1)
+	if !types.BloomLookup(bloomA, common.HexToAddress("0x0a")) || types.BloomLookup(bloomA, common.HexToAddress("0x0b")) {
+		t.Fatal("private state bloom must only contain its own logs")
+	}
+	all := GetPrivateStatesBlockBloom(db, 1)
+	if !types.BloomLookup(all, common.HexToAddress("0x0a")) || !types.BloomLookup(all, common.HexToAddress("0x0b")) {
+		t.Fatal("bloom of all the private states must contain the logs of each of them")
+	}
+	if GetPrivateBlockBloom(db, 1) != (types.Bloom{}) {
+		t.Fatal("default private bloom must not be modified")
+	}
+}
+
+func TestReadPermissionEventIndex(t *testing.T) {
+	db := NewMemoryDatabase()
+	WritePermissionEvent(db, 1, 0, []byte("event"))
+	WritePermissionEventIndex(db, []byte("ORG"), 2, 1)
+	WritePermissionEventIndex(db, []byte("ORG"), 1, 0)
+	WritePermissionEventIndex(db, []byte("ORG2"), 1, 1)
+	WritePermissionEventIndex(db, []byte("OTHER"), 3, 0)
+
+	positions := ReadPermissionEventIndex(db, []byte("ORG"), []byte("ORG"))
+	if len(positions) != 2 || positions[0] != (PermissionEventPosition{1, 0}) || positions[1] != (PermissionEventPosition{2, 1}) {
+		t.Fatalf("unexpected positions of ORG %v", positions)
+	}
+	positions = ReadPermissionEventIndex(db, []byte("ORG"), []byte("ORG2"))
+	if len(positions) != 3 {
+		t.Fatalf("expected 3 positions from ORG to ORG2, got %v", positions)
+	}
+	if events := ReadAllPermissionEvents(db); len(events) != 1 {
+		t.Fatalf("index entries must not be read as events, got %d events", len(events))
+	}
+
+	DeletePermissionEventIndex(db, []byte("ORG"), 1, 0)
+	if positions = ReadPermissionEventIndex(db, []byte("ORG"), []byte("ORG")); len(positions) != 1 {
+		t.Fatalf("expected 1 position of ORG, got %v", positions)
+	}
+}
@@ -115,6 +115,11 @@ type (
 		account            *common.Address
 		prevcode, prevhash []byte
 	}
+	// Quorum
+	privacyMetadataChange struct {
+		account *common.Address
+		prev    *PrivacyMetadata
+	}
 
 	// Changes to other state values.
 	refundChange struct {
@@ -195,6 +200,14 @@ func (ch codeChange) dirtied() *common.Address {
 	return ch.account
 }
 
+func (ch privacyMetadataChange) revert(s *StateDB) {
+	s.getStateObject(*ch.account).setPrivacyMetadata(ch.prev)
+}
+
+func (ch privacyMetadataChange) dirtied() *common.Address {
+	return ch.account
+}
+
 func (ch storageChange) revert(s *StateDB) {
 	s.getStateObject(*ch.account).setState(ch.key, ch.prevalue)
 }
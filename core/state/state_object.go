@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/private/engine"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -105,6 +106,90 @@ type Account struct {
 	Balance  *big.Int
 	Root     common.Hash // merkle root of the storage trie
 	CodeHash []byte
+
+	// Quorum
+	// PrivacyMetadata holds at most one element and is only set on private
+	// contracts created with a privacy flag. It is encoded as an optional
+	// trailing list element so that all other accounts keep their original
+	// encoding and state root.
+	PrivacyMetadata []PrivacyMetadata `rlp:"tail"`
+}
+
+// Quorum
+// PrivacyMetadata records how a private contract was created and the parties
+// removed from it since
+type PrivacyMetadata struct {
+	CreationTxHash common.EncryptedPayloadHash
+	PrivacyFlag    engine.PrivacyFlagType
+	// MandatoryRecipients must be party to every transaction affecting the
+	// contract, only set with engine.PrivacyFlagMandatoryRecipients
+	MandatoryRecipients []string
+	// Frozen is set when this node was removed from the parties of the
+	// contract by a retraction
+	Frozen bool
+	// RetractedParties are the transaction manager keys removed from the
+	// parties of the contract by a retraction
+	RetractedParties []string
+}
+
+// privacyMetadataRLP is the encoding of the privacy metadata of contracts no
+// party was removed from, which keeps the encoding the metadata had before
+// retractions were recorded
+type privacyMetadataRLP struct {
+	CreationTxHash      common.EncryptedPayloadHash
+	PrivacyFlag         engine.PrivacyFlagType
+	MandatoryRecipients []string `rlp:"tail"`
+}
+
+// retractedPrivacyMetadataRLP is the encoding of the privacy metadata of
+// contracts a party was removed from
+type retractedPrivacyMetadataRLP struct {
+	CreationTxHash      common.EncryptedPayloadHash
+	PrivacyFlag         engine.PrivacyFlagType
+	MandatoryRecipients []string
+	Frozen              bool
+	RetractedParties    []string
+}
+
+// EncodeRLP implements rlp.Encoder
+func (pm PrivacyMetadata) EncodeRLP(w io.Writer) error {
+	if !pm.Frozen && len(pm.RetractedParties) == 0 {
+		return rlp.Encode(w, &privacyMetadataRLP{pm.CreationTxHash, pm.PrivacyFlag, pm.MandatoryRecipients})
+	}
+	return rlp.Encode(w, &retractedPrivacyMetadataRLP{pm.CreationTxHash, pm.PrivacyFlag, pm.MandatoryRecipients, pm.Frozen, pm.RetractedParties})
+}
+
+// DecodeRLP implements rlp.Decoder. The mandatory recipients are a list in
+// the encoding with retractions and the trailing strings otherwise.
+func (pm *PrivacyMetadata) DecodeRLP(s *rlp.Stream) error {
+	raw, err := s.Raw()
+	if err != nil {
+		return err
+	}
+	content, _, err := rlp.SplitList(raw)
+	if err != nil {
+		return err
+	}
+	rest := content
+	for i := 0; i < 2 && len(rest) > 0; i++ {
+		if _, _, rest, err = rlp.Split(rest); err != nil {
+			return err
+		}
+	}
+	if kind, _, _, err := rlp.Split(rest); err == nil && kind == rlp.List {
+		var dec retractedPrivacyMetadataRLP
+		if err := rlp.DecodeBytes(raw, &dec); err != nil {
+			return err
+		}
+		*pm = PrivacyMetadata{dec.CreationTxHash, dec.PrivacyFlag, dec.MandatoryRecipients, dec.Frozen, dec.RetractedParties}
+		return nil
+	}
+	var dec privacyMetadataRLP
+	if err := rlp.DecodeBytes(raw, &dec); err != nil {
+		return err
+	}
+	*pm = PrivacyMetadata{CreationTxHash: dec.CreationTxHash, PrivacyFlag: dec.PrivacyFlag, MandatoryRecipients: dec.MandatoryRecipients}
+	return nil
 }
 
 // newObject creates a state object.
@@ -445,6 +530,40 @@ func (s *stateObject) setNonce(nonce uint64) {
 	s.data.Nonce = nonce
 }
 
+// Quorum
+func (s *stateObject) SetPrivacyMetadata(pm *PrivacyMetadata) {
+	s.db.journal.append(privacyMetadataChange{
+		account: &s.address,
+		prev:    s.PrivacyMetadata(),
+	})
+	s.setPrivacyMetadata(pm)
+}
+
+func (s *stateObject) setPrivacyMetadata(pm *PrivacyMetadata) {
+	if pm == nil {
+		s.data.PrivacyMetadata = nil
+		return
+	}
+	s.data.PrivacyMetadata = []PrivacyMetadata{*pm}
+}
+
+// PrivacyMetadata returns a copy of the account's privacy metadata, nil if
+// the account has none
+func (s *stateObject) PrivacyMetadata() *PrivacyMetadata {
+	if len(s.data.PrivacyMetadata) == 0 {
+		return nil
+	}
+	pm := s.data.PrivacyMetadata[0]
+	// decoding gives empty lists, not nil
+	if len(pm.MandatoryRecipients) == 0 {
+		pm.MandatoryRecipients = nil
+	}
+	if len(pm.RetractedParties) == 0 {
+		pm.RetractedParties = nil
+	}
+	return &pm
+}
+
 func (s *stateObject) CodeHash() []byte {
 	return s.data.CodeHash
 }
@@ -351,6 +351,17 @@ func (self *StateDB) GetStorageRoot(addr common.Address) (common.Hash, error) {
 	return so.storageRoot(self.db), nil
 }
 
+// Quorum
+// GetPrivacyMetadata returns the privacy metadata of the given account, nil
+// if the account does not exist or was not created with a privacy flag.
+func (self *StateDB) GetPrivacyMetadata(addr common.Address) *PrivacyMetadata {
+	stateObject := self.getStateObject(addr)
+	if stateObject != nil {
+		return stateObject.PrivacyMetadata()
+	}
+	return nil
+}
+
 /*
  * SETTERS
  */
@@ -385,6 +396,14 @@ func (self *StateDB) SetNonce(addr common.Address, nonce uint64) {
 	}
 }
 
+// Quorum
+func (self *StateDB) SetPrivacyMetadata(addr common.Address, pm *PrivacyMetadata) {
+	stateObject := self.GetOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetPrivacyMetadata(pm)
+	}
+}
+
 func (self *StateDB) SetCode(addr common.Address, code []byte) {
 	stateObject := self.GetOrNewStateObject(addr)
 	if stateObject != nil {
@@ -34,6 +34,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // Tests that updating a state trie does not leak any database writes prior to
@@ -712,3 +714,75 @@ func TestDeleteCreateRevert(t *testing.T) {
 		t.Fatalf("self-destructed contract came alive")
 	}
 }
+
+// Quorum
+func TestPrivacyMetadata(t *testing.T) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	state, _ := New(common.Hash{}, db)
+	addr := toAddr([]byte("contract"))
+	state.SetCode(addr, []byte{1})
+	rootWithoutMetadata := state.IntermediateRoot(true)
+
+	pm := &PrivacyMetadata{
+		CreationTxHash: common.BytesToEncryptedPayloadHash([]byte("creation")),
+		PrivacyFlag:    engine.PrivacyFlagStateValidation,
+	}
+	id := state.Snapshot()
+	state.SetPrivacyMetadata(addr, pm)
+	state.RevertToSnapshot(id)
+	if got := state.GetPrivacyMetadata(addr); got != nil {
+		t.Fatalf("privacy metadata not reverted: %v", got)
+	}
+	if root := state.IntermediateRoot(true); root != rootWithoutMetadata {
+		t.Fatalf("root changed without privacy metadata: %x != %x", root, rootWithoutMetadata)
+	}
+
+	state.SetPrivacyMetadata(addr, pm)
+	root, err := state.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root == rootWithoutMetadata {
+		t.Fatalf("privacy metadata must be part of the state root")
+	}
+	state, _ = New(root, db)
+	if got := state.GetPrivacyMetadata(addr); got == nil || !reflect.DeepEqual(got, pm) {
+		t.Fatalf("wrong privacy metadata after commit: have %v, want %v", got, pm)
+	}
+}
+
+func TestPrivacyMetadata_whenRetracted(t *testing.T) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	state, _ := New(common.Hash{}, db)
+	addr, other := toAddr([]byte("contract")), toAddr([]byte("other"))
+	state.SetCode(addr, []byte{1})
+	state.SetCode(other, []byte{1})
+	legacy := PrivacyMetadata{
+		CreationTxHash:      common.BytesToEncryptedPayloadHash([]byte("creation")),
+		PrivacyFlag:         engine.PrivacyFlagMandatoryRecipients,
+		MandatoryRecipients: []string{"A"},
+	}
+	retracted := legacy
+	retracted.Frozen = true
+	retracted.RetractedParties = []string{"B"}
+	state.SetPrivacyMetadata(addr, &retracted)
+	state.SetPrivacyMetadata(other, &legacy)
+	root, err := state.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, _ = New(root, db)
+	if got := state.GetPrivacyMetadata(addr); got == nil || !reflect.DeepEqual(*got, retracted) {
+		t.Fatalf("wrong retracted privacy metadata after commit: have %v, want %v", got, retracted)
+	}
+	if got := state.GetPrivacyMetadata(other); got == nil || !reflect.DeepEqual(*got, legacy) {
+		t.Fatalf("wrong privacy metadata after commit: have %v, want %v", got, legacy)
+	}
+	// metadata without retractions keeps the encoding it had before them
+	enc, _ := rlp.EncodeToBytes(legacy)
+	want, _ := rlp.EncodeToBytes(&privacyMetadataRLP{legacy.CreationTxHash, legacy.PrivacyFlag, legacy.MandatoryRecipients})
+	if !bytes.Equal(enc, want) {
+		t.Fatalf("privacy metadata encoding changed: %x != %x", enc, want)
+	}
+}
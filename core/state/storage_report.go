@@ -0,0 +1,75 @@
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Quorum
+//
+// StorageReport describes the storage of a contract so that it can be compared
+// between nodes, e.g. by the participants to a private contract. StorageRoot is
+// a deterministic hash of the storage. The slots are keyed by the hash of their
+// key, as the preimages of the keys aren't necessarily known.
+type StorageReport struct {
+	Address     common.Address              `json:"address"`
+	CodeHash    common.Hash                 `json:"codeHash"`
+	StorageRoot common.Hash                 `json:"storageRoot"`
+	Storage     map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// NewStorageReport creates the report of the contract from its storage trie,
+// see StateDB.StorageTrie. The storage slots are only listed if includeStorage
+// is set.
+func NewStorageReport(addr common.Address, codeHash common.Hash, storageTrie Trie, includeStorage bool) (*StorageReport, error) {
+	report := &StorageReport{
+		Address:     addr,
+		CodeHash:    codeHash,
+		StorageRoot: storageTrie.Hash(),
+	}
+	if !includeStorage {
+		return report, nil
+	}
+	report.Storage = make(map[common.Hash]common.Hash)
+	it := trie.NewIterator(storageTrie.NodeIterator(nil))
+	for it.Next() {
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		report.Storage[common.BytesToHash(it.Key)] = common.BytesToHash(content)
+	}
+	return report, it.Err
+}
+
+// StorageSlotDiff is a storage slot whose value differs between two reports,
+// a zero value meaning that the slot isn't set
+type StorageSlotDiff struct {
+	SecureKey common.Hash `json:"secureKey"`
+	Local     common.Hash `json:"local"`
+	Remote    common.Hash `json:"remote"`
+}
+
+// DiffStorage returns the storage slots whose value differ between the local
+// and remote reports sorted by key. Both reports must include their storage.
+func DiffStorage(local, remote *StorageReport) []StorageSlotDiff {
+	diffs := make([]StorageSlotDiff, 0)
+	for key, value := range local.Storage {
+		if remoteValue := remote.Storage[key]; remoteValue != value {
+			diffs = append(diffs, StorageSlotDiff{SecureKey: key, Local: value, Remote: remoteValue})
+		}
+	}
+	for key, value := range remote.Storage {
+		if _, ok := local.Storage[key]; !ok {
+			diffs = append(diffs, StorageSlotDiff{SecureKey: key, Remote: value})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return bytes.Compare(diffs[i].SecureKey[:], diffs[j].SecureKey[:]) < 0
+	})
+	return diffs
+}
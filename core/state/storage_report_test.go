@@ -0,0 +1,71 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStorageReportTestState(storage map[common.Hash]common.Hash) *StateDB {
+	statedb, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+	addr := common.HexToAddress("0x01")
+	statedb.SetCode(addr, []byte("code"))
+	for key, value := range storage {
+		statedb.SetState(addr, key, value)
+	}
+	statedb.Commit(false)
+	return statedb
+}
+
+func newStorageReport(t *testing.T, statedb *StateDB, includeStorage bool) *StorageReport {
+	addr := common.HexToAddress("0x01")
+	report, err := NewStorageReport(addr, statedb.GetCodeHash(addr), statedb.StorageTrie(addr), includeStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestStorageReport_whenSameStorage(t *testing.T) {
+	storage := map[common.Hash]common.Hash{
+		common.HexToHash("0x01"): common.HexToHash("0x0a"),
+		common.HexToHash("0x02"): common.HexToHash("0x0b"),
+	}
+
+	local := newStorageReport(t, newStorageReportTestState(storage), true)
+	remote := newStorageReport(t, newStorageReportTestState(storage), false)
+
+	assert.Equal(t, local.StorageRoot, remote.StorageRoot)
+	assert.Len(t, local.Storage, 2)
+	assert.Nil(t, remote.Storage)
+}
+
+func TestDiffStorage(t *testing.T) {
+	local := newStorageReport(t, newStorageReportTestState(map[common.Hash]common.Hash{
+		common.HexToHash("0x01"): common.HexToHash("0x0a"),
+		common.HexToHash("0x02"): common.HexToHash("0x0b"),
+	}), true)
+	remote := newStorageReport(t, newStorageReportTestState(map[common.Hash]common.Hash{
+		common.HexToHash("0x01"): common.HexToHash("0x0a"),
+		common.HexToHash("0x02"): common.HexToHash("0x0c"),
+		common.HexToHash("0x03"): common.HexToHash("0x0d"),
+	}), true)
+
+	diffs := DiffStorage(local, remote)
+
+	assert.NotEqual(t, local.StorageRoot, remote.StorageRoot)
+	assert.Len(t, diffs, 2)
+	for _, diff := range diffs {
+		switch diff.Remote {
+		case common.HexToHash("0x0c"):
+			assert.Equal(t, common.HexToHash("0x0b"), diff.Local)
+		case common.HexToHash("0x0d"):
+			assert.Equal(t, common.Hash{}, diff.Local)
+		default:
+			t.Errorf("unexpected diff %v", diff)
+		}
+	}
+	assert.Empty(t, DiffStorage(local, local))
+}
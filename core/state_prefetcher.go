@@ -18,15 +18,21 @@ package core
 
 import (
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
 )
 
+var privatePayloadPrefetchTimer = metrics.NewRegisteredTimer("chain/prefetch/privatepayloads", nil)
+
 // statePrefetcher is a basic Prefetcher, which blindly executes a block on top
 // of an arbitrary state with the goal of prefetching potentially useful state
 // data from disk before the main block processor start executing.
@@ -53,6 +59,10 @@ func (p *statePrefetcher) Prefetch(block *types.Block, statedb, privatest *state
 		header  = block.Header()
 		gaspool = new(GasPool).AddGas(block.GasLimit())
 	)
+	// Quorum
+	prefetchPrivatePayloads(p.bc.PrivateTransactionManager(), p.config, block)
+	// End Quorum
+
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		// If block precaching was interrupted, abort
@@ -83,3 +93,31 @@ func precacheTransaction(config *params.ChainConfig, bc ChainContext, author *co
 	_, _, _, err = ApplyMessage(vm, msg, gaspool)
 	return err
 }
+
+// Quorum
+//
+// prefetchPrivatePayloads receives the payloads of all the private transactions
+// of the block in one batch, so that executing the transactions one after
+// another isn't bound by the latency of the private transaction manager.
+func prefetchPrivatePayloads(ptm private.PrivateTransactionManager, config *params.ChainConfig, block *types.Block) {
+	if ptm == nil || !config.IsQuorum {
+		return
+	}
+	var txHashes []common.EncryptedPayloadHash
+	for _, tx := range block.Transactions() {
+		if tx.IsPrivate() {
+			txHashes = append(txHashes, common.BytesToEncryptedPayloadHash(tx.Data()))
+		}
+	}
+	if len(txHashes) == 0 {
+		return
+	}
+	start := time.Now()
+	if _, _, err := ptm.ReceiveBatch(txHashes); err != nil {
+		// processing the transactions receives the payloads again, the
+		// failure is reported as it slows the import down
+		log.Warn("Failed to prefetch private payloads", "number", block.Number(), "hash", block.Hash(), "err", err)
+		return
+	}
+	privatePayloadPrefetchTimer.UpdateSince(start)
+}
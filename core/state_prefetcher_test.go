@@ -0,0 +1,56 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+type batchRecordingPrivateTransactionManager struct {
+	StubPrivateTransactionManager
+	batches [][]common.EncryptedPayloadHash
+}
+
+func (r *batchRecordingPrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	r.batches = append(r.batches, txHashes)
+	return make([][]byte, len(txHashes)), make([]*engine.ExtraMetadata, len(txHashes)), nil
+}
+
+func newPrivatePayloadTestBlock() (*types.Block, []common.EncryptedPayloadHash) {
+	var (
+		txs      types.Transactions
+		txHashes []common.EncryptedPayloadHash
+	)
+	for i, payload := range []string{"first payload", "public", "second payload"} {
+		tx := types.NewTransaction(uint64(i), common.Address{}, big.NewInt(0), 21000, big.NewInt(0), []byte(payload))
+		if payload != "public" {
+			tx.SetPrivate()
+			txHashes = append(txHashes, common.BytesToEncryptedPayloadHash([]byte(payload)))
+		}
+		txs = append(txs, tx)
+	}
+	return types.NewBlock(&types.Header{Number: big.NewInt(1)}, txs, nil, nil), txHashes
+}
+
+func TestPrefetchPrivatePayloads(t *testing.T) {
+	block, txHashes := newPrivatePayloadTestBlock()
+	ptm := &batchRecordingPrivateTransactionManager{}
+
+	prefetchPrivatePayloads(ptm, &params.ChainConfig{IsQuorum: true}, block)
+
+	assert.Equal(t, [][]common.EncryptedPayloadHash{txHashes}, ptm.batches, "the private payloads must be received in a single batch")
+}
+
+func TestPrefetchPrivatePayloads_whenNotQuorum(t *testing.T) {
+	block, _ := newPrivatePayloadTestBlock()
+	ptm := &batchRecordingPrivateTransactionManager{}
+
+	prefetchPrivatePayloads(ptm, &params.ChainConfig{}, block)
+
+	assert.Empty(t, ptm.batches)
+}
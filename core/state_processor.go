@@ -85,7 +85,9 @@ func (p *StateProcessor) Process(block *types.Block, statedb, privateState *stat
 		if privateReceipt != nil {
 			privateReceipts = append(privateReceipts, privateReceipt)
 			allLogs = append(allLogs, privateReceipt.Logs...)
-			p.bc.CheckAndSetPrivateState(privateReceipt.Logs, privateState)
+			if err := p.bc.CheckAndSetPrivateState(privateReceipt.Logs, privateState); err != nil {
+				return nil, nil, nil, 0, err
+			}
 		}
 	}
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
@@ -98,7 +100,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb, privateState *stat
 // and uses the input parameters for its environment. It returns the receipt
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
-func ApplyTransaction(config *params.ChainConfig, bc *BlockChain, author *common.Address, gp *GasPool, statedb, privateState *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, cfg vm.Config) (*types.Receipt, *types.Receipt, error) {
+func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, gp *GasPool, statedb, privateState *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, cfg vm.Config) (*types.Receipt, *types.Receipt, error) {
 	if !config.IsQuorum || !tx.IsPrivate() {
 		privateState = statedb
 	}
@@ -111,6 +113,11 @@ func ApplyTransaction(config *params.ChainConfig, bc *BlockChain, author *common
 	if err != nil {
 		return nil, nil, err
 	}
+	if config.IsQuorum {
+		if err := checkContractAccess(msg.From(), tx, statedb); err != nil {
+			return nil, nil, err
+		}
+	}
 	// Create a new context to be used in the EVM environment
 	context := NewEVMContext(msg, header, bc, author)
 	// Create a new environment which holds all relevant information
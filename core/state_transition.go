@@ -18,14 +18,18 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
 )
 
 var (
@@ -82,6 +86,14 @@ type PrivateMessage interface {
 	IsPrivate() bool
 }
 
+// PrivateCallMessage is a message simulating a private transaction, e.g. for
+// eth_call and eth_estimateGas. Its data is the plaintext payload, which is
+// executed on the private state as is rather than being retrieved from the
+// private transaction manager.
+type PrivateCallMessage struct {
+	types.Message
+}
+
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
 func IntrinsicGas(data []byte, contractCreation, isEIP155 bool, isEIP2028 bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
@@ -198,37 +210,51 @@ func (st *StateTransition) preCheck() error {
 //    and NOT the actual private payload
 // 2. For private transactions, we only deduct intrinsic gas from the gas pool
 //    regardless the current node is party to the transaction or not
+// 3. If the private payload can't be retrieved from the private transaction
+//    manager for any reason other than this node not being a party, an error
+//    is returned
 func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bool, err error) {
-	if err = st.preCheck(); err != nil {
-		return
-	}
-	msg := st.msg
-	sender := vm.AccountRef(msg.From())
-	homestead := st.evm.ChainConfig().IsHomestead(st.evm.BlockNumber)
-	istanbul := st.evm.ChainConfig().IsIstanbul(st.evm.BlockNumber)
-	contractCreation := msg.To() == nil
 	isQuorum := st.evm.ChainConfig().IsQuorum
 
 	var data []byte
+	var privateMetadata *engine.ExtraMetadata
 	isPrivate := false
-	publicState := st.state
-	if msg, ok := msg.(PrivateMessage); ok && isQuorum && msg.IsPrivate() {
+	if msg, ok := st.msg.(PrivateMessage); ok && isQuorum && msg.IsPrivate() {
 		isPrivate = true
-		data, err = private.P.Receive(common.BytesToEncryptedPayloadHash(st.data))
-		// Increment the public account nonce if:
-		// 1. Tx is private and *not* a participant of the group and either call or create
-		// 2. Tx is private we are part of the group and is a call
-		if err != nil || !contractCreation {
-			publicState.SetNonce(sender.Address(), publicState.GetNonce(sender.Address())+1)
+		// The payload is fetched before buying gas so that a failure of the
+		// private transaction manager leaves the gas pool and state untouched.
+		// Such a failure says nothing about whether we are a party to the
+		// transaction, so it must fail the transaction instead of applying it
+		// as if we were not.
+		ptm := st.evm.PrivateTransactionManager
+		if ptm == nil {
+			return nil, 0, false, ErrNoPrivateTransactionManager
 		}
-
+		data, privateMetadata, err = ptm.Receive(common.BytesToEncryptedPayloadHash(st.data))
 		if err != nil {
-			return nil, 0, false, nil
+			return nil, 0, false, err
 		}
 	} else {
 		data = st.data
 	}
 
+	if err = st.preCheck(); err != nil {
+		return
+	}
+	msg := st.msg
+	sender := vm.AccountRef(msg.From())
+	homestead := st.evm.ChainConfig().IsHomestead(st.evm.BlockNumber)
+	istanbul := st.evm.ChainConfig().IsIstanbul(st.evm.BlockNumber)
+	contractCreation := msg.To() == nil
+
+	publicState := st.state
+	if isPrivate && !contractCreation {
+		// Increment the public account nonce if the tx is a private call,
+		// for a private contract creation the EVM increments it on the
+		// public state whether we are a party or not
+		publicState.SetNonce(sender.Address(), publicState.GetNonce(sender.Address())+1)
+	}
+
 	// Pay intrinsic gas. For a private contract this is done using the public hash passed in,
 	// not the private data retrieved above. This is because we need any (participant) validator
 	// node to get the same result as a (non-participant) minter node, to avoid out-of-gas issues.
@@ -248,6 +274,9 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 		// error.
 		vmerr error
 	)
+	// Quorum: private state snapshot to revert to if the transaction
+	// violates the privacy enhancements of the contracts it affects
+	privateSnapshot := evm.PrivateState().Snapshot()
 	if contractCreation {
 		ret, _, leftoverGas, vmerr = evm.Create(sender, data, st.gas, st.value)
 	} else {
@@ -272,6 +301,24 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 
 		ret, leftoverGas, vmerr = evm.Call(sender, to, data, st.gas, st.value)
 	}
+	// Quorum
+	if isPrivate && vmerr == nil {
+		txHash := common.BytesToEncryptedPayloadHash(st.data)
+		if perr := st.checkPrivacyEnhancements(txHash, privateMetadata); perr != nil {
+			// the transaction can't be validated while the transaction
+			// manager fails, so it must fail like a failure to receive it
+			if engine.IsTransportError(perr) {
+				return nil, 0, false, perr
+			}
+			log.Error("Private transaction rejected by privacy enhancements", "hash", txHash.TerminalString(), "err", perr)
+			evm.PrivateState().RevertToSnapshot(privateSnapshot)
+			vmerr = perr
+		} else {
+			st.setPrivacyMetadata(txHash, privateMetadata)
+		}
+	}
+	// End Quorum
+
 	if vmerr != nil {
 		log.Info("VM returned with error", "err", vmerr)
 		// The only possible consensus-error would be if there wasn't
@@ -320,3 +367,141 @@ func (st *StateTransition) refundGas() {
 func (st *StateTransition) gasUsed() uint64 {
 	return st.initialGas - st.gas
 }
+
+// Quorum
+// checkPrivacyEnhancements verifies that a private transaction may affect the
+// private contracts it called. The privacy flag of the transaction must match
+// the flag of every affected contract. For party protection, the creation
+// transaction of every affected contract must be listed in the affected
+// contract transactions stored with the payload. For private state validation,
+// the participants of the transaction must also be those of every affected
+// contract. For mandatory recipients, the mandatory recipients of the
+// transaction must be party to it and include those of every affected contract.
+func (st *StateTransition) checkPrivacyEnhancements(txHash common.EncryptedPayloadHash, metadata *engine.ExtraMetadata) error {
+	flag := engine.PrivacyFlagStandardPrivate
+	var acHashes common.EncryptedPayloadHashes
+	if metadata != nil {
+		flag, acHashes = metadata.PrivacyFlag, metadata.ACHashes
+	}
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	if flag == engine.PrivacyFlagMandatoryRecipients {
+		if len(metadata.MandatoryRecipients) == 0 {
+			return ErrNoMandatoryRecipients
+		}
+		if err := checkMandatoryRecipients(metadata); err != nil {
+			return err
+		}
+	}
+	privateState := st.evm.PrivateState()
+	for _, addr := range st.evm.AffectedContracts() {
+		pm := privateState.GetPrivacyMetadata(addr)
+		contractFlag := engine.PrivacyFlagStandardPrivate
+		if pm != nil {
+			contractFlag = pm.PrivacyFlag
+		}
+		if contractFlag != flag {
+			return fmt.Errorf("%v: contract %s has flag %d, transaction has flag %d", ErrPrivacyFlagMismatch, addr.Hex(), contractFlag, flag)
+		}
+		if flag.IsStandardPrivate() {
+			continue
+		}
+		if acHashes.NotExist(pm.CreationTxHash) {
+			return fmt.Errorf("%v: contract %s", ErrNotContractParty, addr.Hex())
+		}
+		if flag == engine.PrivacyFlagMandatoryRecipients {
+			if missing := missingRecipients(pm.MandatoryRecipients, metadata.MandatoryRecipients); len(missing) > 0 {
+				return fmt.Errorf("%v: contract %s, missing %v", ErrMandatoryRecipientsMissing, addr.Hex(), missing)
+			}
+		}
+		if flag == engine.PrivacyFlagStateValidation {
+			if err := checkSameParticipants(st.evm.PrivateTransactionManager, metadata, pm.CreationTxHash); err != nil {
+				if engine.IsTransportError(err) {
+					return err
+				}
+				return fmt.Errorf("%v: contract %s", err, addr.Hex())
+			}
+		}
+	}
+	return nil
+}
+
+// checkSameParticipants returns ErrParticipantsMismatch if the private
+// transaction and the one which created the contract were not shared with the
+// same set of parties. The participants are the ones stored by the sender with
+// the payloads, which unlike the ones known by the local transaction manager
+// are the same for every party.
+func checkSameParticipants(ptm private.PrivateTransactionManager, metadata *engine.ExtraMetadata, creationTxHash common.EncryptedPayloadHash) error {
+	_, creationMetadata, err := ptm.Receive(creationTxHash)
+	if err != nil {
+		return err
+	}
+	if creationMetadata == nil || len(metadata.Participants) == 0 || len(creationMetadata.Participants) == 0 {
+		return ErrParticipantsMismatch
+	}
+	participants, contractParticipants := metadata.Participants, creationMetadata.Participants
+	set := make(map[string]struct{}, len(contractParticipants))
+	for _, p := range contractParticipants {
+		set[p] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(participants))
+	for _, p := range participants {
+		if _, ok := set[p]; !ok {
+			return ErrParticipantsMismatch
+		}
+		seen[p] = struct{}{}
+	}
+	if len(seen) != len(set) {
+		return ErrParticipantsMismatch
+	}
+	return nil
+}
+
+// checkMandatoryRecipients returns ErrMandatoryRecipientsMissing if one of the
+// mandatory recipients isn't a participant of the private transaction, as
+// stored by the sender with the payload
+func checkMandatoryRecipients(metadata *engine.ExtraMetadata) error {
+	if missing := missingRecipients(metadata.MandatoryRecipients, metadata.Participants); len(missing) > 0 {
+		return fmt.Errorf("%v: missing %v", ErrMandatoryRecipientsMissing, missing)
+	}
+	return nil
+}
+
+// missingRecipients returns the recipients which aren't in parties
+func missingRecipients(recipients, parties []string) []string {
+	set := make(map[string]struct{}, len(parties))
+	for _, p := range parties {
+		set[p] = struct{}{}
+	}
+	var missing []string
+	for _, r := range recipients {
+		if _, ok := set[r]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// setPrivacyMetadata records the privacy flag, creation transaction and
+// mandatory recipients on the private contracts created by a transaction sent
+// with a privacy flag
+func (st *StateTransition) setPrivacyMetadata(txHash common.EncryptedPayloadHash, metadata *engine.ExtraMetadata) {
+	if metadata == nil || metadata.PrivacyFlag.IsStandardPrivate() {
+		return
+	}
+	privateState := st.evm.PrivateState()
+	for _, addr := range st.evm.CreatedContracts() {
+		if !privateState.Exist(addr) {
+			continue
+		}
+		pm := &state.PrivacyMetadata{
+			CreationTxHash: txHash,
+			PrivacyFlag:    metadata.PrivacyFlag,
+		}
+		if metadata.PrivacyFlag == engine.PrivacyFlagMandatoryRecipients {
+			pm.MandatoryRecipients = metadata.MandatoryRecipients
+		}
+		privateState.SetPrivacyMetadata(addr, pm)
+	}
+}
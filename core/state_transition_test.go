@@ -11,16 +11,12 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
 	testifyassert "github.com/stretchr/testify/assert"
 )
 
 func verifyGasPoolCalculation(t *testing.T, pm private.PrivateTransactionManager) {
 	assert := testifyassert.New(t)
-	saved := private.P
-	defer func() {
-		private.P = saved
-	}()
-	private.P = pm
 
 	txGasLimit := uint64(100000)
 	gasPool := new(GasPool).AddGas(200000)
@@ -42,6 +38,7 @@ func verifyGasPoolCalculation(t *testing.T, pm private.PrivateTransactionManager
 		},
 	}
 	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
+	ctx.PrivateTransactionManager = pm
 	evm := vm.NewEVM(ctx, publicState, privateState, params.QuorumTestChainConfig, vm.Config{})
 	arbitraryBalance := big.NewInt(100000000)
 	publicState.SetBalance(evm.Coinbase, arbitraryBalance)
@@ -83,6 +80,289 @@ func TestStateTransition_TransitionDb_GasPoolCalculation_whenPartyNodeProcessing
 	verifyGasPoolCalculation(t, stubPTM)
 }
 
+func TestStateTransition_TransitionDb_whenPrivateTransactionManagerFails(t *testing.T) {
+	assert := testifyassert.New(t)
+	stubPTM := &StubPrivateTransactionManager{
+		responses: map[string][]interface{}{
+			"Receive": {
+				nil,
+				&engine.TransportError{Op: "receiveraw", Err: fmt.Errorf("connection refused")},
+			},
+		},
+	}
+
+	db := rawdb.NewMemoryDatabase()
+	privateState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	publicState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	msg := privateCallMsg{
+		callmsg: callmsg{
+			addr:     common.Address{2},
+			to:       &common.Address{1},
+			value:    new(big.Int),
+			gas:      100000,
+			gasPrice: big.NewInt(0),
+			data:     arbitraryTxHash.Bytes(),
+		},
+	}
+	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
+	ctx.PrivateTransactionManager = stubPTM
+	evm := vm.NewEVM(ctx, publicState, privateState, params.QuorumTestChainConfig, vm.Config{})
+	gasPool := new(GasPool).AddGas(200000)
+
+	_, _, _, err := NewStateTransition(evm, msg, gasPool).TransitionDb()
+
+	assert.Error(err)
+	assert.True(engine.IsTransportError(err))
+	assert.Equal(uint64(200000), gasPool.Gas(), "gas pool must not be changed")
+	assert.Equal(uint64(0), publicState.GetNonce(msg.From()), "nonce must not be changed")
+}
+
+func TestStateTransition_TransitionDb_whenNoPrivateTransactionManager(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	privateState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	publicState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	msg := privateCallMsg{
+		callmsg: callmsg{
+			addr:     common.Address{2},
+			to:       &common.Address{1},
+			value:    new(big.Int),
+			gas:      100000,
+			gasPrice: big.NewInt(0),
+			data:     arbitraryTxHash.Bytes(),
+		},
+	}
+	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
+	evm := vm.NewEVM(ctx, publicState, privateState, params.QuorumTestChainConfig, vm.Config{})
+
+	_, _, _, err := NewStateTransition(evm, msg, new(GasPool).AddGas(200000)).TransitionDb()
+
+	testifyassert.Equal(t, ErrNoPrivateTransactionManager, err)
+}
+
+var (
+	arbitraryTxHash       = common.BytesToEncryptedPayloadHash([]byte("arbitrary tx hash"))
+	arbitraryCreationHash = common.BytesToEncryptedPayloadHash([]byte("arbitrary creation hash"))
+)
+
+// runPrivacyEnhancedCall sends a private transaction carrying metadata to a
+// private contract created with contractMetadata, which stores 10 in slot 0.
+// The creation transaction of the contract carries creationMetadata.
+func runPrivacyEnhancedCall(t *testing.T, metadata *engine.ExtraMetadata, contractMetadata *state.PrivacyMetadata, creationMetadata *engine.ExtraMetadata, participants map[common.EncryptedPayloadHash][]string) (bool, *state.StateDB) {
+	stubPTM := &StubPrivateTransactionManager{
+		responses: map[string][]interface{}{
+			"Receive": {
+				[]byte{1},
+				nil,
+				metadata,
+			},
+		},
+		metadata:     map[common.EncryptedPayloadHash]*engine.ExtraMetadata{arbitraryCreationHash: creationMetadata},
+		participants: participants,
+	}
+
+	contractAddr := common.Address{1}
+	db := rawdb.NewMemoryDatabase()
+	privateState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	publicState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	// PUSH1 0x0a PUSH1 0x00 SSTORE STOP
+	privateState.SetCode(contractAddr, common.Hex2Bytes("600a60005500"))
+	if contractMetadata != nil {
+		privateState.SetPrivacyMetadata(contractAddr, contractMetadata)
+	}
+	msg := privateCallMsg{
+		callmsg: callmsg{
+			addr:     common.Address{2},
+			to:       &contractAddr,
+			value:    new(big.Int),
+			gas:      100000,
+			gasPrice: big.NewInt(0),
+			data:     arbitraryTxHash.Bytes(),
+		},
+	}
+	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
+	ctx.PrivateTransactionManager = stubPTM
+	evm := vm.NewEVM(ctx, publicState, privateState, params.QuorumTestChainConfig, vm.Config{})
+
+	_, _, failed, err := NewStateTransition(evm, msg, new(GasPool).AddGas(200000)).TransitionDb()
+
+	testifyassert.NoError(t, err)
+	return failed, privateState
+}
+
+func TestStateTransition_TransitionDb_whenPartyProtectionAndCreationTxListed(t *testing.T) {
+	assert := testifyassert.New(t)
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(arbitraryCreationHash)
+
+	failed, privateState := runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagPartyProtection},
+		&state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagPartyProtection},
+		nil,
+		nil)
+
+	assert.False(failed)
+	assert.Equal(common.BigToHash(big.NewInt(10)), privateState.GetState(common.Address{1}, common.Hash{}))
+}
+
+func TestStateTransition_TransitionDb_whenPartyProtectionAndCreationTxNotListed(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	failed, privateState := runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: common.EncryptedPayloadHashes{}, PrivacyFlag: engine.PrivacyFlagPartyProtection},
+		&state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagPartyProtection},
+		nil,
+		nil)
+
+	assert.True(failed)
+	assert.Equal(common.Hash{}, privateState.GetState(common.Address{1}, common.Hash{}), "private state must be reverted")
+}
+
+func TestStateTransition_TransitionDb_whenStandardPrivateTxToPartyProtectedContract(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	failed, privateState := runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{PrivacyFlag: engine.PrivacyFlagStandardPrivate},
+		&state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagPartyProtection},
+		nil,
+		nil)
+
+	assert.True(failed)
+	assert.Equal(common.Hash{}, privateState.GetState(common.Address{1}, common.Hash{}), "private state must be reverted")
+}
+
+func TestStateTransition_TransitionDb_whenStateValidationAndParticipantsDiffer(t *testing.T) {
+	assert := testifyassert.New(t)
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(arbitraryCreationHash)
+	metadata := &engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagStateValidation, Participants: []string{"A", "B"}}
+	contractMetadata := &state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagStateValidation}
+
+	failed, _ := runPrivacyEnhancedCall(t, metadata, contractMetadata,
+		&engine.ExtraMetadata{PrivacyFlag: engine.PrivacyFlagStateValidation, Participants: []string{"B", "A"}},
+		nil)
+	assert.False(failed, "same participants in a different order must be accepted")
+
+	failed, _ = runPrivacyEnhancedCall(t, metadata, contractMetadata,
+		&engine.ExtraMetadata{PrivacyFlag: engine.PrivacyFlagStateValidation, Participants: []string{"A", "B", "C"}},
+		nil)
+	assert.True(failed, "a party left out must be rejected")
+
+	failed, _ = runPrivacyEnhancedCall(t, metadata, contractMetadata, nil, map[common.EncryptedPayloadHash][]string{
+		arbitraryTxHash:       {"A", "B"},
+		arbitraryCreationHash: {"A", "B"},
+	})
+	assert.True(failed, "the participants known by the transaction manager must not be trusted")
+}
+
+func TestStateTransition_TransitionDb_whenMandatoryRecipientsIncluded(t *testing.T) {
+	assert := testifyassert.New(t)
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(arbitraryCreationHash)
+
+	failed, privateState := runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"R", "C"}, Participants: []string{"A", "C", "R"}},
+		&state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"R"}},
+		nil,
+		nil)
+
+	assert.False(failed)
+	assert.Equal(common.BigToHash(big.NewInt(10)), privateState.GetState(common.Address{1}, common.Hash{}))
+}
+
+func TestStateTransition_TransitionDb_whenMandatoryRecipientsLeftOut(t *testing.T) {
+	assert := testifyassert.New(t)
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(arbitraryCreationHash)
+	contractMetadata := &state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"R"}}
+
+	failed, privateState := runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"C"}, Participants: []string{"A", "C", "R"}},
+		contractMetadata,
+		nil,
+		nil)
+
+	assert.True(failed, "a mandatory recipient of the contract left out of the transaction must be rejected")
+	assert.Equal(common.Hash{}, privateState.GetState(common.Address{1}, common.Hash{}), "private state must be reverted")
+
+	failed, _ = runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"R"}, Participants: []string{"A", "C"}},
+		contractMetadata,
+		nil,
+		nil)
+
+	assert.True(failed, "a mandatory recipient which isn't a participant must be rejected")
+
+	failed, _ = runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"R"}, Participants: []string{"A", "C"}},
+		contractMetadata,
+		nil,
+		map[common.EncryptedPayloadHash][]string{arbitraryTxHash: {"A", "C", "R"}})
+
+	assert.True(failed, "the participants known by the transaction manager must not be trusted")
+
+	failed, _ = runPrivacyEnhancedCall(t,
+		&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, Participants: []string{"A", "R"}},
+		contractMetadata,
+		nil,
+		nil)
+
+	assert.True(failed, "the mandatory recipients flag without mandatory recipients must be rejected")
+}
+
+// failingCreationPrivateTransactionManager fails to retrieve the creation
+// transaction of the contract
+type failingCreationPrivateTransactionManager struct {
+	*StubPrivateTransactionManager
+}
+
+func (fpm *failingCreationPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	if txHash == arbitraryCreationHash {
+		return nil, nil, &engine.TransportError{Op: "receive", Err: fmt.Errorf("connection refused")}
+	}
+	return fpm.StubPrivateTransactionManager.Receive(txHash)
+}
+
+func TestStateTransition_TransitionDb_whenPrivateTransactionManagerFailsValidating(t *testing.T) {
+	assert := testifyassert.New(t)
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(arbitraryCreationHash)
+	ptm := &failingCreationPrivateTransactionManager{&StubPrivateTransactionManager{
+		responses: map[string][]interface{}{
+			"Receive": {
+				[]byte{1},
+				nil,
+				&engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagStateValidation},
+			},
+		},
+	}}
+
+	contractAddr := common.Address{1}
+	db := rawdb.NewMemoryDatabase()
+	privateState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	publicState, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	// PUSH1 0x0a PUSH1 0x00 SSTORE STOP
+	privateState.SetCode(contractAddr, common.Hex2Bytes("600a60005500"))
+	privateState.SetPrivacyMetadata(contractAddr, &state.PrivacyMetadata{CreationTxHash: arbitraryCreationHash, PrivacyFlag: engine.PrivacyFlagStateValidation})
+	msg := privateCallMsg{
+		callmsg: callmsg{
+			addr:     common.Address{2},
+			to:       &contractAddr,
+			value:    new(big.Int),
+			gas:      100000,
+			gasPrice: big.NewInt(0),
+			data:     arbitraryTxHash.Bytes(),
+		},
+	}
+	ctx := NewEVMContext(msg, &dualStateTestHeader, nil, &common.Address{})
+	ctx.PrivateTransactionManager = ptm
+	evm := vm.NewEVM(ctx, publicState, privateState, params.QuorumTestChainConfig, vm.Config{})
+
+	_, _, _, err := NewStateTransition(evm, msg, new(GasPool).AddGas(200000)).TransitionDb()
+
+	assert.Error(err)
+	assert.True(engine.IsTransportError(err), "a failure of the transaction manager must not be turned into a rejection of the transaction")
+}
+
 type privateCallMsg struct {
 	callmsg
 }
@@ -90,10 +370,12 @@ type privateCallMsg struct {
 func (pm privateCallMsg) IsPrivate() bool { return true }
 
 type StubPrivateTransactionManager struct {
-	responses map[string][]interface{}
+	responses    map[string][]interface{}
+	metadata     map[common.EncryptedPayloadHash]*engine.ExtraMetadata // overrides the metadata of the responses
+	participants map[common.EncryptedPayloadHash][]string
 }
 
-func (spm *StubPrivateTransactionManager) Send(data []byte, from string, to []string) (common.EncryptedPayloadHash, error) {
+func (spm *StubPrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
 	return common.EncryptedPayloadHash{}, fmt.Errorf("to be implemented")
 }
 
@@ -101,19 +383,30 @@ func (spm *StubPrivateTransactionManager) StoreRaw(data []byte, from string) (co
 	return common.EncryptedPayloadHash{}, fmt.Errorf("to be implemented")
 }
 
-func (spm *StubPrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string) ([]byte, error) {
+func (spm *StubPrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
 	return nil, fmt.Errorf("to be implemented")
 }
 
-func (spm *StubPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, error) {
+func (spm *StubPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	if extra, ok := spm.metadata[txHash]; ok {
+		return []byte{1}, extra, nil
+	}
 	res := spm.responses["Receive"]
 	if err, ok := res[1].(error); ok {
-		return nil, err
+		return nil, nil, err
+	}
+	var extra *engine.ExtraMetadata
+	if len(res) > 2 {
+		extra, _ = res[2].(*engine.ExtraMetadata)
 	}
 	if ret, ok := res[0].([]byte); ok {
-		return ret, nil
+		return ret, extra, nil
 	}
-	return nil, nil
+	return nil, extra, nil
+}
+
+func (spm *StubPrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return engine.ReceiveConcurrently(txHashes, 1, spm.Receive)
 }
 
 func (spm *StubPrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
@@ -121,5 +414,8 @@ func (spm *StubPrivateTransactionManager) IsSender(txHash common.EncryptedPayloa
 }
 
 func (spm *StubPrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
+	if p, ok := spm.participants[txHash]; ok {
+		return p, nil
+	}
 	return nil, fmt.Errorf("to be implemented")
 }
@@ -82,6 +82,10 @@ var (
 	// ErrEtherValueUnsupported is returned if a transaction specifies an Ether Value
 	// for a private Quorum transaction.
 	ErrEtherValueUnsupported = errors.New("ether value is not supported for private transactions")
+
+	// ErrContractAccessDenied is returned if the contract level access rules of
+	// the sender's role do not allow it to call the contract or function
+	ErrContractAccessDenied = errors.New("account does not have access to the contract function")
 )
 
 var (
@@ -561,7 +565,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 			return ErrEtherValueUnsupported
 		}
 		// Check if the sender account is authorized to perform the transaction
-		if err := checkAccount(from, tx.To()); err != nil {
+		if err := checkAccount(from, tx, pool.currentState); err != nil {
 			return err
 		}
 	} else {
@@ -1596,7 +1600,8 @@ func (t *txLookup) Remove(hash common.Hash) {
 }
 
 // checks if the account is has the necessary access for the transaction
-func checkAccount(fromAcct common.Address, toAcct *common.Address) error {
+func checkAccount(fromAcct common.Address, tx *types.Transaction, statedb *state.StateDB) error {
+	toAcct := tx.To()
 	access := types.GetAcctAccess(fromAcct)
 
 	switch access {
@@ -1607,10 +1612,20 @@ func checkAccount(fromAcct common.Address, toAcct *common.Address) error {
 		if toAcct == nil {
 			return errors.New("account does not have contract create permissions")
 		}
+	}
+	return checkContractAccess(fromAcct, tx, statedb)
+}
 
-	case types.FullAccess, types.ContractDeploy:
+// checks if the contract level access rules of the account's role allow
+// the transaction. The recipient of a private transaction is a contract of
+// the private state, which the public state doesn't know about
+func checkContractAccess(fromAcct common.Address, tx *types.Transaction, statedb *state.StateDB) error {
+	if tx.To() == nil {
 		return nil
-
+	}
+	isContract := tx.IsPrivate() || statedb.GetCodeSize(*tx.To()) > 0
+	if !types.CheckContractAccess(fromAcct, *tx.To(), tx.Data(), tx.IsPrivate(), isContract) {
+		return ErrContractAccessDenied
 	}
 	return nil
 }
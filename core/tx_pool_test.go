@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
@@ -326,6 +327,43 @@ func TestQuorumInvalidTransactions(t *testing.T) {
 
 }
 
+func TestCheckContractAccess_whenPrivateTransaction(t *testing.T) {
+	defer func(reached bool, orgs *types.OrgCache, accts *types.AcctCache, rules *types.ContractAccessCache) {
+		types.QIP714BlockReached, types.OrgInfoMap, types.AcctInfoMap, types.ContractAccessMap = reached, orgs, accts, rules
+	}(types.QIP714BlockReached, types.OrgInfoMap, types.AcctInfoMap, types.ContractAccessMap)
+	types.QIP714BlockReached = true
+	types.OrgInfoMap = types.NewOrgCache(params.DEFAULT_ORGCACHE_SIZE)
+	types.AcctInfoMap = types.NewAcctCache(params.DEFAULT_ACCOUNTCACHE_SIZE)
+	types.ContractAccessMap = types.NewContractAccessCache()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	allowed, privateContract := common.Address{1}, common.Address{2}
+	types.OrgInfoMap.UpsertOrg("ORG1", "", "ORG1", big.NewInt(1), types.OrgApproved)
+	types.AcctInfoMap.UpsertAccount("ORG1", "ROLE1", from, false, types.AcctActive)
+	types.ContractAccessMap.UpsertRule("ORG1", "ROLE1", allowed, types.FunctionSig{}, true)
+
+	// the private contract has no code in the public state
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	tx := types.NewTransaction(0, privateContract, common.Big0, 100000, common.Big0, nil)
+	if err := checkContractAccess(from, tx, statedb); err != nil {
+		t.Error("expected value transfers to accounts to be allowed; got", err)
+	}
+	tx.SetPrivate()
+	if err := checkContractAccess(from, tx, statedb); err != ErrContractAccessDenied {
+		t.Error("expected", ErrContractAccessDenied, "; got", err)
+	}
+
+	// the rules are enforced when processing blocks too
+	signed, _ := types.SignTx(tx, types.HomesteadSigner{}, key)
+	signed.SetPrivate()
+	header := &types.Header{Number: big.NewInt(1), GasLimit: 1000000, Difficulty: common.Big0}
+	_, _, err := ApplyTransaction(params.QuorumTestChainConfig, nil, &common.Address{}, new(GasPool).AddGas(1000000), statedb, statedb, header, signed, new(uint64), vm.Config{})
+	if err != ErrContractAccessDenied {
+		t.Error("expected", ErrContractAccessDenied, "; got", err)
+	}
+}
+
 func TestValidateTx_whenValueZeroTransferForPrivateTransaction(t *testing.T) {
 	pool, key := setupQuorumTxPool()
 	defer pool.Stop()
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	lru "github.com/hashicorp/golang-lru"
 )
@@ -84,6 +85,28 @@ type AccountInfo struct {
 	Status     AcctStatus     `json:"status"`
 }
 
+// FunctionSig is the 4 byte selector of a contract function. The zero
+// selector stands for all functions of a contract.
+type FunctionSig [4]byte
+
+// MarshalText encodes the selector as a hex string with 0x prefix.
+func (f FunctionSig) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(f[:]).MarshalText()
+}
+
+// UnmarshalText decodes a selector from a hex string with 0x prefix.
+func (f *FunctionSig) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("FunctionSig", input, f[:])
+}
+
+type ContractAccessInfo struct {
+	OrgId       string         `json:"orgId"`
+	RoleId      string         `json:"roleId"`
+	Contract    common.Address `json:"contract"`
+	FunctionSig FunctionSig    `json:"functionSig"`
+	Active      bool           `json:"active"`
+}
+
 type OrgDetailInfo struct {
 	NodeList   []NodeInfo    `json:"nodeList"`
 	RoleList   []RoleInfo    `json:"roleList"`
@@ -105,6 +128,9 @@ type PermissionConfig struct {
 	NwAdminRole    string         `json:"nwAdminRole"`
 	OrgAdminRole   string         `json:"orgAdminRole"`
 
+	// optional, contract level access rules are not enforced without it
+	ContractAccessAddress common.Address `json:"contractAccessMgrAddress"`
+
 	Accounts      []common.Address `json:"accounts"` //initial list of account that need full access
 	SubOrgDepth   *big.Int         `json:"subOrgDepth"`
 	SubOrgBreadth *big.Int         `json:"subOrgBreadth"`
@@ -143,6 +169,18 @@ var (
 	ErrNotMasterOrg       = errors.New("Org is not a master org")
 )
 
+var (
+	ErrContractAccessExists   = errors.New("Contract access rule exists for the role")
+	ErrContractAccessNotFound = errors.New("Contract access rule does not exist")
+	ErrContractAccessDisabled = errors.New("Contract access manager not configured")
+)
+
+var (
+	ErrInactiveAccount = errors.New("Account is not active")
+	ErrOrgSuspended    = errors.New("Org of the account is suspended")
+	ErrReadOnlyAccount = errors.New("Account has read only access")
+)
+
 var syncStarted = false
 
 var DefaultAccess = FullAccess
@@ -155,6 +193,8 @@ var (
 	NodeInfoMap *NodeCache
 	RoleInfoMap *RoleCache
 	AcctInfoMap *AcctCache
+
+	ContractAccessMap *ContractAccessCache
 )
 
 type OrgKey struct {
@@ -259,6 +299,25 @@ func NewAcctCache(cacheSize int) *AcctCache {
 	return &acctCache
 }
 
+type ContractAccessKey struct {
+	OrgId       string
+	RoleId      string
+	Contract    common.Address
+	FunctionSig FunctionSig
+}
+
+// ContractAccessCache holds all contract level access rules. Unlike the
+// other caches it is never evicted, as a missing rule would widen the
+// access of a role.
+type ContractAccessCache struct {
+	c   map[ContractAccessKey]*ContractAccessInfo
+	mux sync.RWMutex
+}
+
+func NewContractAccessCache() *ContractAccessCache {
+	return &ContractAccessCache{c: make(map[ContractAccessKey]*ContractAccessInfo)}
+}
+
 func (pc *PermissionConfig) IsEmpty() bool {
 	return pc.InterfAddress == common.HexToAddress("0x0")
 }
@@ -506,6 +565,46 @@ func (r *RoleCache) GetRoleList() []RoleInfo {
 	return rlist
 }
 
+func (c *ContractAccessCache) UpsertRule(orgId, roleId string, contract common.Address, sig FunctionSig, active bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	key := ContractAccessKey{orgId, roleId, contract, sig}
+	c.c[key] = &ContractAccessInfo{orgId, roleId, contract, sig, active}
+}
+
+func (c *ContractAccessCache) GetRule(orgId, roleId string, contract common.Address, sig FunctionSig) *ContractAccessInfo {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if r, ok := c.c[ContractAccessKey{orgId, roleId, contract, sig}]; ok {
+		rule := *r
+		return &rule
+	}
+	return nil
+}
+
+func (c *ContractAccessCache) GetRuleList() []ContractAccessInfo {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	rlist := make([]ContractAccessInfo, 0, len(c.c))
+	for _, r := range c.c {
+		rlist = append(rlist, *r)
+	}
+	return rlist
+}
+
+// returns the active rules of a role
+func (c *ContractAccessCache) GetRoleRules(orgId, roleId string) []ContractAccessInfo {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	var rlist []ContractAccessInfo
+	for _, r := range c.c {
+		if r.Active && r.OrgId == orgId && r.RoleId == roleId {
+			rlist = append(rlist, *r)
+		}
+	}
+	return rlist
+}
+
 // Returns the access type for an account. If not found returns
 // default access
 func GetAcctAccess(acctId common.Address) AccessType {
@@ -577,6 +676,68 @@ func CheckIfAdminAccount(acctId common.Address) bool {
 	return false
 }
 
+// checks if the account mapped to an RPC caller may call the RPC API. The
+// account must be active and its org not suspended, and only an account
+// with more than read only access may send transactions
+func CheckRPCAccess(acctId common.Address, transacting bool) error {
+	if !QIP714BlockReached {
+		return nil
+	}
+	a, _ := AcctInfoMap.GetAccount(acctId)
+	if a == nil {
+		return ErrAccountNotThere
+	}
+	if a.Status != AcctActive {
+		return ErrInactiveAccount
+	}
+	if !checkIfOrgActive(a.OrgId) {
+		return ErrOrgSuspended
+	}
+	if transacting && GetAcctAccess(acctId) == ReadOnly {
+		return ErrReadOnlyAccount
+	}
+	return nil
+}
+
+// checks if the account may send a transaction with the given payload to
+// the recipient. Accounts whose role has no contract level rules can call
+// any contract. The rules only govern recipients which are contracts or
+// which have a rule of their own, so value transfers to other accounts are
+// not restricted. The payload of a private transaction is encrypted, so a
+// private transaction is only allowed by a rule covering all functions
+// of the contract
+func CheckContractAccess(acctId common.Address, to common.Address, data []byte, isPrivate bool, isContract bool) bool {
+	if !QIP714BlockReached || ContractAccessMap == nil {
+		return true
+	}
+	a, _ := AcctInfoMap.GetAccount(acctId)
+	if a == nil || a.RoleId == networkAdminRole || a.RoleId == orgAdminRole {
+		return true
+	}
+	rules := ContractAccessMap.GetRoleRules(a.OrgId, a.RoleId)
+	if o, _ := OrgInfoMap.GetOrg(a.OrgId); o != nil && o.UltimateParent != a.OrgId {
+		rules = append(rules, ContractAccessMap.GetRoleRules(o.UltimateParent, a.RoleId)...)
+	}
+	if len(rules) == 0 {
+		return true
+	}
+	var sig FunctionSig
+	if !isPrivate && len(data) >= len(sig) {
+		copy(sig[:], data)
+	}
+	hasRule := false
+	for _, r := range rules {
+		if r.Contract != to {
+			continue
+		}
+		hasRule = true
+		if r.FunctionSig == (FunctionSig{}) || (sig != (FunctionSig{}) && r.FunctionSig == sig) {
+			return true
+		}
+	}
+	return !isContract && !hasRule
+}
+
 // validates if the account can transact from the current node
 func ValidateNodeForTxn(hexnodeId string, from common.Address) bool {
 	if !QIP714BlockReached || hexnodeId == "" {
@@ -207,6 +207,115 @@ func TestGetAcctAccess(t *testing.T) {
 	assert.True(access == ReadOnly, fmt.Sprintf("Expected account access to be %v, got %v", ReadOnly, access))
 }
 
+func TestCheckContractAccess(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	SetDefaults(NETWORKADMIN, ORGADMIN)
+	SetDefaultAccess()
+	ContractAccessMap = NewContractAccessCache()
+
+	var Acct3 = common.BytesToAddress([]byte("contract-access1"))
+	contract1 := common.BytesToAddress([]byte("contract1"))
+	contract2 := common.BytesToAddress([]byte("contract2"))
+	transfer := FunctionSig{0xa9, 0x05, 0x9c, 0xbb}
+	approve := FunctionSig{0x09, 0x5e, 0xa7, 0xb3}
+	callData := func(sig FunctionSig) []byte {
+		return append(sig[:], make([]byte, 64)...)
+	}
+
+	OrgInfoMap.UpsertOrg(NETWORKADMIN, "", NETWORKADMIN, big.NewInt(1), OrgApproved)
+	OrgInfoMap.UpsertOrg("SUB1", NETWORKADMIN, NETWORKADMIN, big.NewInt(2), OrgApproved)
+	RoleInfoMap.UpsertRole(NETWORKADMIN, NETWORKADMIN, true, true, FullAccess, true)
+	RoleInfoMap.UpsertRole(NETWORKADMIN, "ROLE1", false, false, Transact, true)
+	AcctInfoMap.UpsertAccount(NETWORKADMIN, NETWORKADMIN, Acct1, true, AcctActive)
+	AcctInfoMap.UpsertAccount(NETWORKADMIN, "ROLE1", Acct2, false, AcctActive)
+	AcctInfoMap.UpsertAccount(NETWORKADMIN+".SUB1", "ROLE1", Acct3, false, AcctActive)
+
+	// without rules the role can call any contract
+	assert.True(CheckContractAccess(Acct2, contract1, callData(transfer), false, true))
+
+	// restrict the role to the transfer function of contract1
+	ContractAccessMap.UpsertRule(NETWORKADMIN, "ROLE1", contract1, transfer, true)
+	assert.True(CheckContractAccess(Acct2, contract1, callData(transfer), false, true))
+	assert.False(CheckContractAccess(Acct2, contract1, callData(approve), false, true))
+	assert.False(CheckContractAccess(Acct2, contract1, nil, false, true))
+	assert.False(CheckContractAccess(Acct2, contract2, callData(transfer), false, true))
+	assert.False(CheckContractAccess(Acct2, contract1, callData(transfer), true, true), "function level rules cannot be checked for private transactions")
+
+	// recipients without a rule of their own are governed by the rules only if they are contracts
+	recipient := common.BytesToAddress([]byte("recipient"))
+	assert.True(CheckContractAccess(Acct2, recipient, nil, false, false), "value transfers to accounts are not restricted")
+	assert.False(CheckContractAccess(Acct2, recipient, nil, false, true))
+	assert.False(CheckContractAccess(Acct2, contract1, callData(approve), false, false), "the rules of the recipient apply even if it has no code")
+
+	// rules of the role in the ultimate parent apply to the accounts of the sub org
+	assert.True(CheckContractAccess(Acct3, contract1, callData(transfer), false, true))
+	assert.False(CheckContractAccess(Acct3, contract2, callData(transfer), false, true))
+
+	// admin accounts are not restricted
+	assert.True(CheckContractAccess(Acct1, contract2, callData(approve), false, true))
+
+	// a rule covering all functions of contract2 allows private transactions to it
+	ContractAccessMap.UpsertRule(NETWORKADMIN, "ROLE1", contract2, FunctionSig{}, true)
+	assert.True(CheckContractAccess(Acct2, contract2, callData(approve), false, true))
+	assert.True(CheckContractAccess(Acct2, contract2, callData(approve), true, true))
+	assert.Equal(2, len(ContractAccessMap.GetRoleRules(NETWORKADMIN, "ROLE1")))
+
+	// once all rules are revoked the role is governed by its base access again
+	ContractAccessMap.UpsertRule(NETWORKADMIN, "ROLE1", contract1, transfer, false)
+	ContractAccessMap.UpsertRule(NETWORKADMIN, "ROLE1", contract2, FunctionSig{}, false)
+	assert.True(CheckContractAccess(Acct2, contract1, callData(approve), false, true))
+	assert.Equal(2, len(ContractAccessMap.GetRuleList()))
+}
+
+func TestCheckRPCAccess(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	SetDefaults(NETWORKADMIN, ORGADMIN)
+	SetDefaultAccess()
+
+	var Acct3 = common.BytesToAddress([]byte("rpc-access1"))
+	var Acct4 = common.BytesToAddress([]byte("rpc-access2"))
+	var Acct5 = common.BytesToAddress([]byte("rpc-access3"))
+	var Acct6 = common.BytesToAddress([]byte("rpc-unassigned"))
+
+	OrgInfoMap.UpsertOrg("RPCORG", "", "RPCORG", big.NewInt(1), OrgApproved)
+	OrgInfoMap.UpsertOrg("SUB1", "RPCORG", "RPCORG", big.NewInt(2), OrgApproved)
+	RoleInfoMap.UpsertRole("RPCORG", "WRITER", false, false, Transact, true)
+	RoleInfoMap.UpsertRole("RPCORG", "READER", false, false, ReadOnly, true)
+	AcctInfoMap.UpsertAccount("RPCORG", "WRITER", Acct3, false, AcctActive)
+	AcctInfoMap.UpsertAccount("RPCORG.SUB1", "READER", Acct4, false, AcctActive)
+	AcctInfoMap.UpsertAccount("RPCORG", "WRITER", Acct5, false, AcctSuspended)
+
+	assert.NoError(CheckRPCAccess(Acct3, true))
+	assert.NoError(CheckRPCAccess(Acct4, false))
+	assert.Equal(ErrReadOnlyAccount, CheckRPCAccess(Acct4, true))
+	assert.Equal(ErrInactiveAccount, CheckRPCAccess(Acct5, false))
+	assert.Equal(ErrAccountNotThere, CheckRPCAccess(Acct6, false))
+
+	// suspending the ultimate parent removes the access of the sub org accounts
+	OrgInfoMap.UpsertOrg("RPCORG", "", "RPCORG", big.NewInt(1), OrgSuspended)
+	assert.Equal(ErrOrgSuspended, CheckRPCAccess(Acct3, false))
+	assert.Equal(ErrOrgSuspended, CheckRPCAccess(Acct4, false))
+
+	OrgInfoMap.UpsertOrg("RPCORG", "", "RPCORG", big.NewInt(1), OrgApproved)
+	assert.NoError(CheckRPCAccess(Acct4, false))
+}
+
+func TestFunctionSig_MarshalText(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	sig := FunctionSig{0xa9, 0x05, 0x9c, 0xbb}
+	text, err := sig.MarshalText()
+	assert.NoError(err)
+	assert.Equal("0xa9059cbb", string(text))
+
+	var decoded FunctionSig
+	assert.NoError(decoded.UnmarshalText(text))
+	assert.Equal(sig, decoded)
+	assert.Error(decoded.UnmarshalText([]byte("0xa9059c")))
+}
+
 func TestValidateNodeForTxn(t *testing.T) {
 	assert := testifyassert.New(t)
 	// pass the enode as null and the response should be true
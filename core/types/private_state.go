@@ -0,0 +1,14 @@
+package types
+
+// PrivateStateIdentifier identifies one of the private states kept by a node,
+// each of them being the private state of a tenant of the node
+type PrivateStateIdentifier string
+
+const (
+	// DefaultPrivateStateIdentifier is the private state of all the keys of
+	// the private transaction manager, which nodes have always kept
+	DefaultPrivateStateIdentifier PrivateStateIdentifier = "private"
+	// EmptyPrivateStateIdentifier is the private state of authenticated callers
+	// which aren't granted any, it never contains any contract
+	EmptyPrivateStateIdentifier PrivateStateIdentifier = "empty"
+)
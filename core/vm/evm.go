@@ -17,7 +17,9 @@
 package vm
 
 import (
+	"bytes"
 	"math/big"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
 )
 
 // note: Quorum, States, and Value Transfer
@@ -103,6 +106,9 @@ type Context struct {
 	BlockNumber *big.Int       // Provides information for NUMBER
 	Time        *big.Int       // Provides information for TIME
 	Difficulty  *big.Int       // Provides information for DIFFICULTY
+
+	// Quorum: private transaction manager used to retrieve private payloads
+	PrivateTransactionManager private.PrivateTransactionManager
 }
 
 type PublicState StateDB
@@ -153,8 +159,21 @@ type EVM struct {
 	// be simplified). This is set by Quorum when it's inside a Private State -> Public State read.
 	quorumReadOnly bool
 	readOnlyDepth  uint
+
+	// Quorum: contracts in the private state which are created or called
+	// while executing a private transaction
+	affectedContracts map[common.Address]AffectedType
 }
 
+// AffectedType describes how a private contract was affected by a transaction
+type AffectedType byte
+
+const (
+	_ AffectedType = iota
+	Creation
+	MessageCall
+)
+
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
 // only ever be used *once*.
 func NewEVM(ctx Context, statedb, privateState StateDB, chainConfig *params.ChainConfig, vmConfig Config) *EVM {
@@ -168,6 +187,8 @@ func NewEVM(ctx Context, statedb, privateState StateDB, chainConfig *params.Chai
 
 		publicState:  statedb,
 		privateState: privateState,
+
+		affectedContracts: make(map[common.Address]AffectedType),
 	}
 
 	if chainConfig.IsEWASM(ctx.BlockNumber) {
@@ -233,6 +254,8 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		return nil, gas, ErrInsufficientBalance
 	}
 
+	evm.recordAffectedContract(evm.StateDB, addr, MessageCall)
+
 	var (
 		to       = AccountRef(addr)
 		snapshot = evm.StateDB.Snapshot()
@@ -321,6 +344,8 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 		return nil, gas, ErrInsufficientBalance
 	}
 
+	evm.recordAffectedContract(evm.StateDB, addr, MessageCall)
+
 	var (
 		snapshot = evm.StateDB.Snapshot()
 		to       = AccountRef(caller.Address())
@@ -358,6 +383,8 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 		return nil, gas, ErrDepth
 	}
 
+	evm.recordAffectedContract(evm.StateDB, addr, MessageCall)
+
 	var (
 		snapshot = evm.StateDB.Snapshot()
 		to       = AccountRef(caller.Address())
@@ -395,6 +422,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 		stateDb  = getDualState(evm, addr)
 		snapshot = stateDb.Snapshot()
 	)
+	evm.recordAffectedContract(stateDb, addr, MessageCall)
 	// Initialise a new contract and set the code that is to be used by the EVM.
 	// The contract is a scoped environment for this execution context only.
 	contract := NewContract(caller, to, new(big.Int), gas)
@@ -469,6 +497,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	// Create a new account on the state
 	snapshot := evm.StateDB.Snapshot()
 	evm.StateDB.CreateAccount(address)
+	evm.recordAffectedContract(evm.StateDB, address, Creation)
 	if evm.chainRules.IsEIP158 {
 		evm.StateDB.SetNonce(address, 1)
 	}
@@ -619,6 +648,47 @@ func (env *EVM) Pop() {
 
 func (env *EVM) Depth() int { return env.depth }
 
+// Quorum
+// recordAffectedContract tracks addr if db is the private state of a private
+// transaction. A contract created during the transaction remains recorded as
+// a creation even if it is called afterwards.
+func (evm *EVM) recordAffectedContract(db StateDB, addr common.Address, mode AffectedType) {
+	if evm.privateState == evm.publicState || db != evm.privateState {
+		return
+	}
+	if mode == MessageCall && db.GetCodeSize(addr) == 0 {
+		return
+	}
+	if _, ok := evm.affectedContracts[addr]; !ok || mode == Creation {
+		evm.affectedContracts[addr] = mode
+	}
+}
+
+// AffectedContracts returns the existing private contracts called during the
+// transaction, sorted by address
+func (evm *EVM) AffectedContracts() []common.Address {
+	return evm.affectedContractsOf(MessageCall)
+}
+
+// CreatedContracts returns the private contracts created during the
+// transaction, sorted by address
+func (evm *EVM) CreatedContracts() []common.Address {
+	return evm.affectedContractsOf(Creation)
+}
+
+func (evm *EVM) affectedContractsOf(mode AffectedType) []common.Address {
+	addresses := make([]common.Address, 0, len(evm.affectedContracts))
+	for addr, m := range evm.affectedContracts {
+		if m == mode {
+			addresses = append(addresses, addr)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	return addresses
+}
+
 // We only need to revert the current state because when we call from private
 // public state it's read only, there wouldn't be anything to reset.
 // (A)->(B)->C->(B): A failure in (B) wouldn't need to reset C, as C was flagged
@@ -41,6 +41,8 @@ type MinimalApiState interface {
 	GetCodeHash(common.Address) common.Hash
 	SetState(common.Address, common.Hash, common.Hash)
 	SetStorage(addr common.Address, storage map[common.Hash]common.Hash)
+	// Quorum
+	GetPrivacyMetadata(common.Address) *state.PrivacyMetadata
 }
 
 // StateDB is an EVM database for full state querying.
@@ -85,6 +87,10 @@ type StateDB interface {
 	AddPreimage(common.Hash, []byte)
 
 	ForEachStorage(common.Address, func(common.Hash, common.Hash) bool) error
+
+	// Quorum
+	GetPrivacyMetadata(common.Address) *state.PrivacyMetadata
+	SetPrivacyMetadata(common.Address, *state.PrivacyMetadata)
 }
 
 // CallContext provides a basic interface for the EVM calling conventions. The EVM
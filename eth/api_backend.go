@@ -34,7 +34,9 @@ import (
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -150,10 +152,20 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 			if header == nil || err != nil {
 				return nil, nil, err
 			}
-			publicState, privateState, err := b.eth.BlockChain().StateAt(header.Root)
+			publicState, privateState, err := b.eth.BlockChain().StateAtOf(header.Root, ethapi.PrivateStateIdentifierFromContext(ctx))
 			return EthAPIState{publicState, privateState}, header, err
 		}
 		block, publicState, privateState := b.eth.miner.Pending()
+		// the miner only keeps the pending default private state, the other
+		// private states are given as of the head of the chain along with the
+		// pending public state, so that e.g. pending nonces are still seen
+		if psi := ethapi.PrivateStateIdentifierFromContext(ctx); psi != types.DefaultPrivateStateIdentifier {
+			_, headPrivateState, err := b.eth.BlockChain().StateAtOf(b.eth.BlockChain().CurrentBlock().Root(), psi)
+			if err != nil {
+				return nil, nil, err
+			}
+			privateState = headPrivateState
+		}
 		return EthAPIState{publicState, privateState}, block.Header(), nil
 	}
 	// Otherwise resolve the block number and return its state
@@ -164,7 +176,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, privateState, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, privateState, err := b.eth.BlockChain().StateAtOf(header.Root, ethapi.PrivateStateIdentifierFromContext(ctx))
 	return EthAPIState{stateDb, privateState}, header, err
 
 }
@@ -184,7 +196,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if blockNrOrHash.RequireCanonical && b.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, privateState, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, privateState, err := b.eth.BlockChain().StateAtOf(header.Root, ethapi.PrivateStateIdentifierFromContext(ctx))
 		return EthAPIState{stateDb, privateState}, header, err
 
 	}
@@ -192,11 +204,11 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 }
 
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
-	return b.eth.blockchain.GetReceiptsByHash(hash), nil
+	return b.eth.blockchain.GetReceiptsByHashOf(hash, ethapi.PrivateStateIdentifierFromContext(ctx)), nil
 }
 
 func (b *EthAPIBackend) GetLogs(ctx context.Context, hash common.Hash) ([][]*types.Log, error) {
-	receipts := b.eth.blockchain.GetReceiptsByHash(hash)
+	receipts := b.eth.blockchain.GetReceiptsByHashOf(hash, ethapi.PrivateStateIdentifierFromContext(ctx))
 	if receipts == nil {
 		return nil, nil
 	}
@@ -225,14 +237,20 @@ func (b *EthAPIBackend) GetEVM(ctx context.Context, msg core.Message, state vm.M
 		to = *msg.To()
 	}
 
+	// A simulated private transaction always runs on the private state, e.g. to
+	// create a private contract
 	privateState := statedb.privateState
-	if !privateState.Exist(to) {
+	if _, isPrivateCall := msg.(core.PrivateCallMessage); !isPrivateCall && !privateState.Exist(to) {
 		privateState = statedb.state
 	}
 
 	return vm.NewEVM(context, statedb.state, privateState, b.eth.blockchain.Config(), *b.eth.blockchain.GetVMConfig()), vmError, nil
 }
 
+func (b *EthAPIBackend) SubscribePrivateStateLogsEvent(ch chan<- core.PrivateStateLogsEvent) event.Subscription {
+	return b.eth.BlockChain().SubscribePrivateStateLogsEvent(ch)
+}
+
 func (b *EthAPIBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeRemovedLogsEvent(ch)
 }
@@ -315,6 +333,15 @@ func (b *EthAPIBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	}
 }
 
+// Quorum
+func (b *EthAPIBackend) PrivateTransactionManager() private.PrivateTransactionManager {
+	return b.eth.PrivateTransactionManager()
+}
+
+func (b *EthAPIBackend) PrivateTransactionManagerOf(psi types.PrivateStateIdentifier) private.PrivateTransactionManager {
+	return b.eth.BlockChain().PrivateTransactionManagerOf(psi)
+}
+
 func (b *EthAPIBackend) ChainDb() ethdb.Database {
 	return b.eth.ChainDb()
 }
@@ -454,4 +481,9 @@ func (s EthAPIState) GetCodeHash(addr common.Address) common.Hash {
 	return s.state.GetCodeHash(addr)
 }
 
+// Quorum
+func (s EthAPIState) GetPrivacyMetadata(addr common.Address) *state.PrivacyMetadata {
+	return s.privateState.GetPrivacyMetadata(addr)
+}
+
 //func (s MinimalApiState) Error
@@ -0,0 +1,68 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newTestAPIBackend(t *testing.T) *EthAPIBackend {
+	db := rawdb.NewMemoryDatabase()
+	(&core.Genesis{Config: params.QuorumTestChainConfig}).MustCommit(db)
+	blockchain, err := core.NewBlockChain(db, nil, params.QuorumTestChainConfig, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &EthAPIBackend{eth: &Ethereum{blockchain: blockchain}}
+}
+
+func newTestAPIState(t *testing.T) EthAPIState {
+	publicState, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateState, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return EthAPIState{state: publicState, privateState: privateState}
+}
+
+func TestGetEVM_whenContractCreation(t *testing.T) {
+	b := newTestAPIBackend(t)
+	defer b.eth.blockchain.Stop()
+	apiState := newTestAPIState(t)
+	msg := types.NewMessage(common.Address{1}, nil, 0, new(big.Int), 100000, new(big.Int), nil, false)
+
+	evm, _, err := b.GetEVM(context.Background(), msg, apiState, b.eth.blockchain.CurrentHeader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evm.PrivateState() != apiState.state {
+		t.Errorf("contract creation must run on the public state")
+	}
+}
+
+func TestGetEVM_whenPrivateContractCreation(t *testing.T) {
+	b := newTestAPIBackend(t)
+	defer b.eth.blockchain.Stop()
+	apiState := newTestAPIState(t)
+	msg := types.NewMessage(common.Address{1}, nil, 0, new(big.Int), 100000, new(big.Int), nil, false)
+
+	evm, _, err := b.GetEVM(context.Background(), core.PrivateCallMessage{Message: msg}, apiState, b.eth.blockchain.CurrentHeader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evm.PrivateState() != apiState.privateState {
+		t.Errorf("private contract creation must run on the private state")
+	}
+}
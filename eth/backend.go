@@ -53,6 +53,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/plugin"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -93,6 +94,8 @@ type Ethereum struct {
 
 	securityPlugin *plugin.SecurityPluginTemplate
 
+	ptm private.PrivateTransactionManager // Quorum: private transaction manager of the node, nil if not configured
+
 	miner     *miner.Miner
 	gasPrice  *big.Int
 	etherbase common.Address
@@ -172,6 +175,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		etherbase:      config.Miner.Etherbase,
 		bloomRequests:  make(chan chan *bloombits.Retrieval),
 		bloomIndexer:   NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
+		ptm:            ctx.PrivateTransactionManager(),
 	}
 
 	// Quorum: Set protocol Name/Version
@@ -218,6 +222,11 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	if pc, ok := eth.ptm.(private.PersistentCache); ok {
+		pc.SetCacheDatabase(chainDb)
+	}
+	eth.blockchain.SetPrivateTransactionManager(eth.ptm)
+	eth.blockchain.SetPrivateStates(config.PrivateStates)
 	// Rewind the chain in case of an incompatible config upgrade.
 	if compat, ok := genesisErr.(*params.ConfigCompatError); ok {
 		log.Warn("Rewinding chain to upgrade configuration", "err", compat)
@@ -556,6 +565,14 @@ func (s *Ethereum) Downloader() *downloader.Downloader { return s.protocolManage
 func (s *Ethereum) Synced() bool                       { return atomic.LoadUint32(&s.protocolManager.acceptTxs) == 1 }
 func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruning }
 
+// Quorum
+//
+// PrivateTransactionManager returns the private transaction manager of the
+// node, nil if none is configured.
+func (s *Ethereum) PrivateTransactionManager() private.PrivateTransactionManager {
+	return s.ptm
+}
+
 // Protocols implements node.Service, returning all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
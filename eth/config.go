@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/miner"
@@ -142,6 +143,11 @@ type Config struct {
 
 	RaftMode             bool
 	EnableNodePermission bool
+
+	// Private states kept besides the default one, each of them mapped to the
+	// keys of its tenant in the private transaction manager
+	PrivateStates map[types.PrivateStateIdentifier][]string `toml:",omitempty"`
+
 	// Istanbul options
 	Istanbul istanbul.Config
 
@@ -28,6 +28,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -43,9 +45,12 @@ type Backend interface {
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
+	SubscribePrivateStateLogsEvent(ch chan<- core.PrivateStateLogsEvent) event.Subscription
 
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
+
+	PrivateTransactionManager() private.PrivateTransactionManager // nil if not configured
 }
 
 // Filter can be used to retrieve and filter logs.
@@ -56,6 +61,10 @@ type Filter struct {
 	addresses []common.Address
 	topics    [][]common.Hash
 
+	// Quorum
+	privateOnly  bool
+	participants []string
+
 	block      common.Hash // Block hash if filtering a single block
 	begin, end int64       // Range interval if filtering multiple blocks
 
@@ -233,9 +242,11 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, e
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(ctx context.Context, header *types.Header) (logs []*types.Log, err error) {
 	// Quorum
-	// Apply bloom filter for both public bloom and private bloom
-	bloomMatches := bloomFilter(header.Bloom, f.addresses, f.topics) ||
-		bloomFilter(rawdb.GetPrivateBlockBloom(f.db, header.Number.Uint64()), f.addresses, f.topics)
+	// Apply bloom filter for both public bloom and the bloom of the private state of the caller
+	// Only the latter can match when filtering the logs of private transactions
+	psi := ethapi.PrivateStateIdentifierFromContext(ctx)
+	bloomMatches := bloomFilter(rawdb.GetPrivateBlockBloomOf(f.db, psi, header.Number.Uint64()), f.addresses, f.topics) ||
+		(!f.privateOnly && len(f.participants) == 0 && bloomFilter(header.Bloom, f.addresses, f.topics))
 	if bloomMatches {
 		found, err := f.checkMatches(ctx, header)
 		if err != nil {
@@ -272,7 +283,7 @@ func (f *Filter) checkMatches(ctx context.Context, header *types.Header) (logs [
 			}
 			logs = filterLogs(unfiltered, nil, nil, f.addresses, f.topics)
 		}
-		return logs, nil
+		return filterPrivateLogs(f.db, f.backend.PrivateTransactionManager(), logs, f.privateOnly, f.participants)
 	}
 	return nil, nil
 }
@@ -0,0 +1,131 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/private"
+)
+
+// Quorum
+
+var errParticipantsWithoutPrivateTransactionManager = errors.New("filtering by participants requires a private transaction manager")
+
+// checkPrivacyCriteria checks that the logs can be filtered by participants
+// when the criteria require it
+func checkPrivacyCriteria(backend Backend, crit FilterCriteria) error {
+	if len(crit.Participants) > 0 && backend.PrivateTransactionManager() == nil {
+		return errParticipantsWithoutPrivateTransactionManager
+	}
+	return nil
+}
+
+// deliverLogs writes the logs to the subscription. The logs of a subscription
+// with privacy criteria are handed to its own goroutine, so that the event
+// loop doesn't wait for the private transaction manager.
+func (es *EventSystem) deliverLogs(f *subscription, logs []*types.Log) {
+	if len(logs) == 0 {
+		return
+	}
+	if f.privateLogs != nil {
+		f.privateLogs <- logs
+		return
+	}
+	f.logs <- logs
+}
+
+// privateLogsLoop writes the logs of the subscription matching its privacy
+// criteria until it is uninstalled
+func (es *EventSystem) privateLogsLoop(f *subscription) {
+	for {
+		select {
+		case logs := <-f.privateLogs:
+			if matchedLogs := es.filterPrivateLogs(logs, f.logsCrit); len(matchedLogs) > 0 {
+				select {
+				case f.logs <- matchedLogs:
+				case <-f.err:
+					return
+				}
+			}
+		case <-f.err:
+			return
+		}
+	}
+}
+
+// filterPrivateLogs restricts the logs matching the criteria to the ones of
+// private transactions, see filterPrivateLogs. The participants of the
+// transactions are cached by the private transaction manager, so that only
+// the first subscription matching a transaction waits for them.
+func (es *EventSystem) filterPrivateLogs(logs []*types.Log, crit ethereum.FilterQuery) []*types.Log {
+	matched, err := filterPrivateLogs(es.backend.ChainDb(), es.backend.PrivateTransactionManager(), logs, crit.PrivateOnly, crit.Participants)
+	if err != nil {
+		log.Warn("Failed to get the participants of private transactions, leaving their logs out", "err", err)
+	}
+	return matched
+}
+
+// filterPrivateLogs returns the logs of private transactions if privateOnly is
+// set or participants are given, only keeping in the latter case the logs of
+// the private transactions sent to at least one of the participants. Logs of
+// transactions which aren't stored in the database, e.g. pending ones, are
+// left out. The logs are returned as is otherwise. The logs of transactions
+// whose participants can't be retrieved are left out too, and the first
+// failure is returned along with the other logs.
+func filterPrivateLogs(db ethdb.Database, ptm private.PrivateTransactionManager, logs []*types.Log, privateOnly bool, participants []string) ([]*types.Log, error) {
+	if !privateOnly && len(participants) == 0 {
+		return logs, nil
+	}
+	var (
+		ret      []*types.Log
+		firstErr error
+		matches  = make(map[common.Hash]bool)
+	)
+	for _, log := range logs {
+		match, ok := matches[log.TxHash]
+		if !ok {
+			var err error
+			if match, err = isPrivateTransactionFor(db, ptm, log.TxHash, participants); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			matches[log.TxHash] = match
+		}
+		if match {
+			ret = append(ret, log)
+		}
+	}
+	return ret, firstErr
+}
+
+// isPrivateTransactionFor returns whether the transaction is private and sent
+// to at least one of the participants, if any are given
+func isPrivateTransactionFor(db ethdb.Database, ptm private.PrivateTransactionManager, txHash common.Hash, participants []string) (bool, error) {
+	tx, _, _, _ := rawdb.ReadTransaction(db, txHash)
+	if tx == nil || !tx.IsPrivate() {
+		return false, nil
+	}
+	if len(participants) == 0 {
+		return true, nil
+	}
+	if ptm == nil {
+		return false, nil
+	}
+	txParticipants, err := ptm.GetParticipants(common.BytesToEncryptedPayloadHash(tx.Data()))
+	if err != nil {
+		return false, fmt.Errorf("transaction %x: %v", txHash, err)
+	}
+	for _, participant := range txParticipants {
+		for _, key := range participants {
+			if participant == key {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
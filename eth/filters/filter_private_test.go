@@ -0,0 +1,230 @@
+package filters
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/private"
+)
+
+type participantsPrivateTransactionManager struct {
+	private.PrivateTransactionManager
+	participants []string
+	err          error
+}
+
+func (ptm *participantsPrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
+	return ptm.participants, ptm.err
+}
+
+// blockingPrivateTransactionManager answers once released
+type blockingPrivateTransactionManager struct {
+	private.PrivateTransactionManager
+	release chan struct{}
+}
+
+func (ptm *blockingPrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
+	<-ptm.release
+	return []string{"A"}, nil
+}
+
+type privateTestBackend struct {
+	*testBackend
+	ptm private.PrivateTransactionManager
+}
+
+func (b *privateTestBackend) PrivateTransactionManager() private.PrivateTransactionManager {
+	return b.ptm
+}
+
+func TestFilterPrivateLogs(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		publicTx  = types.NewTransaction(0, common.Address{1}, big.NewInt(0), 100000, big.NewInt(0), nil)
+		privateTx = types.NewTransaction(1, common.Address{1}, big.NewInt(0), 100000, big.NewInt(0), common.Hex2Bytes(maxPrivatePayloadHashHex))
+		ptm       = &participantsPrivateTransactionManager{participants: []string{"A", "B"}}
+	)
+	privateTx.SetPrivate()
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, []*types.Transaction{publicTx, privateTx}, nil, nil)
+	rawdb.WriteBlock(db, block)
+	rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	rawdb.WriteTxLookupEntries(db, block)
+
+	var (
+		publicLog  = &types.Log{Address: common.Address{1}, TxHash: publicTx.Hash()}
+		privateLog = &types.Log{Address: common.Address{1}, TxHash: privateTx.Hash()}
+		pendingLog = &types.Log{Address: common.Address{1}, TxHash: common.Hash{1}}
+		logs       = []*types.Log{publicLog, privateLog, pendingLog}
+	)
+	testCases := []struct {
+		name         string
+		privateOnly  bool
+		participants []string
+		expected     []*types.Log
+	}{
+		{"no criteria", false, nil, logs},
+		{"private only", true, nil, []*types.Log{privateLog}},
+		{"participant", false, []string{"C", "B"}, []*types.Log{privateLog}},
+		{"not a participant", true, []string{"C"}, nil},
+	}
+	for _, tc := range testCases {
+		found, err := filterPrivateLogs(db, ptm, logs, tc.privateOnly, tc.participants)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+			continue
+		}
+		if len(found) != len(tc.expected) {
+			t.Errorf("%s: expected %d logs, got %d", tc.name, len(tc.expected), len(found))
+			continue
+		}
+		for i := range found {
+			if found[i] != tc.expected[i] {
+				t.Errorf("%s: unexpected log %d", tc.name, i)
+			}
+		}
+	}
+}
+
+func TestFilterPrivateLogs_whenParticipantsUnavailable(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		privateTx = types.NewTransaction(0, common.Address{1}, big.NewInt(0), 100000, big.NewInt(0), common.Hex2Bytes(maxPrivatePayloadHashHex))
+		ptmErr    = errors.New("transaction manager unavailable")
+		ptm       = &participantsPrivateTransactionManager{err: ptmErr}
+	)
+	privateTx.SetPrivate()
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, []*types.Transaction{privateTx}, nil, nil)
+	rawdb.WriteBlock(db, block)
+	rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	rawdb.WriteTxLookupEntries(db, block)
+
+	found, err := filterPrivateLogs(db, ptm, []*types.Log{{TxHash: privateTx.Hash()}}, false, []string{"A"})
+
+	if err == nil || !strings.Contains(err.Error(), ptmErr.Error()) {
+		t.Errorf("expected the error of the transaction manager, got %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("expected no logs, got %d", len(found))
+	}
+}
+
+func TestCheckPrivacyCriteria_whenNoPrivateTransactionManager(t *testing.T) {
+	backend := &testBackend{db: rawdb.NewMemoryDatabase()}
+
+	if err := checkPrivacyCriteria(backend, FilterCriteria{PrivateOnly: true}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := checkPrivacyCriteria(backend, FilterCriteria{Participants: []string{"A"}}); err != errParticipantsWithoutPrivateTransactionManager {
+		t.Errorf("expected %v, got %v", errParticipantsWithoutPrivateTransactionManager, err)
+	}
+}
+
+func TestSubscribePrivateStateLogs(t *testing.T) {
+	var (
+		logsFeed        = new(event.Feed)
+		privateLogsFeed = new(event.Feed)
+		backend         = &testBackend{new(event.TypeMux), rawdb.NewMemoryDatabase(), 0, new(event.Feed), new(event.Feed), logsFeed, new(event.Feed), privateLogsFeed}
+		es              = NewEventSystem(backend.mux, backend, false)
+
+		defaultLog = &types.Log{Address: common.Address{1}}
+		tenantLog  = &types.Log{Address: common.Address{2}}
+		otherLog   = &types.Log{Address: common.Address{3}}
+
+		defaultLogs = make(chan []*types.Log)
+		tenantLogs  = make(chan []*types.Log)
+	)
+	defaultSub, err := es.SubscribeLogs(ethereum.FilterQuery{}, defaultLogs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer defaultSub.Unsubscribe()
+	tenantSub, err := es.SubscribePrivateStateLogs("A", ethereum.FilterQuery{}, tenantLogs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tenantSub.Unsubscribe()
+
+	expectLogs := func(ch chan []*types.Log, expected *types.Log) {
+		select {
+		case logs := <-ch:
+			if len(logs) != 1 || logs[0] != expected {
+				t.Fatalf("expected log of %x, got %v", expected.Address, logs)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected log of %x, got none", expected.Address)
+		}
+	}
+	logsFeed.Send([]*types.Log{defaultLog})
+	expectLogs(defaultLogs, defaultLog)
+
+	privateLogsFeed.Send(core.PrivateStateLogsEvent{PSI: "B", Logs: []*types.Log{otherLog}})
+	privateLogsFeed.Send(core.PrivateStateLogsEvent{PSI: "A", Logs: []*types.Log{tenantLog}})
+	expectLogs(tenantLogs, tenantLog)
+
+	select {
+	case logs := <-defaultLogs:
+		t.Errorf("expected no logs for the default private state, got %v", logs)
+	case logs := <-tenantLogs:
+		t.Errorf("expected no other logs for the private state, got %v", logs)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscribeLogs_whenPrivateTransactionManagerSlow(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		logsFeed  = new(event.Feed)
+		ptm       = &blockingPrivateTransactionManager{release: make(chan struct{})}
+		backend   = &privateTestBackend{&testBackend{new(event.TypeMux), db, 0, new(event.Feed), new(event.Feed), logsFeed, new(event.Feed), new(event.Feed)}, ptm}
+		es        = NewEventSystem(backend.mux, backend, false)
+		privateTx = types.NewTransaction(0, common.Address{1}, big.NewInt(0), 100000, big.NewInt(0), common.Hex2Bytes(maxPrivatePayloadHashHex))
+
+		participantLogs = make(chan []*types.Log)
+		allLogs         = make(chan []*types.Log)
+	)
+	privateTx.SetPrivate()
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, []*types.Transaction{privateTx}, nil, nil)
+	rawdb.WriteBlock(db, block)
+	rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	rawdb.WriteTxLookupEntries(db, block)
+	privateLog := &types.Log{Address: common.Address{1}, TxHash: privateTx.Hash()}
+
+	participantSub, err := es.SubscribeLogs(ethereum.FilterQuery{Participants: []string{"A"}}, participantLogs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer participantSub.Unsubscribe()
+	allSub, err := es.SubscribeLogs(ethereum.FilterQuery{}, allLogs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer allSub.Unsubscribe()
+
+	logsFeed.Send([]*types.Log{privateLog})
+	select {
+	case <-allLogs:
+	case <-time.After(time.Second):
+		t.Fatal("the other subscriptions must not wait for the private transaction manager")
+	}
+
+	close(ptm.release)
+	select {
+	case logs := <-participantLogs:
+		if len(logs) != 1 || logs[0] != privateLog {
+			t.Errorf("expected the private log, got %v", logs)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the private log, got none")
+	}
+}
+
+const maxPrivatePayloadHashHex = "11111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111"
@@ -68,6 +68,11 @@ const (
 	logsChanSize = 10
 	// chainEvChanSize is the size of channel listening to ChainEvent.
 	chainEvChanSize = 10
+	// privateLogsChanSize is the size of channel listening to PrivateStateLogsEvent.
+	privateLogsChanSize = 10
+	// privateLogsQueueSize is the size of the channel of the logs waiting for
+	// the privacy criteria of a subscription to be checked.
+	privateLogsQueueSize = 100
 )
 
 var (
@@ -79,11 +84,17 @@ type subscription struct {
 	typ       Type
 	created   time.Time
 	logsCrit  ethereum.FilterQuery
+	psi       types.PrivateStateIdentifier
 	logs      chan []*types.Log
 	hashes    chan []common.Hash
 	headers   chan *types.Header
 	installed chan struct{} // closed when the filter is installed
 	err       chan error    // closed when the filter is uninstalled
+
+	// Quorum
+	// logs waiting to be checked against the privacy criteria, nil if the
+	// criteria have none
+	privateLogs chan []*types.Log
 }
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -95,19 +106,21 @@ type EventSystem struct {
 	lastHead  *types.Header
 
 	// Subscriptions
-	txsSub        event.Subscription         // Subscription for new transaction event
-	logsSub       event.Subscription         // Subscription for new log event
-	rmLogsSub     event.Subscription         // Subscription for removed log event
-	chainSub      event.Subscription         // Subscription for new chain event
-	pendingLogSub *event.TypeMuxSubscription // Subscription for pending log event
+	txsSub         event.Subscription         // Subscription for new transaction event
+	logsSub        event.Subscription         // Subscription for new log event
+	rmLogsSub      event.Subscription         // Subscription for removed log event
+	chainSub       event.Subscription         // Subscription for new chain event
+	pendingLogSub  *event.TypeMuxSubscription // Subscription for pending log event
+	privateLogsSub event.Subscription         // Subscription for private state log event
 
 	// Channels
-	install   chan *subscription         // install filter for event notification
-	uninstall chan *subscription         // remove filter for event notification
-	txsCh     chan core.NewTxsEvent      // Channel to receive new transactions event
-	logsCh    chan []*types.Log          // Channel to receive new log event
-	rmLogsCh  chan core.RemovedLogsEvent // Channel to receive removed log event
-	chainCh   chan core.ChainEvent       // Channel to receive new chain event
+	install       chan *subscription              // install filter for event notification
+	uninstall     chan *subscription              // remove filter for event notification
+	txsCh         chan core.NewTxsEvent           // Channel to receive new transactions event
+	logsCh        chan []*types.Log               // Channel to receive new log event
+	rmLogsCh      chan core.RemovedLogsEvent      // Channel to receive removed log event
+	chainCh       chan core.ChainEvent            // Channel to receive new chain event
+	privateLogsCh chan core.PrivateStateLogsEvent // Channel to receive private state log event
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -118,15 +131,16 @@ type EventSystem struct {
 // or by stopping the given mux.
 func NewEventSystem(mux *event.TypeMux, backend Backend, lightMode bool) *EventSystem {
 	m := &EventSystem{
-		mux:       mux,
-		backend:   backend,
-		lightMode: lightMode,
-		install:   make(chan *subscription),
-		uninstall: make(chan *subscription),
-		txsCh:     make(chan core.NewTxsEvent, txChanSize),
-		logsCh:    make(chan []*types.Log, logsChanSize),
-		rmLogsCh:  make(chan core.RemovedLogsEvent, rmLogsChanSize),
-		chainCh:   make(chan core.ChainEvent, chainEvChanSize),
+		mux:           mux,
+		backend:       backend,
+		lightMode:     lightMode,
+		install:       make(chan *subscription),
+		uninstall:     make(chan *subscription),
+		txsCh:         make(chan core.NewTxsEvent, txChanSize),
+		logsCh:        make(chan []*types.Log, logsChanSize),
+		rmLogsCh:      make(chan core.RemovedLogsEvent, rmLogsChanSize),
+		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
+		privateLogsCh: make(chan core.PrivateStateLogsEvent, privateLogsChanSize),
 	}
 
 	// Subscribe events
@@ -134,12 +148,13 @@ func NewEventSystem(mux *event.TypeMux, backend Backend, lightMode bool) *EventS
 	m.logsSub = m.backend.SubscribeLogsEvent(m.logsCh)
 	m.rmLogsSub = m.backend.SubscribeRemovedLogsEvent(m.rmLogsCh)
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
+	m.privateLogsSub = m.backend.SubscribePrivateStateLogsEvent(m.privateLogsCh)
 	// TODO(rjl493456442): use feed to subscribe pending log event
 	m.pendingLogSub = m.mux.Subscribe(core.PendingLogsEvent{})
 
 	// Make sure none of the subscriptions are empty
 	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil ||
-		m.pendingLogSub.Closed() {
+		m.privateLogsSub == nil || m.pendingLogSub.Closed() {
 		log.Crit("Subscribe for event system failed")
 	}
 
@@ -187,6 +202,10 @@ func (sub *Subscription) Unsubscribe() {
 
 // subscribe installs the subscription in the event broadcast loop.
 func (es *EventSystem) subscribe(sub *subscription) *Subscription {
+	if sub.logsCrit.PrivateOnly || len(sub.logsCrit.Participants) > 0 {
+		sub.privateLogs = make(chan []*types.Log, privateLogsQueueSize)
+		go es.privateLogsLoop(sub)
+	}
 	es.install <- sub
 	<-sub.installed
 	return &Subscription{ID: sub.id, f: sub, es: es}
@@ -196,6 +215,17 @@ func (es *EventSystem) subscribe(sub *subscription) *Subscription {
 // given criteria to the given logs channel. Default value for the from and to
 // block is "latest". If the fromBlock > toBlock an error is returned.
 func (es *EventSystem) SubscribeLogs(crit ethereum.FilterQuery, logs chan []*types.Log) (*Subscription, error) {
+	return es.SubscribePrivateStateLogs(types.DefaultPrivateStateIdentifier, crit, logs)
+}
+
+// Quorum
+//
+// SubscribePrivateStateLogs is SubscribeLogs for the logs as seen from the given
+// private state. The pending logs are the ones of the default private state,
+// hence they are only written for it. Pending logs are never written when
+// the criteria restrict the logs to private transactions or participants, as
+// their transactions can only be checked once mined.
+func (es *EventSystem) SubscribePrivateStateLogs(psi types.PrivateStateIdentifier, crit ethereum.FilterQuery, logs chan []*types.Log) (*Subscription, error) {
 	var from, to rpc.BlockNumber
 	if crit.FromBlock == nil {
 		from = rpc.LatestBlockNumber
@@ -210,34 +240,35 @@ func (es *EventSystem) SubscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 
 	// only interested in pending logs
 	if from == rpc.PendingBlockNumber && to == rpc.PendingBlockNumber {
-		return es.subscribePendingLogs(crit, logs), nil
+		return es.subscribePendingLogs(psi, crit, logs), nil
 	}
 	// only interested in new mined logs
 	if from == rpc.LatestBlockNumber && to == rpc.LatestBlockNumber {
-		return es.subscribeLogs(crit, logs), nil
+		return es.subscribeLogs(psi, crit, logs), nil
 	}
 	// only interested in mined logs within a specific block range
 	if from >= 0 && to >= 0 && to >= from {
-		return es.subscribeLogs(crit, logs), nil
+		return es.subscribeLogs(psi, crit, logs), nil
 	}
 	// interested in mined logs from a specific block number, new logs and pending logs
 	if from >= rpc.LatestBlockNumber && to == rpc.PendingBlockNumber {
-		return es.subscribeMinedPendingLogs(crit, logs), nil
+		return es.subscribeMinedPendingLogs(psi, crit, logs), nil
 	}
 	// interested in logs from a specific block number to new mined blocks
 	if from >= 0 && to == rpc.LatestBlockNumber {
-		return es.subscribeLogs(crit, logs), nil
+		return es.subscribeLogs(psi, crit, logs), nil
 	}
 	return nil, fmt.Errorf("invalid from and to block combination: from > to")
 }
 
 // subscribeMinedPendingLogs creates a subscription that returned mined and
 // pending logs that match the given criteria.
-func (es *EventSystem) subscribeMinedPendingLogs(crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
+func (es *EventSystem) subscribeMinedPendingLogs(psi types.PrivateStateIdentifier, crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
 	sub := &subscription{
 		id:        rpc.NewID(),
 		typ:       MinedAndPendingLogsSubscription,
 		logsCrit:  crit,
+		psi:       psi,
 		created:   time.Now(),
 		logs:      logs,
 		hashes:    make(chan []common.Hash),
@@ -250,11 +281,12 @@ func (es *EventSystem) subscribeMinedPendingLogs(crit ethereum.FilterQuery, logs
 
 // subscribeLogs creates a subscription that will write all logs matching the
 // given criteria to the given logs channel.
-func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
+func (es *EventSystem) subscribeLogs(psi types.PrivateStateIdentifier, crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
 	sub := &subscription{
 		id:        rpc.NewID(),
 		typ:       LogsSubscription,
 		logsCrit:  crit,
+		psi:       psi,
 		created:   time.Now(),
 		logs:      logs,
 		hashes:    make(chan []common.Hash),
@@ -267,11 +299,12 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 
 // subscribePendingLogs creates a subscription that writes transaction hashes for
 // transactions that enter the transaction pool.
-func (es *EventSystem) subscribePendingLogs(crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
+func (es *EventSystem) subscribePendingLogs(psi types.PrivateStateIdentifier, crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
 	sub := &subscription{
 		id:        rpc.NewID(),
 		typ:       PendingLogsSubscription,
 		logsCrit:  crit,
+		psi:       psi,
 		created:   time.Now(),
 		logs:      logs,
 		hashes:    make(chan []common.Hash),
@@ -326,27 +359,41 @@ func (es *EventSystem) broadcast(filters filterIndex, ev interface{}) {
 	case []*types.Log:
 		if len(e) > 0 {
 			for _, f := range filters[LogsSubscription] {
-				if matchedLogs := filterLogs(e, f.logsCrit.FromBlock, f.logsCrit.ToBlock, f.logsCrit.Addresses, f.logsCrit.Topics); len(matchedLogs) > 0 {
-					f.logs <- matchedLogs
+				if f.psi != types.DefaultPrivateStateIdentifier {
+					continue
 				}
+				es.deliverLogs(f, filterLogs(e, f.logsCrit.FromBlock, f.logsCrit.ToBlock, f.logsCrit.Addresses, f.logsCrit.Topics))
 			}
 		}
 	case core.RemovedLogsEvent:
 		for _, f := range filters[LogsSubscription] {
-			if matchedLogs := filterLogs(e.Logs, f.logsCrit.FromBlock, f.logsCrit.ToBlock, f.logsCrit.Addresses, f.logsCrit.Topics); len(matchedLogs) > 0 {
-				f.logs <- matchedLogs
+			if f.psi != types.DefaultPrivateStateIdentifier {
+				continue
 			}
+			es.deliverLogs(f, filterLogs(e.Logs, f.logsCrit.FromBlock, f.logsCrit.ToBlock, f.logsCrit.Addresses, f.logsCrit.Topics))
 		}
 	case *event.TypeMuxEvent:
 		if muxe, ok := e.Data.(core.PendingLogsEvent); ok {
 			for _, f := range filters[PendingLogsSubscription] {
-				if e.Time.After(f.created) {
+				// the transactions of the pending logs aren't stored yet, so
+				// none of them would match the privacy criteria
+				if f.privateLogs != nil {
+					continue
+				}
+				if e.Time.After(f.created) && f.psi == types.DefaultPrivateStateIdentifier {
 					if matchedLogs := filterLogs(muxe.Logs, nil, f.logsCrit.ToBlock, f.logsCrit.Addresses, f.logsCrit.Topics); len(matchedLogs) > 0 {
 						f.logs <- matchedLogs
 					}
 				}
 			}
 		}
+	case core.PrivateStateLogsEvent:
+		for _, f := range filters[LogsSubscription] {
+			if f.psi != e.PSI {
+				continue
+			}
+			es.deliverLogs(f, filterLogs(e.Logs, f.logsCrit.FromBlock, f.logsCrit.ToBlock, f.logsCrit.Addresses, f.logsCrit.Topics))
+		}
 	case core.NewTxsEvent:
 		hashes := make([]common.Hash, 0, len(e.Txs))
 		for _, tx := range e.Txs {
@@ -362,9 +409,10 @@ func (es *EventSystem) broadcast(filters filterIndex, ev interface{}) {
 		if es.lightMode && len(filters[LogsSubscription]) > 0 {
 			es.lightFilterNewHead(e.Block.Header(), func(header *types.Header, remove bool) {
 				for _, f := range filters[LogsSubscription] {
-					if matchedLogs := es.lightFilterLogs(header, f.logsCrit.Addresses, f.logsCrit.Topics, remove); len(matchedLogs) > 0 {
-						f.logs <- matchedLogs
+					if f.psi != types.DefaultPrivateStateIdentifier {
+						continue
 					}
+					es.deliverLogs(f, es.lightFilterLogs(header, f.logsCrit.Addresses, f.logsCrit.Topics, remove))
 				}
 			})
 		}
@@ -453,6 +501,7 @@ func (es *EventSystem) eventLoop() {
 		es.logsSub.Unsubscribe()
 		es.rmLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
+		es.privateLogsSub.Unsubscribe()
 	}()
 
 	index := make(filterIndex)
@@ -471,6 +520,8 @@ func (es *EventSystem) eventLoop() {
 			es.broadcast(index, ev)
 		case ev := <-es.chainCh:
 			es.broadcast(index, ev)
+		case ev := <-es.privateLogsCh:
+			es.broadcast(index, ev)
 		case ev, active := <-es.pendingLogSub.Chan():
 			if !active { // system stopped
 				return
@@ -506,6 +557,8 @@ func (es *EventSystem) eventLoop() {
 			return
 		case <-es.chainSub.Err():
 			return
+		case <-es.privateLogsSub.Err():
+			return
 		}
 	}
 }
@@ -19,6 +19,7 @@ package ethclient
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -74,6 +75,18 @@ func (ec *Client) WithPrivateTransactionManager(rawurl string) (*Client, error)
 	return ec, nil
 }
 
+// provides support for private transactions with a private transaction manager
+// reached over https, tlsConfig holding the root CAs and, for mutual TLS, the
+// client certificate
+func (ec *Client) WithPrivateTransactionManagerTLS(rawurl string, tlsConfig *tls.Config) (*Client, error) {
+	var err error
+	ec.pc, err = newPrivateTransactionManagerTLSClient(rawurl, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ec, nil
+}
+
 // /Quorum
 
 func (ec *Client) Close() {
@@ -432,6 +445,13 @@ func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
 		}
 		arg["toBlock"] = toBlockNumArg(q.ToBlock)
 	}
+	// Quorum
+	if q.PrivateOnly {
+		arg["privateOnly"] = true
+	}
+	if len(q.Participants) > 0 {
+		arg["participants"] = q.Participants
+	}
 	return arg, nil
 }
 
@@ -536,7 +556,7 @@ func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction, ar
 		return err
 	}
 	if args.PrivateFor != nil {
-		return ec.c.CallContext(ctx, nil, "eth_sendRawPrivateTransaction", common.ToHex(data), bind.PrivateTxArgs{PrivateFor: args.PrivateFor})
+		return ec.c.CallContext(ctx, nil, "eth_sendRawPrivateTransaction", common.ToHex(data), args)
 	} else {
 		return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", common.ToHex(data))
 	}
@@ -556,6 +576,34 @@ func (ec *Client) PreparePrivateTransaction(data []byte, privateFrom string) ([]
 	return encryptedPayloadHash.Bytes(), err
 }
 
+// Quorum
+//
+// SendPrivateTransaction sends the unsigned transaction privately without the
+// node signing it: its payload is stored in the private transaction manager,
+// the transaction with the hash of the payload is signed by opts.Signer with
+// types.QuorumPrivateTxSigner and then sent to the participants given by opts.
+// It returns the signed transaction.
+func (ec *Client) SendPrivateTransaction(ctx context.Context, tx *types.Transaction, opts *bind.TransactOpts) (*types.Transaction, error) {
+	if opts.PrivateFor == nil {
+		return nil, errors.New("private transaction without privateFor")
+	}
+	if opts.Signer == nil {
+		return nil, errors.New("no signer to authorize the transaction with")
+	}
+	payload, err := ec.PreparePrivateTransaction(tx.Data(), opts.PrivateFrom)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := opts.Signer(types.QuorumPrivateTxSigner{}, opts.From, bind.NewPrivateTransaction(tx, payload))
+	if err != nil {
+		return nil, err
+	}
+	if err := ec.SendTransaction(ctx, signedTx, opts.PrivateTxArgs()); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
 func toCallArg(msg ethereum.CallMsg) interface{} {
 	arg := map[string]interface{}{
 		"from": msg.From,
@@ -573,5 +621,12 @@ func toCallArg(msg ethereum.CallMsg) interface{} {
 	if msg.GasPrice != nil {
 		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
 	}
+	// Quorum
+	if msg.PrivateFor != nil {
+		arg["privateFor"] = msg.PrivateFor
+		if msg.PrivateFrom != "" {
+			arg["privateFrom"] = msg.PrivateFrom
+		}
+	}
 	return arg
 }
@@ -26,7 +26,9 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -35,6 +37,9 @@ import (
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -363,3 +368,48 @@ type privateTransactionManagerStubClient struct {
 func (s *privateTransactionManagerStubClient) StoreRaw(_ []byte, _ string) (common.EncryptedPayloadHash, error) {
 	return s.expectedData, nil
 }
+
+type privateTransactionService struct {
+	tx   *types.Transaction
+	args bind.PrivateTxArgs
+}
+
+func (s *privateTransactionService) SendRawPrivateTransaction(encodedTx hexutil.Bytes, args bind.PrivateTxArgs) (common.Hash, error) {
+	s.tx = new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, s.tx); err != nil {
+		return common.Hash{}, err
+	}
+	s.args = args
+	return s.tx.Hash(), nil
+}
+
+func TestClient_SendPrivateTransaction_whenTypical(t *testing.T) {
+	service := &privateTransactionService{}
+	server := rpc.NewServer()
+	defer server.Stop()
+	assert.NoError(t, server.RegisterName("eth", service))
+	expectedData := common.BytesToEncryptedPayloadHash([]byte("arbitrary data"))
+	testObject := NewClientWithPTM(rpc.DialInProc(server), &privateTransactionManagerStubClient{expectedData})
+	opts := bind.NewKeyedTransactor(testKey)
+	opts.PrivateFor = []string{"arbitrary private for"}
+	opts.PrivacyFlag = engine.PrivacyFlagPartyProtection
+
+	signedTx, err := testObject.SendPrivateTransaction(context.Background(), types.NewContractCreation(0, common.Big0, 100000, common.Big0, []byte("arbitrary payload")), opts)
+
+	assert.NoError(t, err)
+	assert.True(t, signedTx.IsPrivate())
+	assert.Equal(t, expectedData.Bytes(), signedTx.Data())
+	from, err := types.Sender(types.QuorumPrivateTxSigner{}, signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, testAddr, from)
+	assert.Equal(t, signedTx.Hash(), service.tx.Hash())
+	assert.Equal(t, opts.PrivateTxArgs(), service.args)
+}
+
+func TestClient_SendPrivateTransaction_whenNotPrivate(t *testing.T) {
+	testObject := NewClientWithPTM(nil, &privateTransactionManagerStubClient{})
+
+	_, err := testObject.SendPrivateTransaction(context.Background(), types.NewContractCreation(0, common.Big0, 100000, common.Big0, nil), bind.NewKeyedTransactor(testKey))
+
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package ethclient
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -32,6 +33,26 @@ func newPrivateTransactionManagerClient(endpoint string) (privateTransactionMana
 	}, nil
 }
 
+// Create a new client to interact with private transaction manager via a HTTPS endpoint
+func newPrivateTransactionManagerTLSClient(endpoint string, tlsConfig *tls.Config) (privateTransactionManagerClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("TLS requires an https endpoint: %s", endpoint)
+	}
+	return &privateTransactionManagerDefaultClient{
+		rawurl: endpoint,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}, nil
+}
+
 type storeRawReq struct {
 	Payload string `json:"payload"`
 	From    string `json:"from,omitempty"`
@@ -1,6 +1,8 @@
 package ethclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -28,7 +30,32 @@ func TestPrivateTransactionManagerClient_storeRaw(t *testing.T) {
 	assert.Equal(t, common.BytesToEncryptedPayloadHash([]byte("arbitrary data")), key)
 }
 
+func TestPrivateTransactionManagerClient_storeRawWithTLS(t *testing.T) {
+	// mock tessera client
+	arbitraryServer := httptest.NewTLSServer(newStoreRawHandler())
+	defer arbitraryServer.Close()
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(arbitraryServer.Certificate())
+	testObject, err := newPrivateTransactionManagerTLSClient(arbitraryServer.URL, &tls.Config{RootCAs: rootCAs})
+	assert.NoError(t, err)
+
+	key, err := testObject.StoreRaw([]byte("arbitrary payload"), "arbitrary private from")
+
+	assert.NoError(t, err)
+	assert.Equal(t, common.BytesToEncryptedPayloadHash([]byte("arbitrary data")), key)
+}
+
+func TestPrivateTransactionManagerClient_whenTLSWithoutHttps(t *testing.T) {
+	_, err := newPrivateTransactionManagerTLSClient("http://localhost:9080", &tls.Config{})
+
+	assert.Error(t, err)
+}
+
 func newStoreRawServer() *httptest.Server {
+	return httptest.NewServer(newStoreRawHandler())
+}
+
+func newStoreRawHandler() http.Handler {
 	arbitraryResponse := fmt.Sprintf(`
 {
 	"key": "%s"
@@ -50,5 +77,5 @@ func newStoreRawServer() *httptest.Server {
 		}
 
 	})
-	return httptest.NewServer(mux)
+	return mux
 }
@@ -8,12 +8,16 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 var (
 	errNotAcceptor = errors.New("account is not acceptor of this extension request")
 	errNotCreator  = errors.New("account is not the creator of this extension request")
+
+	errNotRetractionVoter = errors.New("account is not a voter of this retraction request")
 )
 
 const extensionCompleted = "DONE"
@@ -94,6 +98,34 @@ func (api *PrivateExtensionAPI) checkIfPrivateStateExists(toExtend common.Addres
 	return false
 }
 
+// checkDependencies checks that the dependencies of the contract being
+// extended are distinct private contracts available on the node
+func (api *PrivateExtensionAPI) checkDependencies(toExtend common.Address, dependencies []common.Address) error {
+	listed := map[common.Address]bool{toExtend: true}
+	for _, dependency := range dependencies {
+		if listed[dependency] {
+			return fmt.Errorf("dependency %s listed more than once", dependency.Hex())
+		}
+		listed[dependency] = true
+		if api.checkIfPublicContract(dependency) {
+			return fmt.Errorf("dependency %s is a public contract", dependency.Hex())
+		}
+		if !api.checkIfPrivateStateExists(dependency) {
+			return fmt.Errorf("dependency %s is not an existing private contract", dependency.Hex())
+		}
+	}
+	_, privateStateDb, _ := api.privacyService.stateFetcher.chainAccessor.State()
+	if privateStateDb == nil {
+		return nil
+	}
+	for _, referenced := range referencedContracts(privateStateDb, toExtend) {
+		if !listed[referenced] {
+			log.Warn("Extension: the contract may depend on a private contract which isn't extended along with it", "contract", toExtend.Hex(), "dependency", referenced.Hex())
+		}
+	}
+	return nil
+}
+
 // ApproveContractExtension submits the vote to the specified extension management contract. The vote indicates whether to extend
 // a given contract to a new participant or not
 func (api *PrivateExtensionAPI) ApproveExtension(addressToVoteOn common.Address, vote bool, txa ethapi.SendTxArgs) (string, error) {
@@ -157,6 +189,15 @@ func (api *PrivateExtensionAPI) ApproveExtension(addressToVoteOn common.Address,
 // - the new PTM public key
 // - the Ethereum addresses of who can vote to extend the contract
 func (api *PrivateExtensionAPI) ExtendContract(toExtend common.Address, newRecipientPtmPublicKey string, recipientAddr common.Address, txa ethapi.SendTxArgs) (string, error) {
+	return api.ExtendContractWithDependencies(toExtend, nil, newRecipientPtmPublicKey, recipientAddr, txa)
+}
+
+// ExtendContractWithDependencies extends a contract along with the private
+// contracts it depends on, e.g. the libraries it calls or the contracts it
+// created, in a single extension. Only the given dependencies are shared with
+// the new participant: the contracts the extended one appears to reference are
+// reported in the log but never shared without being listed.
+func (api *PrivateExtensionAPI) ExtendContractWithDependencies(toExtend common.Address, dependencies []common.Address, newRecipientPtmPublicKey string, recipientAddr common.Address, txa ethapi.SendTxArgs) (string, error) {
 
 	// check if the contract to be extended is already under extension
 	// if yes throw an error
@@ -174,6 +215,10 @@ func (api *PrivateExtensionAPI) ExtendContract(toExtend common.Address, newRecip
 		return "", errors.New("extending a non-existent private contract!!! not allowed")
 	}
 
+	if err := api.checkDependencies(toExtend, dependencies); err != nil {
+		return "", err
+	}
+
 	// check if recipient address is 0x0
 	if recipientAddr == (common.Address{0}) {
 		return "", errors.New("invalid recipient address")
@@ -221,6 +266,13 @@ func (api *PrivateExtensionAPI) ExtendContract(toExtend common.Address, newRecip
 		return "", err
 	}
 
+	// the dependencies are kept until the state is shared by this node
+	if len(dependencies) > 0 {
+		if err := api.privacyService.saveDependencies(crypto.CreateAddress(txArgs.From, tx.Nonce()), dependencies); err != nil {
+			return "", err
+		}
+	}
+
 	//Return the transaction hash for later lookup
 	msg := fmt.Sprintf("0x%x", tx.Hash())
 	return msg, nil
@@ -281,3 +333,202 @@ func (api *PrivateExtensionAPI) GetExtensionStatus(extensionContract common.Addr
 
 	return extensionInProgress, nil
 }
+
+// ExtensionHistory returns the history of the extension run by the given
+// management contract, including the votes, the block the state was shared at
+// and the failures to apply it, whether the extension is still active or not
+func (api *PrivateExtensionAPI) ExtensionHistory(extensionContract common.Address) (*ExtensionHistory, error) {
+	return api.privacyService.history.Get(extensionContract)
+}
+
+// ExtensionHistories returns the history of all the extensions this node took
+// part in, optionally restricted to the ones of the given extended contract
+func (api *PrivateExtensionAPI) ExtensionHistories(contractExtended *common.Address) ([]ExtensionHistory, error) {
+	return api.privacyService.history.All(contractExtended)
+}
+
+// ActiveRetractionContracts returns the list of all currently outstanding retraction contracts
+func (api *PrivateExtensionAPI) ActiveRetractionContracts() []RetractionContract {
+	api.privacyService.mu.Lock()
+	defer api.privacyService.mu.Unlock()
+
+	extracted := make([]RetractionContract, 0, len(api.privacyService.currentRetractions))
+	for _, contract := range api.privacyService.currentRetractions {
+		extracted = append(extracted, *contract)
+	}
+	return extracted
+}
+
+// checks of the passed contract address is under retraction process
+func (api *PrivateExtensionAPI) checkIfContractUnderRetraction(toRetract common.Address) bool {
+	for _, v := range api.ActiveRetractionContracts() {
+		if v.ContractRetracted == toRetract {
+			return true
+		}
+	}
+	return false
+}
+
+// RetractContract deploys a new retraction management contract to the blockchain to start the process of removing
+// a party from a contract. The management contract must be sent to all the parties of the contract, including the
+// one to remove, which will mark the contract as frozen once the voters have approved the retraction. The creator
+// is a voter, along with the given ones.
+func (api *PrivateExtensionAPI) RetractContract(toRetract common.Address, removedPtmPublicKey string, voters []common.Address, txa ethapi.SendTxArgs) (string, error) {
+	if api.checkIfContractUnderRetraction(toRetract) {
+		return "", errors.New("contract retraction in progress for the given contract address")
+	}
+	if api.checkIfContractUnderExtension(toRetract) {
+		return "", errors.New("contract extension in progress for the given contract address")
+	}
+	if api.checkIfPublicContract(toRetract) {
+		return "", errors.New("retracting a public contract!!! not allowed")
+	}
+	if !api.checkIfPrivateStateExists(toRetract) {
+		return "", errors.New("retracting a non-existent private contract!!! not allowed")
+	}
+
+	// if running in permissioned mode with new permissions model
+	// ensure that the accounts voting on the retraction are admin accounts
+	if !types.CheckIfAdminAccount(txa.From) {
+		return "", errors.New("account not an org admin account, cannot initiate retraction")
+	}
+	for _, voter := range voters {
+		if !types.CheckIfAdminAccount(voter) {
+			return "", fmt.Errorf("voter account address %s is not an org admin account. cannot vote on retraction", voter.Hex())
+		}
+	}
+
+	// check the removed key is valid
+	if _, err := base64.StdEncoding.DecodeString(removedPtmPublicKey); err != nil {
+		return "", errors.New("invalid removed party transaction manager key provided")
+	}
+	if txa.PrivateFrom == removedPtmPublicKey {
+		return "", errors.New("the party initiating the retraction cannot be removed")
+	}
+	// the removed party must receive the retraction in order to freeze the contract
+	if !checkKeyInList(removedPtmPublicKey, txa.PrivateFor) {
+		return "", errors.New("privateFor argument must include the transaction manager key of the removed party")
+	}
+
+	txArgs, err := api.privacyService.GenerateTransactOptions(txa)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := api.privacyService.managementContractFacade.DeployRetractor(txArgs, toRetract, voters, removedPtmPublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	msg := fmt.Sprintf("0x%x", tx.Hash())
+	return msg, nil
+}
+
+// ApproveRetraction submits the vote to the specified retraction management contract. The vote indicates whether to
+// remove the party from the contract or not, a vote against the retraction finishing it
+func (api *PrivateExtensionAPI) ApproveRetraction(addressToVoteOn common.Address, vote bool, txa ethapi.SendTxArgs) (string, error) {
+	caller, err := api.privacyService.managementContractFacade.RetractorCaller(addressToVoteOn)
+	if err != nil {
+		return "", err
+	}
+	opts := &bind.CallOpts{Pending: true, From: txa.From}
+
+	finished, err := caller.CheckIfRetractionFinished(opts)
+	if err != nil {
+		return "", err
+	}
+	if finished {
+		return "", errors.New("contract retraction process complete. nothing to accept")
+	}
+
+	if !types.CheckIfAdminAccount(txa.From) {
+		return "", errors.New("account cannot accept retraction")
+	}
+
+	txArgs, err := api.privacyService.GenerateTransactOptions(txa)
+	if err != nil {
+		return "", err
+	}
+
+	if isVoter, err := caller.IsVoter(opts, txArgs.From); err != nil {
+		return "", err
+	} else if !isVoter {
+		return "", errNotRetractionVoter
+	}
+	if voted, err := caller.CheckIfVoted(opts); err != nil {
+		return "", err
+	} else if voted {
+		return "", errors.New("already voted")
+	}
+
+	retractor, err := api.privacyService.managementContractFacade.RetractorTransactor(addressToVoteOn)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := retractor.DoVote(txArgs, vote)
+	if err != nil {
+		return "", err
+	}
+	msg := fmt.Sprintf("0x%x", tx.Hash())
+	return msg, nil
+}
+
+// CancelRetraction allows the creator to cancel the given retraction contract, ensuring
+// that no more votes can be made
+func (api *PrivateExtensionAPI) CancelRetraction(retractionContract common.Address, txa ethapi.SendTxArgs) (string, error) {
+	caller, err := api.privacyService.managementContractFacade.RetractorCaller(retractionContract)
+	if err != nil {
+		return "", err
+	}
+	finished, err := caller.CheckIfRetractionFinished(&bind.CallOpts{Pending: true, From: txa.From})
+	if err != nil {
+		return "", err
+	}
+	if finished {
+		return "", errors.New("contract retraction process complete. nothing to cancel")
+	}
+
+	txArgs, err := api.privacyService.GenerateTransactOptions(txa)
+	if err != nil {
+		return "", err
+	}
+
+	creatorAddress, err := caller.Creator(nil)
+	if err != nil {
+		return "", err
+	}
+	if isCreator := checkAddressInList(txArgs.From, []common.Address{creatorAddress}); !isCreator {
+		return "", errNotCreator
+	}
+
+	retractor, err := api.privacyService.managementContractFacade.RetractorTransactor(retractionContract)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := retractor.Finish(txArgs)
+	if err != nil {
+		return "", err
+	}
+	msg := fmt.Sprintf("0x%x", tx.Hash())
+	return msg, nil
+}
+
+// Returns the retraction status from management contract
+func (api *PrivateExtensionAPI) GetRetractionStatus(retractionContract common.Address) (string, error) {
+	caller, err := api.privacyService.managementContractFacade.RetractorCaller(retractionContract)
+	if err != nil {
+		return "", err
+	}
+	finished, err := caller.CheckIfRetractionFinished(&bind.CallOpts{Pending: true})
+	if err != nil {
+		return "", err
+	}
+
+	if finished {
+		return extensionCompleted, nil
+	}
+
+	return extensionInProgress, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
@@ -28,12 +29,17 @@ type PrivacyService struct {
 	stateFetcher             *StateFetcher
 	accountManager           *accounts.Manager
 	dataHandler              DataHandler
+	history                  *ExtensionHistoryStore
 	managementContractFacade ManagementContractFacade
 	extClient                Client
 	stopFeed                 event.Feed
 
-	mu               sync.Mutex
-	currentContracts map[common.Address]*ExtensionContract
+	mu                 sync.Mutex
+	currentContracts   map[common.Address]*ExtensionContract
+	currentRetractions map[common.Address]*RetractionContract
+	// the contracts extended along with the contract of the extensions this
+	// node initiated, by management contract
+	extensionDependencies map[common.Address][]common.Address
 }
 
 var (
@@ -56,11 +62,12 @@ func (service *PrivacyService) subscribeStopEvent() (chan stopEvent, event.Subsc
 	return c, s
 }
 
-func New(ptm private.PrivateTransactionManager, manager *accounts.Manager, handler DataHandler, fetcher *StateFetcher) (*PrivacyService, error) {
+func New(ptm private.PrivateTransactionManager, manager *accounts.Manager, handler DataHandler, fetcher *StateFetcher, history *ExtensionHistoryStore) (*PrivacyService, error) {
 	service := &PrivacyService{
 		currentContracts: make(map[common.Address]*ExtensionContract),
 		ptm:              ptm,
 		dataHandler:      handler,
+		history:          history,
 		stateFetcher:     fetcher,
 		accountManager:   manager,
 	}
@@ -70,6 +77,14 @@ func New(ptm private.PrivateTransactionManager, manager *accounts.Manager, handl
 	if err != nil {
 		return nil, errors.New("could not load existing extension contracts: " + err.Error())
 	}
+	service.currentRetractions, err = service.dataHandler.LoadRetractions()
+	if err != nil {
+		return nil, errors.New("could not load existing retraction contracts: " + err.Error())
+	}
+	service.extensionDependencies, err = service.dataHandler.LoadDependencies()
+	if err != nil {
+		return nil, errors.New("could not load the dependencies of existing extension contracts: " + err.Error())
+	}
 
 	return service, nil
 }
@@ -88,9 +103,13 @@ func (service *PrivacyService) initialise(node *node.Node) {
 	service.extClient = NewInProcessClient(client)
 
 	for _, f := range []func() error{
-		service.watchForNewContracts,       // watch for new extension contract creation event
-		service.watchForCancelledContracts, // watch for extension contract cancellation event
-		service.watchForCompletionEvents,   // watch for extension contract voting complete event
+		service.watchForNewContracts,        // watch for new extension contract creation event
+		service.watchForCancelledContracts,  // watch for extension contract cancellation event
+		service.watchForCompletionEvents,    // watch for extension contract voting complete event
+		service.watchForHistoryEvents,       // watch for extension contract events recorded in the history
+		service.watchForNewRetractions,      // watch for retraction contract creation event
+		service.watchForFinishedRetractions, // watch for retraction contract cancellation or completion event
+		service.watchForRetractionApprovals, // watch for retraction contract voting complete event
 	} {
 		if err := f(); err != nil {
 			log.Error("")
@@ -118,27 +137,15 @@ func (service *PrivacyService) watchForNewContracts() error {
 			case foundLog := <-incomingLogs:
 				service.mu.Lock()
 
-				tx, _ := service.extClient.TransactionByHash(foundLog.TxHash)
-				from, _ := types.QuorumPrivateTxSigner{}.Sender(tx)
-
-				newExtensionEvent, err := extensionContracts.UnpackNewExtensionCreatedLog(foundLog.Data)
+				newContractExtension, err := service.extensionFromCreationLog(foundLog)
 				if err != nil {
-					log.Error("Error unpacking extension creation log", "error", err)
+					log.Error("Error reading extension creation log", "error", err)
 					log.Debug("Errored log", foundLog)
 					service.mu.Unlock()
 					continue
 				}
 
-				newContractExtension := ExtensionContract{
-					ContractExtended:          newExtensionEvent.ToExtend,
-					Initiator:                 from,
-					Recipient:                 newExtensionEvent.RecipientAddress,
-					RecipientPtmKey:           newExtensionEvent.RecipientPTMKey,
-					ManagementContractAddress: foundLog.Address,
-					CreationData:              tx.Data(),
-				}
-
-				service.currentContracts[foundLog.Address] = &newContractExtension
+				service.currentContracts[foundLog.Address] = newContractExtension
 				err = service.dataHandler.Save(service.currentContracts)
 				if err != nil {
 					log.Error("Error writing extension data to file", "error", err)
@@ -180,6 +187,29 @@ func (service *PrivacyService) watchForNewContracts() error {
 	return nil
 }
 
+// extensionFromCreationLog returns the extension run by the management contract
+// whose creation emitted the log
+func (service *PrivacyService) extensionFromCreationLog(l types.Log) (*ExtensionContract, error) {
+	tx, err := service.extClient.TransactionByHash(l.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	from, _ := types.QuorumPrivateTxSigner{}.Sender(tx)
+
+	newExtensionEvent, err := extensionContracts.UnpackNewExtensionCreatedLog(l.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtensionContract{
+		ContractExtended:          newExtensionEvent.ToExtend,
+		Initiator:                 from,
+		Recipient:                 newExtensionEvent.RecipientAddress,
+		RecipientPtmKey:           newExtensionEvent.RecipientPTMKey,
+		ManagementContractAddress: l.Address,
+		CreationData:              tx.Data(),
+	}, nil
+}
+
 func (service *PrivacyService) watchForCancelledContracts() error {
 	incomingLogs, subscription, err := service.extClient.SubscribeToLogs(finishedExtensionQuery)
 
@@ -203,6 +233,12 @@ func (service *PrivacyService) watchForCancelledContracts() error {
 						log.Error("Faile to store list of contracts being extended", "error", err)
 					}
 				}
+				if _, ok := service.extensionDependencies[l.Address]; ok {
+					delete(service.extensionDependencies, l.Address)
+					if err := service.dataHandler.SaveDependencies(service.extensionDependencies); err != nil {
+						log.Error("Failed to store the dependencies of the contracts being extended", "error", err)
+					}
+				}
 				service.mu.Unlock()
 			case <-stopChan:
 				return
@@ -286,7 +322,8 @@ func (service *PrivacyService) watchForCompletionEvents() error {
 						return
 					}
 					log.Debug("Extension: dump current state", "block", l.BlockHash, "contract", contractToExtend.Hex())
-					entireStateData, err := service.stateFetcher.GetAddressStateFromBlock(l.BlockHash, contractToExtend)
+					dependencies := service.extensionDependencies[l.Address]
+					entireStateData, anchoredAccounts, err := service.stateFetcher.GetAddressStateFromBlock(l.BlockHash, contractToExtend, dependencies)
 					if err != nil {
 						log.Error("[state] service.stateFetcher.GetAddressStateFromBlock", "block", l.BlockHash.Hex(), "contract", contractToExtend.Hex(), "error", err)
 						return
@@ -294,12 +331,15 @@ func (service *PrivacyService) watchForCompletionEvents() error {
 
 					log.Debug("Extension: send the state dump to the new recipient", "recipient", recipientPTMKey)
 					//send to PTM
-					hashOfStateData, err := service.ptm.Send(entireStateData, "", []string{recipientPTMKey})
+					hashOfStateData, err := service.ptm.Send(entireStateData, "", []string{recipientPTMKey}, nil)
 					if err != nil {
 						log.Error("[ptm] service.ptm.Send", "stateDataInHex", hex.EncodeToString(entireStateData[:]), "recipient", recipientPTMKey, "error", err)
 						return
 					}
-					hashofStateDataBase64 := base64.StdEncoding.EncodeToString(hashOfStateData.Bytes())
+					// the accounts of the state, with their code hash and storage root,
+					// are anchored on the management contract so that the recipient
+					// rejects any other account, code or storage
+					hashofStateDataBase64 := extensionContracts.EncodeSharedStateHash(base64.StdEncoding.EncodeToString(hashOfStateData.Bytes()), anchoredAccounts)
 
 					transactor, err := service.managementContractFacade.Transactor(l.Address)
 					if err != nil {
@@ -322,7 +362,102 @@ func (service *PrivacyService) watchForCompletionEvents() error {
 	return nil
 }
 
+func (service *PrivacyService) watchForHistoryEvents() error {
+	incomingLogs, subscription, err := service.extClient.SubscribeToLogs(historyQuery)
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		stopChan, stopSubscription := service.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+
+		// the events emitted before the subscription are recorded from the
+		// stored logs, the events seen twice are recorded once
+		storedLogsQuery := historyQuery
+		storedLogsQuery.FromBlock = big.NewInt(0)
+		storedLogs, err := service.extClient.FilterLogs(storedLogsQuery)
+		if err != nil {
+			log.Error("Failed to retrieve the stored extension events", "error", err)
+		}
+		for _, l := range storedLogs {
+			service.recordHistory(l)
+		}
+
+		for {
+			select {
+			case err := <-subscription.Err():
+				log.Error("Contract extension history watcher subscription error", "error", err)
+				return
+			case l := <-incomingLogs:
+				service.recordHistory(l)
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// recordHistory records the event of a management contract in the history of
+// its extension, which starts with the creation of the management contract
+func (service *PrivacyService) recordHistory(l types.Log) {
+	if len(l.Topics) > 0 && l.Topics[0] == common.HexToHash(extensionContracts.NewContractExtensionContractCreatedTopicHash) {
+		if l.Removed {
+			return
+		}
+		extension, err := service.extensionFromCreationLog(l)
+		if err == nil {
+			err = service.history.RecordCreation(extension, l.BlockNumber)
+		}
+		if err != nil {
+			log.Error("Failed to record extension creation in history", "address", l.Address.Hex(), "error", err)
+		}
+		return
+	}
+	if err := service.history.RecordEvent(l); err != nil {
+		log.Error("Failed to record extension event in history", "address", l.Address.Hex(), "error", err)
+	}
+}
+
+// watchForWrittenBlocks records in the history the apply failures seen while
+// processing the blocks, once they are written to the chain
+func (service *PrivacyService) watchForWrittenBlocks(chain *core.BlockChain) {
+	chainEvents := make(chan core.ChainEvent, 10)
+	subscription := chain.SubscribeChainEvent(chainEvents)
+
+	go func() {
+		stopChan, stopSubscription := service.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+		defer subscription.Unsubscribe()
+		for {
+			select {
+			case ev := <-chainEvents:
+				if err := service.history.CommitApplyFailures(ev.Hash); err != nil {
+					log.Error("Failed to record extension failures in history", "block", ev.Hash.Hex(), "error", err)
+				}
+			case <-subscription.Err():
+				return
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+}
+
 // node.Service interface methods:
+// saveDependencies records the contracts extended along with the contract of
+// the extension run by the management contract
+func (service *PrivacyService) saveDependencies(managementContract common.Address, dependencies []common.Address) error {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	service.extensionDependencies[managementContract] = dependencies
+	return service.dataHandler.SaveDependencies(service.extensionDependencies)
+}
+
 func (service *PrivacyService) Protocols() []p2p.Protocol {
 	return []p2p.Protocol{}
 }
@@ -11,6 +11,7 @@ import (
 
 type Client interface {
 	SubscribeToLogs(query ethereum.FilterQuery) (<-chan types.Log, ethereum.Subscription, error)
+	FilterLogs(query ethereum.FilterQuery) ([]types.Log, error)
 	NextNonce(from common.Address) (uint64, error)
 	TransactionByHash(hash common.Hash) (*types.Transaction, error)
 	TransactionInBlock(blockHash common.Hash, txIndex uint) (*types.Transaction, error)
@@ -32,6 +33,10 @@ func (client *InProcessClient) SubscribeToLogs(query ethereum.FilterQuery) (<-ch
 	return retrievedLogsChan, sub, err
 }
 
+func (client *InProcessClient) FilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	return client.client.FilterLogs(context.Background(), query)
+}
+
 func (client *InProcessClient) NextNonce(from common.Address) (uint64, error) {
 	return client.client.PendingNonceAt(context.Background(), from)
 }
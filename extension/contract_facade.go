@@ -16,6 +16,10 @@ type ManagementContractFacade interface {
 	Deploy(args *bind.TransactOpts, toExtend common.Address, recipientAddress common.Address, recipientHash string) (*types.Transaction, error)
 
 	GetAllVoters(addressToVoteOn common.Address) ([]common.Address, error)
+
+	RetractorTransactor(managementAddress common.Address) (*extensionContracts.ContractRetractorTransactor, error)
+	RetractorCaller(managementAddress common.Address) (*extensionContracts.ContractRetractorCaller, error)
+	DeployRetractor(args *bind.TransactOpts, toRetract common.Address, voters []common.Address, removedPtmKey string) (*types.Transaction, error)
 }
 
 type EthclientManagementContractFacade struct {
@@ -59,3 +63,16 @@ func (facade EthclientManagementContractFacade) GetAllVoters(addressToVoteOn com
 	}
 	return voters, nil
 }
+
+func (facade EthclientManagementContractFacade) RetractorTransactor(managementAddress common.Address) (*extensionContracts.ContractRetractorTransactor, error) {
+	return extensionContracts.NewContractRetractorTransactor(managementAddress, facade.client)
+}
+
+func (facade EthclientManagementContractFacade) RetractorCaller(managementAddress common.Address) (*extensionContracts.ContractRetractorCaller, error) {
+	return extensionContracts.NewContractRetractorCaller(managementAddress, facade.client)
+}
+
+func (facade EthclientManagementContractFacade) DeployRetractor(args *bind.TransactOpts, toRetract common.Address, voters []common.Address, removedPtmKey string) (*types.Transaction, error) {
+	_, tx, _, err := extensionContracts.DeployContractRetractor(args, facade.client, toRetract, voters, removedPtmKey)
+	return tx, err
+}
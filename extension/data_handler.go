@@ -11,20 +11,34 @@ import (
 )
 
 const extensionContractData = "activeExtensions.json"
+const retractionContractData = "activeRetractions.json"
+const extensionDependenciesData = "extensionDependencies.json"
 
 type DataHandler interface {
 	Load() (map[common.Address]*ExtensionContract, error)
 
 	Save(extensionContracts map[common.Address]*ExtensionContract) error
+
+	LoadRetractions() (map[common.Address]*RetractionContract, error)
+
+	SaveRetractions(retractionContracts map[common.Address]*RetractionContract) error
+
+	LoadDependencies() (map[common.Address][]common.Address, error)
+
+	SaveDependencies(dependencies map[common.Address][]common.Address) error
 }
 
 type JsonFileDataHandler struct {
-	saveFile string
+	saveFile             string
+	retractionSaveFile   string
+	dependenciesSaveFile string
 }
 
 func NewJsonFileDataHandler(dataDirectory string) *JsonFileDataHandler {
 	return &JsonFileDataHandler{
-		saveFile: filepath.Join(dataDirectory, extensionContractData),
+		saveFile:             filepath.Join(dataDirectory, extensionContractData),
+		retractionSaveFile:   filepath.Join(dataDirectory, retractionContractData),
+		dependenciesSaveFile: filepath.Join(dataDirectory, extensionDependenciesData),
 	}
 }
 
@@ -53,3 +67,57 @@ func (handler *JsonFileDataHandler) Save(extensionContracts map[common.Address]*
 	}
 	return nil
 }
+
+func (handler *JsonFileDataHandler) LoadRetractions() (map[common.Address]*RetractionContract, error) {
+	currentContracts := make(map[common.Address]*RetractionContract)
+	if _, err := os.Stat(handler.retractionSaveFile); err == nil || !os.IsNotExist(err) {
+		blob, err := ioutil.ReadFile(handler.retractionSaveFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(blob, &currentContracts); err != nil {
+			return nil, err
+		}
+	}
+	return currentContracts, nil
+}
+
+func (handler *JsonFileDataHandler) SaveRetractions(retractionContracts map[common.Address]*RetractionContract) error {
+	//no unmarshallable types, so can't error
+	output, _ := json.Marshal(&retractionContracts)
+
+	if errSaving := ioutil.WriteFile(handler.retractionSaveFile, output, 0644); errSaving != nil {
+		log.Error("Couldn't save outstanding retraction contract details")
+		return errSaving
+	}
+	return nil
+}
+
+// LoadDependencies loads the contracts extended along with the contract of
+// the extensions initiated by this node, by management contract
+func (handler *JsonFileDataHandler) LoadDependencies() (map[common.Address][]common.Address, error) {
+	dependencies := make(map[common.Address][]common.Address)
+	if _, err := os.Stat(handler.dependenciesSaveFile); err == nil || !os.IsNotExist(err) {
+		blob, err := ioutil.ReadFile(handler.dependenciesSaveFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(blob, &dependencies); err != nil {
+			return nil, err
+		}
+	}
+	return dependencies, nil
+}
+
+func (handler *JsonFileDataHandler) SaveDependencies(dependencies map[common.Address][]common.Address) error {
+	//no unmarshallable types, so can't error
+	output, _ := json.Marshal(&dependencies)
+
+	if errSaving := ioutil.WriteFile(handler.dependenciesSaveFile, output, 0644); errSaving != nil {
+		log.Error("Couldn't save the dependencies of the extended contracts")
+		return errSaving
+	}
+	return nil
+}
@@ -0,0 +1,1145 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package extensionContracts
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ContractRetractorABI is the input ABI used to generate the binding from.
+const ContractRetractorABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"creator\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"contractToRetract\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"totalNumberOfVoters\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"isFinished\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"haveAllNodesVoted\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"voter\",\"type\":\"address\"}],\"name\":\"isVoter\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"checkIfVoted\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"checkIfRetractionFinished\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"vote\",\"type\":\"bool\"}],\"name\":\"doVote\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"removedPTMKey\",\"type\":\"string\"},{\"name\":\"marker\",\"type\":\"string\"}],\"name\":\"retract\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"finish\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"contractAddress\",\"type\":\"address\"},{\"name\":\"voters\",\"type\":\"address[]\"},{\"name\":\"removedPTMKey\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"toRetract\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"removedPTMKey\",\"type\":\"string\"}],\"name\":\"NewContractRetractionCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"CanPerformRetraction\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"RetractionFinished\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"vote\",\"type\":\"bool\"},{\"indexed\":false,\"name\":\"voter\",\"type\":\"address\"}],\"name\":\"NewRetractionVote\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"toRetract\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"removedPTMKey\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"marker\",\"type\":\"string\"}],\"name\":\"ContractRetracted\",\"type\":\"event\"}]"
+
+var ContractRetractorParsedABI, _ = abi.JSON(strings.NewReader(ContractRetractorABI))
+
+// ContractRetractorBin is the compiled bytecode used for deploying new contracts.
+var ContractRetractorBin = "0x341561000a57600080fd5b61072538036107256080393360005560805173ffffffffffffffffffffffffffffffffffffffff166001553360005260036020526001604060002055600160025560a05160800180516020028101602001906020015b818110156100bb57805173ffffffffffffffffffffffffffffffffffffffff168060005260036020526040600020546100b157600052600360205260016040600020556002546001016002556100b3565b505b602001610060565b5050610725380360800160805173ffffffffffffffffffffffffffffffffffffffff1681526040816020015260c0518060800151808360400152601f01602090046020029060200161072501819083606001396060017fdd3db889a6d7b7f0bcee7f5611357dbfc9af50fb4b9b94ada8cdb955c32b6d9791a16105e3806101426000396000f3341561000a57600080fd5b600436106100af576000357c01000000000000000000000000000000000000000000000000000000009004806302d05d3f146100b4578063bb372b21146100d657806338527727146100f85780637b35296214610104578063f57077d814610112578063a7771ee314610123578063cb2805ec1461015d5780634e26bdf41461010457806387caea78146101ad5780637b543dd11461029a578063d56b288914610363575b600080fd5b60005473ffffffffffffffffffffffffffffffffffffffff1660005260206000f35b60015473ffffffffffffffffffffffffffffffffffffffff1660005260206000f35b60025460005260206000f35b600654151560005260206000f35b61011a610177565b60005260206000f35b602436106100af5760043573ffffffffffffffffffffffffffffffffffffffff166000526003602052604060002054151560005260206000f35b336000526005602052604060002054151560005260206000f35b6004546002541490565b60016006557f5eca300ad40f784f8db734a5ba72aeba97a414ecb1405e7d35d09b9db72f7de2600080a1565b602436106100af57600654156101ca576084806103af6000396000fd5b33600052600360205260406000205415156101ec576064806104336000396000fd5b3360005260056020526040600020541561020d576064806104976000396000fd5b3360005260056020526001604060002055600454600101600455600435151580600052336020527ffba971e6dfb76e79f615b4d742a13ef8afe73d464663b5c58f898552e03f06ab60406000a1610266576103ac610181565b61026e610177565b156103ac577fd847e03b174ed6cbe6b6f2f3ff4f1fd55f6b0604508a9d2a9a9b7e44707d9cbd600080a1005b604436106100af5760005473ffffffffffffffffffffffffffffffffffffffff1633146102ce576084806104fb6000396000fd5b600654156102e3576084806103af6000396000fd5b6102eb610177565b6102fc5760648061057f6000396000fd5b60015473ffffffffffffffffffffffffffffffffffffffff166000526004356020016020526024356020016040526044360360446060377f8da9fbb7a2fdcd97cbdb4e85716ae4583c3d7e145e47a45ffa816a169320cf69601c36016000a16103ac610181565b60065415610378576084806103af6000396000fd5b60005473ffffffffffffffffffffffffffffffffffffffff1633146103a4576084806104fb6000396000fd5b6103ac610181565b00fe08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000002672657472616374696f6e20686173206265656e206d61726b65642061732066696e6973686564000000000000000000000000000000000000000000000000000008c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000136e6f7420616c6c6f77656420746f20766f74650000000000000000000000000008c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d616c726561647920766f7465640000000000000000000000000000000000000008c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000236f6e6c79206c6561646572206d617920706572666f726d207468697320616374696f6e000000000000000000000000000000000000000000000000000000000008c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000186e6f7420616c6c206e6f646573206861766520766f7465640000000000000000"
+
+// ContractRetractorCodeHash is the hash of the code of deployed retraction
+// management contracts. As any contract can emit a retraction event, only the
+// events of contracts with this code are applied.
+var ContractRetractorCodeHash = common.HexToHash("0x865337349457b8f72ecd7bb5753cb2301d13c24cb9fe2f1b9090c878d578b293")
+
+// DeployContractRetractor deploys a new Ethereum contract, binding an instance of ContractRetractor to it.
+func DeployContractRetractor(auth *bind.TransactOpts, backend bind.ContractBackend, contractAddress common.Address, voters []common.Address, removedPTMKey string) (common.Address, *types.Transaction, *ContractRetractor, error) {
+	parsed, err := abi.JSON(strings.NewReader(ContractRetractorABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(ContractRetractorBin), backend, contractAddress, voters, removedPTMKey)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ContractRetractor{ContractRetractorCaller: ContractRetractorCaller{contract: contract}, ContractRetractorTransactor: ContractRetractorTransactor{contract: contract}, ContractRetractorFilterer: ContractRetractorFilterer{contract: contract}}, nil
+}
+
+// ContractRetractor is an auto generated Go binding around an Ethereum contract.
+type ContractRetractor struct {
+	ContractRetractorCaller     // Read-only binding to the contract
+	ContractRetractorTransactor // Write-only binding to the contract
+	ContractRetractorFilterer   // Log filterer for contract events
+}
+
+// ContractRetractorCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ContractRetractorCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractRetractorTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ContractRetractorTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractRetractorFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ContractRetractorFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractRetractorSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ContractRetractorSession struct {
+	Contract     *ContractRetractor // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// ContractRetractorCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ContractRetractorCallerSession struct {
+	Contract *ContractRetractorCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// ContractRetractorTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ContractRetractorTransactorSession struct {
+	Contract     *ContractRetractorTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// ContractRetractorRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ContractRetractorRaw struct {
+	Contract *ContractRetractor // Generic contract binding to access the raw methods on
+}
+
+// ContractRetractorCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ContractRetractorCallerRaw struct {
+	Contract *ContractRetractorCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ContractRetractorTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ContractRetractorTransactorRaw struct {
+	Contract *ContractRetractorTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewContractRetractor creates a new instance of ContractRetractor, bound to a specific deployed contract.
+func NewContractRetractor(address common.Address, backend bind.ContractBackend) (*ContractRetractor, error) {
+	contract, err := bindContractRetractor(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractor{ContractRetractorCaller: ContractRetractorCaller{contract: contract}, ContractRetractorTransactor: ContractRetractorTransactor{contract: contract}, ContractRetractorFilterer: ContractRetractorFilterer{contract: contract}}, nil
+}
+
+// NewContractRetractorCaller creates a new read-only instance of ContractRetractor, bound to a specific deployed contract.
+func NewContractRetractorCaller(address common.Address, caller bind.ContractCaller) (*ContractRetractorCaller, error) {
+	contract, err := bindContractRetractor(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorCaller{contract: contract}, nil
+}
+
+// NewContractRetractorTransactor creates a new write-only instance of ContractRetractor, bound to a specific deployed contract.
+func NewContractRetractorTransactor(address common.Address, transactor bind.ContractTransactor) (*ContractRetractorTransactor, error) {
+	contract, err := bindContractRetractor(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorTransactor{contract: contract}, nil
+}
+
+// NewContractRetractorFilterer creates a new log filterer instance of ContractRetractor, bound to a specific deployed contract.
+func NewContractRetractorFilterer(address common.Address, filterer bind.ContractFilterer) (*ContractRetractorFilterer, error) {
+	contract, err := bindContractRetractor(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorFilterer{contract: contract}, nil
+}
+
+// bindContractRetractor binds a generic wrapper to an already deployed contract.
+func bindContractRetractor(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ContractRetractorABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ContractRetractor *ContractRetractorRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _ContractRetractor.Contract.ContractRetractorCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ContractRetractor *ContractRetractorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.ContractRetractorTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ContractRetractor *ContractRetractorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.ContractRetractorTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ContractRetractor *ContractRetractorCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _ContractRetractor.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ContractRetractor *ContractRetractorTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ContractRetractor *ContractRetractorTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.contract.Transact(opts, method, params...)
+}
+
+// CheckIfRetractionFinished is a free data retrieval call binding the contract method 0x4e26bdf4.
+//
+// Solidity: function checkIfRetractionFinished() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCaller) CheckIfRetractionFinished(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "checkIfRetractionFinished")
+	return *ret0, err
+}
+
+// CheckIfRetractionFinished is a free data retrieval call binding the contract method 0x4e26bdf4.
+//
+// Solidity: function checkIfRetractionFinished() constant returns(bool)
+func (_ContractRetractor *ContractRetractorSession) CheckIfRetractionFinished() (bool, error) {
+	return _ContractRetractor.Contract.CheckIfRetractionFinished(&_ContractRetractor.CallOpts)
+}
+
+// CheckIfRetractionFinished is a free data retrieval call binding the contract method 0x4e26bdf4.
+//
+// Solidity: function checkIfRetractionFinished() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCallerSession) CheckIfRetractionFinished() (bool, error) {
+	return _ContractRetractor.Contract.CheckIfRetractionFinished(&_ContractRetractor.CallOpts)
+}
+
+// CheckIfVoted is a free data retrieval call binding the contract method 0xcb2805ec.
+//
+// Solidity: function checkIfVoted() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCaller) CheckIfVoted(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "checkIfVoted")
+	return *ret0, err
+}
+
+// CheckIfVoted is a free data retrieval call binding the contract method 0xcb2805ec.
+//
+// Solidity: function checkIfVoted() constant returns(bool)
+func (_ContractRetractor *ContractRetractorSession) CheckIfVoted() (bool, error) {
+	return _ContractRetractor.Contract.CheckIfVoted(&_ContractRetractor.CallOpts)
+}
+
+// CheckIfVoted is a free data retrieval call binding the contract method 0xcb2805ec.
+//
+// Solidity: function checkIfVoted() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCallerSession) CheckIfVoted() (bool, error) {
+	return _ContractRetractor.Contract.CheckIfVoted(&_ContractRetractor.CallOpts)
+}
+
+// ContractToRetract is a free data retrieval call binding the contract method 0xbb372b21.
+//
+// Solidity: function contractToRetract() constant returns(address)
+func (_ContractRetractor *ContractRetractorCaller) ContractToRetract(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "contractToRetract")
+	return *ret0, err
+}
+
+// ContractToRetract is a free data retrieval call binding the contract method 0xbb372b21.
+//
+// Solidity: function contractToRetract() constant returns(address)
+func (_ContractRetractor *ContractRetractorSession) ContractToRetract() (common.Address, error) {
+	return _ContractRetractor.Contract.ContractToRetract(&_ContractRetractor.CallOpts)
+}
+
+// ContractToRetract is a free data retrieval call binding the contract method 0xbb372b21.
+//
+// Solidity: function contractToRetract() constant returns(address)
+func (_ContractRetractor *ContractRetractorCallerSession) ContractToRetract() (common.Address, error) {
+	return _ContractRetractor.Contract.ContractToRetract(&_ContractRetractor.CallOpts)
+}
+
+// Creator is a free data retrieval call binding the contract method 0x02d05d3f.
+//
+// Solidity: function creator() constant returns(address)
+func (_ContractRetractor *ContractRetractorCaller) Creator(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "creator")
+	return *ret0, err
+}
+
+// Creator is a free data retrieval call binding the contract method 0x02d05d3f.
+//
+// Solidity: function creator() constant returns(address)
+func (_ContractRetractor *ContractRetractorSession) Creator() (common.Address, error) {
+	return _ContractRetractor.Contract.Creator(&_ContractRetractor.CallOpts)
+}
+
+// Creator is a free data retrieval call binding the contract method 0x02d05d3f.
+//
+// Solidity: function creator() constant returns(address)
+func (_ContractRetractor *ContractRetractorCallerSession) Creator() (common.Address, error) {
+	return _ContractRetractor.Contract.Creator(&_ContractRetractor.CallOpts)
+}
+
+// HaveAllNodesVoted is a free data retrieval call binding the contract method 0xf57077d8.
+//
+// Solidity: function haveAllNodesVoted() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCaller) HaveAllNodesVoted(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "haveAllNodesVoted")
+	return *ret0, err
+}
+
+// HaveAllNodesVoted is a free data retrieval call binding the contract method 0xf57077d8.
+//
+// Solidity: function haveAllNodesVoted() constant returns(bool)
+func (_ContractRetractor *ContractRetractorSession) HaveAllNodesVoted() (bool, error) {
+	return _ContractRetractor.Contract.HaveAllNodesVoted(&_ContractRetractor.CallOpts)
+}
+
+// HaveAllNodesVoted is a free data retrieval call binding the contract method 0xf57077d8.
+//
+// Solidity: function haveAllNodesVoted() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCallerSession) HaveAllNodesVoted() (bool, error) {
+	return _ContractRetractor.Contract.HaveAllNodesVoted(&_ContractRetractor.CallOpts)
+}
+
+// IsFinished is a free data retrieval call binding the contract method 0x7b352962.
+//
+// Solidity: function isFinished() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCaller) IsFinished(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "isFinished")
+	return *ret0, err
+}
+
+// IsFinished is a free data retrieval call binding the contract method 0x7b352962.
+//
+// Solidity: function isFinished() constant returns(bool)
+func (_ContractRetractor *ContractRetractorSession) IsFinished() (bool, error) {
+	return _ContractRetractor.Contract.IsFinished(&_ContractRetractor.CallOpts)
+}
+
+// IsFinished is a free data retrieval call binding the contract method 0x7b352962.
+//
+// Solidity: function isFinished() constant returns(bool)
+func (_ContractRetractor *ContractRetractorCallerSession) IsFinished() (bool, error) {
+	return _ContractRetractor.Contract.IsFinished(&_ContractRetractor.CallOpts)
+}
+
+// IsVoter is a free data retrieval call binding the contract method 0xa7771ee3.
+//
+// Solidity: function isVoter(address voter) constant returns(bool)
+func (_ContractRetractor *ContractRetractorCaller) IsVoter(opts *bind.CallOpts, voter common.Address) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "isVoter", voter)
+	return *ret0, err
+}
+
+// IsVoter is a free data retrieval call binding the contract method 0xa7771ee3.
+//
+// Solidity: function isVoter(address voter) constant returns(bool)
+func (_ContractRetractor *ContractRetractorSession) IsVoter(voter common.Address) (bool, error) {
+	return _ContractRetractor.Contract.IsVoter(&_ContractRetractor.CallOpts, voter)
+}
+
+// IsVoter is a free data retrieval call binding the contract method 0xa7771ee3.
+//
+// Solidity: function isVoter(address voter) constant returns(bool)
+func (_ContractRetractor *ContractRetractorCallerSession) IsVoter(voter common.Address) (bool, error) {
+	return _ContractRetractor.Contract.IsVoter(&_ContractRetractor.CallOpts, voter)
+}
+
+// TotalNumberOfVoters is a free data retrieval call binding the contract method 0x38527727.
+//
+// Solidity: function totalNumberOfVoters() constant returns(uint256)
+func (_ContractRetractor *ContractRetractorCaller) TotalNumberOfVoters(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _ContractRetractor.contract.Call(opts, out, "totalNumberOfVoters")
+	return *ret0, err
+}
+
+// TotalNumberOfVoters is a free data retrieval call binding the contract method 0x38527727.
+//
+// Solidity: function totalNumberOfVoters() constant returns(uint256)
+func (_ContractRetractor *ContractRetractorSession) TotalNumberOfVoters() (*big.Int, error) {
+	return _ContractRetractor.Contract.TotalNumberOfVoters(&_ContractRetractor.CallOpts)
+}
+
+// TotalNumberOfVoters is a free data retrieval call binding the contract method 0x38527727.
+//
+// Solidity: function totalNumberOfVoters() constant returns(uint256)
+func (_ContractRetractor *ContractRetractorCallerSession) TotalNumberOfVoters() (*big.Int, error) {
+	return _ContractRetractor.Contract.TotalNumberOfVoters(&_ContractRetractor.CallOpts)
+}
+
+// DoVote is a paid mutator transaction binding the contract method 0x87caea78.
+//
+// Solidity: function doVote(bool vote) returns()
+func (_ContractRetractor *ContractRetractorTransactor) DoVote(opts *bind.TransactOpts, vote bool) (*types.Transaction, error) {
+	return _ContractRetractor.contract.Transact(opts, "doVote", vote)
+}
+
+// DoVote is a paid mutator transaction binding the contract method 0x87caea78.
+//
+// Solidity: function doVote(bool vote) returns()
+func (_ContractRetractor *ContractRetractorSession) DoVote(vote bool) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.DoVote(&_ContractRetractor.TransactOpts, vote)
+}
+
+// DoVote is a paid mutator transaction binding the contract method 0x87caea78.
+//
+// Solidity: function doVote(bool vote) returns()
+func (_ContractRetractor *ContractRetractorTransactorSession) DoVote(vote bool) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.DoVote(&_ContractRetractor.TransactOpts, vote)
+}
+
+// Finish is a paid mutator transaction binding the contract method 0xd56b2889.
+//
+// Solidity: function finish() returns()
+func (_ContractRetractor *ContractRetractorTransactor) Finish(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractRetractor.contract.Transact(opts, "finish")
+}
+
+// Finish is a paid mutator transaction binding the contract method 0xd56b2889.
+//
+// Solidity: function finish() returns()
+func (_ContractRetractor *ContractRetractorSession) Finish() (*types.Transaction, error) {
+	return _ContractRetractor.Contract.Finish(&_ContractRetractor.TransactOpts)
+}
+
+// Finish is a paid mutator transaction binding the contract method 0xd56b2889.
+//
+// Solidity: function finish() returns()
+func (_ContractRetractor *ContractRetractorTransactorSession) Finish() (*types.Transaction, error) {
+	return _ContractRetractor.Contract.Finish(&_ContractRetractor.TransactOpts)
+}
+
+// Retract is a paid mutator transaction binding the contract method 0x7b543dd1.
+//
+// Solidity: function retract(string removedPTMKey, string marker) returns()
+func (_ContractRetractor *ContractRetractorTransactor) Retract(opts *bind.TransactOpts, removedPTMKey string, marker string) (*types.Transaction, error) {
+	return _ContractRetractor.contract.Transact(opts, "retract", removedPTMKey, marker)
+}
+
+// Retract is a paid mutator transaction binding the contract method 0x7b543dd1.
+//
+// Solidity: function retract(string removedPTMKey, string marker) returns()
+func (_ContractRetractor *ContractRetractorSession) Retract(removedPTMKey string, marker string) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.Retract(&_ContractRetractor.TransactOpts, removedPTMKey, marker)
+}
+
+// Retract is a paid mutator transaction binding the contract method 0x7b543dd1.
+//
+// Solidity: function retract(string removedPTMKey, string marker) returns()
+func (_ContractRetractor *ContractRetractorTransactorSession) Retract(removedPTMKey string, marker string) (*types.Transaction, error) {
+	return _ContractRetractor.Contract.Retract(&_ContractRetractor.TransactOpts, removedPTMKey, marker)
+}
+
+// ContractRetractorCanPerformRetractionIterator is returned from FilterCanPerformRetraction and is used to iterate over the raw logs and unpacked data for CanPerformRetraction events raised by the ContractRetractor contract.
+type ContractRetractorCanPerformRetractionIterator struct {
+	Event *ContractRetractorCanPerformRetraction // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractRetractorCanPerformRetractionIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractRetractorCanPerformRetraction)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractRetractorCanPerformRetraction)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractRetractorCanPerformRetractionIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractRetractorCanPerformRetractionIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractRetractorCanPerformRetraction represents a CanPerformRetraction event raised by the ContractRetractor contract.
+type ContractRetractorCanPerformRetraction struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterCanPerformRetraction is a free log retrieval operation binding the contract event 0xd847e03b174ed6cbe6b6f2f3ff4f1fd55f6b0604508a9d2a9a9b7e44707d9cbd.
+//
+// Solidity: event CanPerformRetraction()
+func (_ContractRetractor *ContractRetractorFilterer) FilterCanPerformRetraction(opts *bind.FilterOpts) (*ContractRetractorCanPerformRetractionIterator, error) {
+
+	logs, sub, err := _ContractRetractor.contract.FilterLogs(opts, "CanPerformRetraction")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorCanPerformRetractionIterator{contract: _ContractRetractor.contract, event: "CanPerformRetraction", logs: logs, sub: sub}, nil
+}
+
+var CanPerformRetractionTopicHash = "0xd847e03b174ed6cbe6b6f2f3ff4f1fd55f6b0604508a9d2a9a9b7e44707d9cbd"
+
+// WatchCanPerformRetraction is a free log subscription operation binding the contract event 0xd847e03b174ed6cbe6b6f2f3ff4f1fd55f6b0604508a9d2a9a9b7e44707d9cbd.
+//
+// Solidity: event CanPerformRetraction()
+func (_ContractRetractor *ContractRetractorFilterer) WatchCanPerformRetraction(opts *bind.WatchOpts, sink chan<- *ContractRetractorCanPerformRetraction) (event.Subscription, error) {
+
+	logs, sub, err := _ContractRetractor.contract.WatchLogs(opts, "CanPerformRetraction")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractRetractorCanPerformRetraction)
+				if err := _ContractRetractor.contract.UnpackLog(event, "CanPerformRetraction", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCanPerformRetraction is a log parse operation binding the contract event 0xd847e03b174ed6cbe6b6f2f3ff4f1fd55f6b0604508a9d2a9a9b7e44707d9cbd.
+//
+// Solidity: event CanPerformRetraction()
+func (_ContractRetractor *ContractRetractorFilterer) ParseCanPerformRetraction(log types.Log) (*ContractRetractorCanPerformRetraction, error) {
+	event := new(ContractRetractorCanPerformRetraction)
+	if err := _ContractRetractor.contract.UnpackLog(event, "CanPerformRetraction", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ContractRetractorContractRetractedIterator is returned from FilterContractRetracted and is used to iterate over the raw logs and unpacked data for ContractRetracted events raised by the ContractRetractor contract.
+type ContractRetractorContractRetractedIterator struct {
+	Event *ContractRetractorContractRetracted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractRetractorContractRetractedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractRetractorContractRetracted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractRetractorContractRetracted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractRetractorContractRetractedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractRetractorContractRetractedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractRetractorContractRetracted represents a ContractRetracted event raised by the ContractRetractor contract.
+type ContractRetractorContractRetracted struct {
+	ToRetract     common.Address
+	RemovedPTMKey string
+	Marker        string
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterContractRetracted is a free log retrieval operation binding the contract event 0x8da9fbb7a2fdcd97cbdb4e85716ae4583c3d7e145e47a45ffa816a169320cf69.
+//
+// Solidity: event ContractRetracted(address toRetract, string removedPTMKey, string marker)
+func (_ContractRetractor *ContractRetractorFilterer) FilterContractRetracted(opts *bind.FilterOpts) (*ContractRetractorContractRetractedIterator, error) {
+
+	logs, sub, err := _ContractRetractor.contract.FilterLogs(opts, "ContractRetracted")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorContractRetractedIterator{contract: _ContractRetractor.contract, event: "ContractRetracted", logs: logs, sub: sub}, nil
+}
+
+var ContractRetractedTopicHash = "0x8da9fbb7a2fdcd97cbdb4e85716ae4583c3d7e145e47a45ffa816a169320cf69"
+
+// WatchContractRetracted is a free log subscription operation binding the contract event 0x8da9fbb7a2fdcd97cbdb4e85716ae4583c3d7e145e47a45ffa816a169320cf69.
+//
+// Solidity: event ContractRetracted(address toRetract, string removedPTMKey, string marker)
+func (_ContractRetractor *ContractRetractorFilterer) WatchContractRetracted(opts *bind.WatchOpts, sink chan<- *ContractRetractorContractRetracted) (event.Subscription, error) {
+
+	logs, sub, err := _ContractRetractor.contract.WatchLogs(opts, "ContractRetracted")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractRetractorContractRetracted)
+				if err := _ContractRetractor.contract.UnpackLog(event, "ContractRetracted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseContractRetracted is a log parse operation binding the contract event 0x8da9fbb7a2fdcd97cbdb4e85716ae4583c3d7e145e47a45ffa816a169320cf69.
+//
+// Solidity: event ContractRetracted(address toRetract, string removedPTMKey, string marker)
+func (_ContractRetractor *ContractRetractorFilterer) ParseContractRetracted(log types.Log) (*ContractRetractorContractRetracted, error) {
+	event := new(ContractRetractorContractRetracted)
+	if err := _ContractRetractor.contract.UnpackLog(event, "ContractRetracted", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ContractRetractorNewContractRetractionCreatedIterator is returned from FilterNewContractRetractionCreated and is used to iterate over the raw logs and unpacked data for NewContractRetractionCreated events raised by the ContractRetractor contract.
+type ContractRetractorNewContractRetractionCreatedIterator struct {
+	Event *ContractRetractorNewContractRetractionCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractRetractorNewContractRetractionCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractRetractorNewContractRetractionCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractRetractorNewContractRetractionCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractRetractorNewContractRetractionCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractRetractorNewContractRetractionCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractRetractorNewContractRetractionCreated represents a NewContractRetractionCreated event raised by the ContractRetractor contract.
+type ContractRetractorNewContractRetractionCreated struct {
+	ToRetract     common.Address
+	RemovedPTMKey string
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewContractRetractionCreated is a free log retrieval operation binding the contract event 0xdd3db889a6d7b7f0bcee7f5611357dbfc9af50fb4b9b94ada8cdb955c32b6d97.
+//
+// Solidity: event NewContractRetractionCreated(address toRetract, string removedPTMKey)
+func (_ContractRetractor *ContractRetractorFilterer) FilterNewContractRetractionCreated(opts *bind.FilterOpts) (*ContractRetractorNewContractRetractionCreatedIterator, error) {
+
+	logs, sub, err := _ContractRetractor.contract.FilterLogs(opts, "NewContractRetractionCreated")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorNewContractRetractionCreatedIterator{contract: _ContractRetractor.contract, event: "NewContractRetractionCreated", logs: logs, sub: sub}, nil
+}
+
+var NewContractRetractionCreatedTopicHash = "0xdd3db889a6d7b7f0bcee7f5611357dbfc9af50fb4b9b94ada8cdb955c32b6d97"
+
+// WatchNewContractRetractionCreated is a free log subscription operation binding the contract event 0xdd3db889a6d7b7f0bcee7f5611357dbfc9af50fb4b9b94ada8cdb955c32b6d97.
+//
+// Solidity: event NewContractRetractionCreated(address toRetract, string removedPTMKey)
+func (_ContractRetractor *ContractRetractorFilterer) WatchNewContractRetractionCreated(opts *bind.WatchOpts, sink chan<- *ContractRetractorNewContractRetractionCreated) (event.Subscription, error) {
+
+	logs, sub, err := _ContractRetractor.contract.WatchLogs(opts, "NewContractRetractionCreated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractRetractorNewContractRetractionCreated)
+				if err := _ContractRetractor.contract.UnpackLog(event, "NewContractRetractionCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewContractRetractionCreated is a log parse operation binding the contract event 0xdd3db889a6d7b7f0bcee7f5611357dbfc9af50fb4b9b94ada8cdb955c32b6d97.
+//
+// Solidity: event NewContractRetractionCreated(address toRetract, string removedPTMKey)
+func (_ContractRetractor *ContractRetractorFilterer) ParseNewContractRetractionCreated(log types.Log) (*ContractRetractorNewContractRetractionCreated, error) {
+	event := new(ContractRetractorNewContractRetractionCreated)
+	if err := _ContractRetractor.contract.UnpackLog(event, "NewContractRetractionCreated", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ContractRetractorNewRetractionVoteIterator is returned from FilterNewRetractionVote and is used to iterate over the raw logs and unpacked data for NewRetractionVote events raised by the ContractRetractor contract.
+type ContractRetractorNewRetractionVoteIterator struct {
+	Event *ContractRetractorNewRetractionVote // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractRetractorNewRetractionVoteIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractRetractorNewRetractionVote)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractRetractorNewRetractionVote)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractRetractorNewRetractionVoteIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractRetractorNewRetractionVoteIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractRetractorNewRetractionVote represents a NewRetractionVote event raised by the ContractRetractor contract.
+type ContractRetractorNewRetractionVote struct {
+	Vote  bool
+	Voter common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewRetractionVote is a free log retrieval operation binding the contract event 0xfba971e6dfb76e79f615b4d742a13ef8afe73d464663b5c58f898552e03f06ab.
+//
+// Solidity: event NewRetractionVote(bool vote, address voter)
+func (_ContractRetractor *ContractRetractorFilterer) FilterNewRetractionVote(opts *bind.FilterOpts) (*ContractRetractorNewRetractionVoteIterator, error) {
+
+	logs, sub, err := _ContractRetractor.contract.FilterLogs(opts, "NewRetractionVote")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorNewRetractionVoteIterator{contract: _ContractRetractor.contract, event: "NewRetractionVote", logs: logs, sub: sub}, nil
+}
+
+var NewRetractionVoteTopicHash = "0xfba971e6dfb76e79f615b4d742a13ef8afe73d464663b5c58f898552e03f06ab"
+
+// WatchNewRetractionVote is a free log subscription operation binding the contract event 0xfba971e6dfb76e79f615b4d742a13ef8afe73d464663b5c58f898552e03f06ab.
+//
+// Solidity: event NewRetractionVote(bool vote, address voter)
+func (_ContractRetractor *ContractRetractorFilterer) WatchNewRetractionVote(opts *bind.WatchOpts, sink chan<- *ContractRetractorNewRetractionVote) (event.Subscription, error) {
+
+	logs, sub, err := _ContractRetractor.contract.WatchLogs(opts, "NewRetractionVote")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractRetractorNewRetractionVote)
+				if err := _ContractRetractor.contract.UnpackLog(event, "NewRetractionVote", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewRetractionVote is a log parse operation binding the contract event 0xfba971e6dfb76e79f615b4d742a13ef8afe73d464663b5c58f898552e03f06ab.
+//
+// Solidity: event NewRetractionVote(bool vote, address voter)
+func (_ContractRetractor *ContractRetractorFilterer) ParseNewRetractionVote(log types.Log) (*ContractRetractorNewRetractionVote, error) {
+	event := new(ContractRetractorNewRetractionVote)
+	if err := _ContractRetractor.contract.UnpackLog(event, "NewRetractionVote", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ContractRetractorRetractionFinishedIterator is returned from FilterRetractionFinished and is used to iterate over the raw logs and unpacked data for RetractionFinished events raised by the ContractRetractor contract.
+type ContractRetractorRetractionFinishedIterator struct {
+	Event *ContractRetractorRetractionFinished // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractRetractorRetractionFinishedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractRetractorRetractionFinished)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractRetractorRetractionFinished)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractRetractorRetractionFinishedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractRetractorRetractionFinishedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractRetractorRetractionFinished represents a RetractionFinished event raised by the ContractRetractor contract.
+type ContractRetractorRetractionFinished struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterRetractionFinished is a free log retrieval operation binding the contract event 0x5eca300ad40f784f8db734a5ba72aeba97a414ecb1405e7d35d09b9db72f7de2.
+//
+// Solidity: event RetractionFinished()
+func (_ContractRetractor *ContractRetractorFilterer) FilterRetractionFinished(opts *bind.FilterOpts) (*ContractRetractorRetractionFinishedIterator, error) {
+
+	logs, sub, err := _ContractRetractor.contract.FilterLogs(opts, "RetractionFinished")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRetractorRetractionFinishedIterator{contract: _ContractRetractor.contract, event: "RetractionFinished", logs: logs, sub: sub}, nil
+}
+
+var RetractionFinishedTopicHash = "0x5eca300ad40f784f8db734a5ba72aeba97a414ecb1405e7d35d09b9db72f7de2"
+
+// WatchRetractionFinished is a free log subscription operation binding the contract event 0x5eca300ad40f784f8db734a5ba72aeba97a414ecb1405e7d35d09b9db72f7de2.
+//
+// Solidity: event RetractionFinished()
+func (_ContractRetractor *ContractRetractorFilterer) WatchRetractionFinished(opts *bind.WatchOpts, sink chan<- *ContractRetractorRetractionFinished) (event.Subscription, error) {
+
+	logs, sub, err := _ContractRetractor.contract.WatchLogs(opts, "RetractionFinished")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractRetractorRetractionFinished)
+				if err := _ContractRetractor.contract.UnpackLog(event, "RetractionFinished", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRetractionFinished is a log parse operation binding the contract event 0x5eca300ad40f784f8db734a5ba72aeba97a414ecb1405e7d35d09b9db72f7de2.
+//
+// Solidity: event RetractionFinished()
+func (_ContractRetractor *ContractRetractorFilterer) ParseRetractionFinished(log types.Log) (*ContractRetractorRetractionFinished, error) {
+	event := new(ContractRetractorRetractionFinished)
+	if err := _ContractRetractor.contract.UnpackLog(event, "RetractionFinished", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,111 @@
+pragma solidity ^0.5.3;
+
+contract ContractRetractor {
+
+    //target details - what to retract and who leads it
+    address public creator;
+    address public contractToRetract;
+
+    //the wallet addresses of the remaining parties that can cast votes
+    uint256 public totalNumberOfVoters;
+    mapping(address => bool) walletAddressesToVoteMap;
+    uint256 numberOfVotesSoFar;
+    mapping(address => bool) hasVotedMapping;
+
+    //if the retraction is done, rejected or cancelled
+    bool public isFinished;
+
+    // General housekeeping
+    event NewContractRetractionCreated(address toRetract, string removedPTMKey); //to tell nodes a new retraction is happening
+    event CanPerformRetraction(); //when all nodes have voted in favour
+    event RetractionFinished(); //if the retraction is cancelled, rejected or completed
+    event NewRetractionVote(bool vote, address voter); // when someone voted (either true or false)
+    event ContractRetracted(address toRetract, string removedPTMKey, string marker); //when the party is removed, the marker is readable by the removed party only
+
+    constructor(address contractAddress, address[] memory voters, string memory removedPTMKey) public {
+        creator = msg.sender;
+        contractToRetract = contractAddress;
+
+        walletAddressesToVoteMap[msg.sender] = true;
+        totalNumberOfVoters = 1;
+        for (uint256 i = 0; i < voters.length; i++) {
+            if (!walletAddressesToVoteMap[voters[i]]) {
+                walletAddressesToVoteMap[voters[i]] = true;
+                totalNumberOfVoters++;
+            }
+        }
+        emit NewContractRetractionCreated(contractAddress, removedPTMKey);
+    }
+
+    /////////////////////////////////////////////////////////////////////////////////////
+    //modifiers
+    /////////////////////////////////////////////////////////////////////////////////////
+    modifier notFinished() {
+        require(!isFinished, "retraction has been marked as finished");
+        _;
+    }
+
+    modifier onlyCreator() {
+        require(msg.sender == creator, "only leader may perform this action");
+        _;
+    }
+
+    /////////////////////////////////////////////////////////////////////////////////////
+    //main
+    /////////////////////////////////////////////////////////////////////////////////////
+    function haveAllNodesVoted() public view returns (bool) {
+        return totalNumberOfVoters == numberOfVotesSoFar;
+    }
+
+    // returns true if the address can vote on the retraction
+    function isVoter(address voter) public view returns (bool) {
+        return walletAddressesToVoteMap[voter];
+    }
+
+    // returns true if the sender address has already voted on the
+    // retraction contract
+    function checkIfVoted() public view returns (bool) {
+        return hasVotedMapping[msg.sender];
+    }
+
+    // returns true if the contract retraction is finished
+    function checkIfRetractionFinished() public view returns (bool) {
+        return isFinished;
+    }
+
+    // single node vote to either retract or not, a vote against the
+    // retraction finishes it
+    function doVote(bool vote) public notFinished() {
+        require(walletAddressesToVoteMap[msg.sender], "not allowed to vote");
+        require(!hasVotedMapping[msg.sender], "already voted");
+
+        hasVotedMapping[msg.sender] = true;
+        numberOfVotesSoFar++;
+        emit NewRetractionVote(vote, msg.sender);
+
+        if (!vote) {
+            setFinished();
+        } else if (haveAllNodesVoted()) {
+            emit CanPerformRetraction();
+        }
+    }
+
+    //the parties have agreed, the marker was sent to the removed party only
+    //via a private transaction so that its node can recognise itself
+    function retract(string memory removedPTMKey, string memory marker) public onlyCreator() notFinished() {
+        require(haveAllNodesVoted(), "not all nodes have voted");
+        emit ContractRetracted(contractToRetract, removedPTMKey, marker);
+        setFinished();
+    }
+
+    //close the contract to further modifications
+    function finish() public notFinished() onlyCreator() {
+        setFinished();
+    }
+
+    // Internal methods
+    function setFinished() internal {
+        isFinished = true;
+        emit RetractionFinished();
+    }
+}
@@ -0,0 +1,180 @@
+package extensionContracts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type retractorTest struct {
+	t         *testing.T
+	backend   *backends.SimulatedBackend
+	retractor *ContractRetractor
+	address   common.Address
+}
+
+func newRetractorTest(t *testing.T, creator *ecdsa.PrivateKey, voters []common.Address, toRetract common.Address, removedPTMKey string) *retractorTest {
+	alloc := core.GenesisAlloc{}
+	for _, key := range testKeys {
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: big.NewInt(1000000000000000000)}
+	}
+	backend := backends.NewSimulatedBackend(alloc, 10000000)
+	address, _, retractor, err := DeployContractRetractor(bind.NewKeyedTransactor(creator), backend, toRetract, voters, removedPTMKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	return &retractorTest{t: t, backend: backend, retractor: retractor, address: address}
+}
+
+// transact sends the transaction and returns the logs of its receipt
+func (rt *retractorTest) transact(tx *types.Transaction, err error) []*types.Log {
+	if err != nil {
+		rt.t.Fatal(err)
+	}
+	rt.backend.Commit()
+	receipt, err := rt.backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		rt.t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		rt.t.Fatalf("transaction %s failed", tx.Hash().Hex())
+	}
+	return receipt.Logs
+}
+
+func (rt *retractorTest) isFinished() bool {
+	finished, err := rt.retractor.CheckIfRetractionFinished(nil)
+	if err != nil {
+		rt.t.Fatal(err)
+	}
+	return finished
+}
+
+func hasTopic(logs []*types.Log, topic string) bool {
+	for _, l := range logs {
+		if l.Topics[0] == common.HexToHash(topic) {
+			return true
+		}
+	}
+	return false
+}
+
+var testKeys = func() []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	return keys
+}()
+
+func TestContractRetractor_whenAllVoteInFavour(t *testing.T) {
+	var (
+		creator   = testKeys[0]
+		voter     = testKeys[1]
+		toRetract = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		voters    = []common.Address{crypto.PubkeyToAddress(voter.PublicKey), crypto.PubkeyToAddress(creator.PublicKey)}
+	)
+	rt := newRetractorTest(t, creator, voters, toRetract, "removedKey")
+
+	creation, err := rt.retractor.FilterNewContractRetractionCreated(&bind.FilterOpts{Start: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !creation.Next() || creation.Event.ToRetract != toRetract || creation.Event.RemovedPTMKey != "removedKey" {
+		t.Fatalf("unexpected creation event %v", creation.Event)
+	}
+	if total, _ := rt.retractor.TotalNumberOfVoters(nil); total.Uint64() != 2 {
+		t.Errorf("expected 2 voters as the creator is listed, got %d", total)
+	}
+	for _, key := range testKeys {
+		expected := key != testKeys[2]
+		if isVoter, _ := rt.retractor.IsVoter(nil, crypto.PubkeyToAddress(key.PublicKey)); isVoter != expected {
+			t.Errorf("expected voter %t for %s", expected, crypto.PubkeyToAddress(key.PublicKey).Hex())
+		}
+	}
+	if _, err := rt.retractor.Retract(bind.NewKeyedTransactor(creator), "removedKey", "marker"); err == nil {
+		t.Errorf("expected retraction to fail before the votes")
+	}
+
+	logs := rt.transact(rt.retractor.DoVote(bind.NewKeyedTransactor(creator), true))
+	if !hasTopic(logs, NewRetractionVoteTopicHash) || hasTopic(logs, CanPerformRetractionTopicHash) {
+		t.Errorf("expected a vote only after the first vote")
+	}
+	if _, err := rt.retractor.DoVote(bind.NewKeyedTransactor(creator), true); err == nil {
+		t.Errorf("expected a second vote to fail")
+	}
+	if _, err := rt.retractor.DoVote(bind.NewKeyedTransactor(testKeys[2]), true); err == nil {
+		t.Errorf("expected a vote from a non voter to fail")
+	}
+	logs = rt.transact(rt.retractor.DoVote(bind.NewKeyedTransactor(voter), true))
+	if !hasTopic(logs, CanPerformRetractionTopicHash) {
+		t.Errorf("expected the retraction to be possible once all voted")
+	}
+	if voted, _ := rt.retractor.CheckIfVoted(&bind.CallOpts{From: crypto.PubkeyToAddress(voter.PublicKey)}); !voted {
+		t.Errorf("expected the voter to have voted")
+	}
+	if _, err := rt.retractor.Retract(bind.NewKeyedTransactor(voter), "removedKey", "marker"); err == nil {
+		t.Errorf("expected retraction by another party than the creator to fail")
+	}
+
+	logs = rt.transact(rt.retractor.Retract(bind.NewKeyedTransactor(creator), "removedKey", "marker"))
+	if !hasTopic(logs, RetractionFinishedTopicHash) || !rt.isFinished() {
+		t.Errorf("expected the retraction to be finished")
+	}
+	retracted, err := rt.retractor.FilterContractRetracted(&bind.FilterOpts{Start: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !retracted.Next() || retracted.Event.ToRetract != toRetract || retracted.Event.RemovedPTMKey != "removedKey" || retracted.Event.Marker != "marker" {
+		t.Errorf("unexpected retraction event %v", retracted.Event)
+	}
+}
+
+func TestContractRetractor_whenVoteAgainst(t *testing.T) {
+	var (
+		creator = testKeys[0]
+		voter   = testKeys[1]
+	)
+	rt := newRetractorTest(t, creator, []common.Address{crypto.PubkeyToAddress(voter.PublicKey)}, common.Address{1}, "removedKey")
+
+	logs := rt.transact(rt.retractor.DoVote(bind.NewKeyedTransactor(voter), false))
+	if !hasTopic(logs, RetractionFinishedTopicHash) || !rt.isFinished() {
+		t.Errorf("expected a vote against the retraction to finish it")
+	}
+	if _, err := rt.retractor.DoVote(bind.NewKeyedTransactor(creator), true); err == nil {
+		t.Errorf("expected a vote on a finished retraction to fail")
+	}
+}
+
+func TestContractRetractor_whenCancelled(t *testing.T) {
+	rt := newRetractorTest(t, testKeys[0], nil, common.Address{1}, "")
+
+	if _, err := rt.retractor.Finish(bind.NewKeyedTransactor(testKeys[1])); err == nil {
+		t.Errorf("expected cancellation by another party than the creator to fail")
+	}
+	rt.transact(rt.retractor.Finish(bind.NewKeyedTransactor(testKeys[0])))
+	if !rt.isFinished() {
+		t.Errorf("expected the retraction to be finished")
+	}
+}
+
+func TestContractRetractor_codeHash(t *testing.T) {
+	rt := newRetractorTest(t, testKeys[0], nil, common.Address{1}, "")
+
+	code, err := rt.backend.CodeAt(context.Background(), rt.address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.Keccak256Hash(code) != ContractRetractorCodeHash {
+		t.Errorf("expected code hash %s, got %s", ContractRetractorCodeHash.Hex(), crypto.Keccak256Hash(code).Hex())
+	}
+}
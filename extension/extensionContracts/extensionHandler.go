@@ -1,6 +1,16 @@
 package extensionContracts
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// sharedStateAccountsSeparator separates the hash of the shared state from
+// the accounts it holds in the hash set on the management contract. It isn't
+// a base64 character, so it doesn't occur in the hash itself.
+const sharedStateAccountsSeparator = "#"
 
 func UnpackStateSharedLog(logData []byte) (common.Address, string, string, error) {
 	decodedLog := new(ContractExtenderStateShared)
@@ -10,9 +20,56 @@ func UnpackStateSharedLog(logData []byte) (common.Address, string, string, error
 	return decodedLog.ToExtend, decodedLog.Tesserahash, decodedLog.Uuid, nil
 }
 
+// EncodeSharedStateHash returns the hash to set on the management contract for
+// the state shared under the transaction manager hash, which anchors the code
+// hashes and storage roots of the accounts of the shared state in the
+// StateShared event
+func EncodeSharedStateHash(ptmHash string, accounts map[common.Address]AnchoredAccount) string {
+	//types can be marshalled, so errors can't occur
+	encodedAccounts, _ := json.Marshal(accounts)
+	return ptmHash + sharedStateAccountsSeparator + string(encodedAccounts)
+}
+
+// DecodeSharedStateHash splits the hash of a StateShared event into the
+// transaction manager hash of the shared state and the accounts it holds. No
+// accounts are returned for the hashes of nodes which don't anchor them.
+func DecodeSharedStateHash(hash string) (string, map[common.Address]AnchoredAccount, error) {
+	separator := strings.Index(hash, sharedStateAccountsSeparator)
+	if separator < 0 {
+		return hash, nil, nil
+	}
+	var accounts map[common.Address]AnchoredAccount
+	if err := json.Unmarshal([]byte(hash[separator+len(sharedStateAccountsSeparator):]), &accounts); err != nil {
+		return "", nil, err
+	}
+	return hash[:separator], accounts, nil
+}
+
 func UnpackNewExtensionCreatedLog(data []byte) (*ContractExtenderNewContractExtensionContractCreated, error) {
 	newExtensionEvent := new(ContractExtenderNewContractExtensionContractCreated)
 	err := ContractExtenderParsedABI.Unpack(newExtensionEvent, "NewContractExtensionContractCreated", data)
 
 	return newExtensionEvent, err
 }
+
+func UnpackNewVoteLog(data []byte) (*ContractExtenderNewVote, error) {
+	newVoteEvent := new(ContractExtenderNewVote)
+	err := ContractExtenderParsedABI.Unpack(newVoteEvent, "NewVote", data)
+
+	return newVoteEvent, err
+}
+
+func UnpackNewRetractionCreatedLog(data []byte) (*ContractRetractorNewContractRetractionCreated, error) {
+	newRetractionEvent := new(ContractRetractorNewContractRetractionCreated)
+	err := ContractRetractorParsedABI.Unpack(newRetractionEvent, "NewContractRetractionCreated", data)
+
+	return newRetractionEvent, err
+}
+
+func UnpackContractRetractedLog(logData []byte) (common.Address, string, string, error) {
+	decodedLog := new(ContractRetractorContractRetracted)
+	if err := ContractRetractorParsedABI.Unpack(decodedLog, "ContractRetracted", logData); err != nil {
+		return common.Address{}, "", "", err
+	}
+	return decodedLog.ToRetract, decodedLog.RemovedPTMKey, decodedLog.Marker, nil
+}
@@ -0,0 +1,34 @@
+package extensionContracts
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSharedStateHash(t *testing.T) {
+	var (
+		ptmHash  = "8SjRHlUBe4hAmTk3KDeJ96RhN+s10xRrHDrxEi1O5W0lJTdLMBHNBnJvhjkVlIv7tJbhZIYPcfMZzVG9WNbvJQ=="
+		accounts = map[common.Address]AnchoredAccount{
+			{1}: {CodeHash: common.Hash{1}, StorageRoot: common.Hash{2}},
+			{2}: {CodeHash: common.Hash{3}, StorageRoot: common.Hash{4}},
+		}
+	)
+
+	hash, decodedAccounts, err := DecodeSharedStateHash(EncodeSharedStateHash(ptmHash, accounts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != ptmHash || !reflect.DeepEqual(decodedAccounts, accounts) {
+		t.Errorf("expected %s %v, got %s %v", ptmHash, accounts, hash, decodedAccounts)
+	}
+
+	hash, decodedAccounts, err = DecodeSharedStateHash(ptmHash)
+	if err != nil || hash != ptmHash || decodedAccounts != nil {
+		t.Errorf("expected a hash without accounts to be kept as is, got %s %v %v", hash, decodedAccounts, err)
+	}
+	if _, _, err := DecodeSharedStateHash(ptmHash + "#{\"0x01"); err == nil {
+		t.Errorf("expected malformed accounts to fail")
+	}
+}
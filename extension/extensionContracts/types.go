@@ -1,9 +1,18 @@
 package extensionContracts
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
 )
 
 type AccountWithMetadata struct {
 	State state.DumpAccount `json:"state"`
 }
+
+// AnchoredAccount holds the code hash and the storage root of an account of a
+// shared state, which the sharing node anchors on the management contract for
+// the recipient to check the shared state against
+type AnchoredAccount struct {
+	CodeHash    common.Hash `json:"codeHash"`
+	StorageRoot common.Hash `json:"storageRoot"`
+}
@@ -10,7 +10,7 @@ import (
 // means we get a effectively random hash, whilst also having a reference
 // transaction inside the PTM
 func generateUuid(contractAddress common.Address, privateFrom string, ptm private.PrivateTransactionManager) (string, error) {
-	hash, err := ptm.Send(contractAddress.Bytes(), privateFrom, []string{})
+	hash, err := ptm.Send(contractAddress.Bytes(), privateFrom, []string{}, nil)
 	if err != nil {
 		return "", err
 	}
@@ -25,3 +25,12 @@ func checkAddressInList(addressToFind common.Address, addressList []common.Addre
 	}
 	return false
 }
+
+func checkKeyInList(keyToFind string, keyList []string) bool {
+	for _, key := range keyList {
+		if keyToFind == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package extension
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/extension/extensionContracts"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const extensionCancelled = "CANCELLED"
+const extensionRejected = "REJECTED"
+
+// the number of blocks whose apply failures are kept until they are written
+const pendingApplyFailuresLimit = 128
+
+var errExtensionHistoryNotFound = errors.New("no history found for the given extension management contract")
+
+// ExtensionVote is a vote cast on a contract extension
+type ExtensionVote struct {
+	Voter       common.Address `json:"voter"`
+	Vote        bool           `json:"vote"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      common.Hash    `json:"txHash"`
+}
+
+// ExtensionApplyFailure describes why the shared state of an extended contract
+// couldn't be applied to the private state of this node
+type ExtensionApplyFailure struct {
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+	Reason      string      `json:"reason"`
+}
+
+// ExtensionHistory records the progress of a contract extension this node takes
+// part in, from the creation of its management contract until it is completed,
+// rejected or cancelled
+type ExtensionHistory struct {
+	ManagementContractAddress common.Address          `json:"managementContractAddress"`
+	ContractExtended          common.Address          `json:"contractExtended"`
+	Initiator                 common.Address          `json:"initiator"`
+	Recipient                 common.Address          `json:"recipient"`
+	RecipientPtmKey           string                  `json:"recipientPtmKey"`
+	Status                    string                  `json:"status"`
+	CreationBlock             uint64                  `json:"creationBlock"`
+	Votes                     []ExtensionVote         `json:"votes"`
+	StateShareBlock           uint64                  `json:"stateShareBlock,omitempty"`
+	FinishBlock               uint64                  `json:"finishBlock,omitempty"`
+	ApplyFailures             []ExtensionApplyFailure `json:"applyFailures"`
+}
+
+type pendingApplyFailure struct {
+	txLog  *types.Log
+	reason string
+}
+
+// ExtensionHistoryStore keeps the history of the contract extensions in the
+// chain database, filled from the events of the management contracts
+type ExtensionHistoryStore struct {
+	db ethdb.Database
+	mu sync.Mutex
+
+	// the apply failures seen while processing blocks, by block hash, until
+	// the blocks are written to the chain
+	pendingFailures   *lru.Cache
+	pendingFailuresMu sync.Mutex
+}
+
+func NewExtensionHistoryStore(db ethdb.Database) *ExtensionHistoryStore {
+	pendingFailures, _ := lru.New(pendingApplyFailuresLimit)
+	return &ExtensionHistoryStore{db: db, pendingFailures: pendingFailures}
+}
+
+// Get returns the history of the extension run by the management contract
+func (store *ExtensionHistoryStore) Get(managementContract common.Address) (*ExtensionHistory, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return store.read(managementContract)
+}
+
+// All returns the history of all the extensions, optionally restricted to the
+// ones of the given extended contract
+func (store *ExtensionHistoryStore) All(contractExtended *common.Address) ([]ExtensionHistory, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	histories := make([]ExtensionHistory, 0)
+	for _, data := range rawdb.ReadAllExtensionHistories(store.db) {
+		var history ExtensionHistory
+		if err := json.Unmarshal(data, &history); err != nil {
+			return nil, err
+		}
+		if contractExtended == nil || history.ContractExtended == *contractExtended {
+			histories = append(histories, history)
+		}
+	}
+	return histories, nil
+}
+
+// RecordCreation records the creation of the management contract of an
+// extension at the given block. Only the extensions whose creation was seen
+// have a history.
+func (store *ExtensionHistoryStore) RecordCreation(extension *ExtensionContract, blockNumber uint64) error {
+	return store.update(extension.ManagementContractAddress, true, func(history *ExtensionHistory) {
+		history.ContractExtended = extension.ContractExtended
+		history.Initiator = extension.Initiator
+		history.Recipient = extension.Recipient
+		history.RecipientPtmKey = extension.RecipientPtmKey
+		history.CreationBlock = blockNumber
+	})
+}
+
+// RecordEvent records a vote, the state share or the end of an extension from
+// the corresponding event of its management contract. Events of management
+// contracts without a history are ignored.
+func (store *ExtensionHistoryStore) RecordEvent(l types.Log) error {
+	if l.Removed || len(l.Topics) == 0 {
+		return nil
+	}
+	switch l.Topics[0] {
+	case common.HexToHash(extensionContracts.NewVoteTopicHash):
+		newVote, err := extensionContracts.UnpackNewVoteLog(l.Data)
+		if err != nil {
+			return err
+		}
+		return store.update(l.Address, false, func(history *ExtensionHistory) {
+			for _, vote := range history.Votes {
+				if vote.Voter == newVote.Voter {
+					return
+				}
+			}
+			history.Votes = append(history.Votes, ExtensionVote{
+				Voter:       newVote.Voter,
+				Vote:        newVote.Vote,
+				BlockNumber: l.BlockNumber,
+				TxHash:      l.TxHash,
+			})
+			// a single vote against the extension finishes it
+			if !newVote.Vote {
+				history.Status = extensionRejected
+			}
+		})
+	case common.HexToHash(extensionContracts.StateSharedTopicHash):
+		return store.update(l.Address, false, func(history *ExtensionHistory) {
+			history.Status = extensionCompleted
+			history.StateShareBlock = l.BlockNumber
+		})
+	case common.HexToHash(extensionContracts.ExtensionFinishedTopicHash):
+		return store.update(l.Address, false, func(history *ExtensionHistory) {
+			// the extension was neither completed nor rejected beforehand
+			if history.Status == extensionInProgress {
+				history.Status = extensionCancelled
+			}
+			history.FinishBlock = l.BlockNumber
+		})
+	}
+	return nil
+}
+
+// QueueApplyFailure keeps the failure to apply the state shared by the
+// management contract in the log until its block is written to the chain, as
+// it is seen while the block is processed and the block may be rejected
+func (store *ExtensionHistoryStore) QueueApplyFailure(txLog *types.Log, reason string) {
+	store.pendingFailuresMu.Lock()
+	defer store.pendingFailuresMu.Unlock()
+
+	var failures []pendingApplyFailure
+	if queued, ok := store.pendingFailures.Get(txLog.BlockHash); ok {
+		failures = queued.([]pendingApplyFailure)
+	}
+	store.pendingFailures.Add(txLog.BlockHash, append(failures, pendingApplyFailure{txLog: txLog, reason: reason}))
+}
+
+// CommitApplyFailures records the failures queued while processing the block,
+// once it is written to the chain
+func (store *ExtensionHistoryStore) CommitApplyFailures(blockHash common.Hash) error {
+	store.pendingFailuresMu.Lock()
+	queued, ok := store.pendingFailures.Get(blockHash)
+	store.pendingFailures.Remove(blockHash)
+	store.pendingFailuresMu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	for _, failure := range queued.([]pendingApplyFailure) {
+		if err := store.RecordApplyFailure(failure.txLog, failure.reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordApplyFailure records that the state shared by the management contract
+// in the log couldn't be applied
+func (store *ExtensionHistoryStore) RecordApplyFailure(txLog *types.Log, reason string) error {
+	return store.update(txLog.Address, false, func(history *ExtensionHistory) {
+		for _, failure := range history.ApplyFailures {
+			if failure.TxHash == txLog.TxHash && failure.Reason == reason {
+				return
+			}
+		}
+		history.ApplyFailures = append(history.ApplyFailures, ExtensionApplyFailure{
+			BlockNumber: txLog.BlockNumber,
+			TxHash:      txLog.TxHash,
+			Reason:      reason,
+		})
+	})
+}
+
+func (store *ExtensionHistoryStore) read(managementContract common.Address) (*ExtensionHistory, error) {
+	data := rawdb.ReadExtensionHistory(store.db, managementContract)
+	if data == nil {
+		return nil, errExtensionHistoryNotFound
+	}
+	var history ExtensionHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// update applies the change to the history of the extension, which is only
+// created with the creation of its management contract
+func (store *ExtensionHistoryStore) update(managementContract common.Address, create bool, change func(history *ExtensionHistory)) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	history, err := store.read(managementContract)
+	if err == errExtensionHistoryNotFound {
+		if !create {
+			return nil
+		}
+		history = &ExtensionHistory{
+			ManagementContractAddress: managementContract,
+			Status:                    extensionInProgress,
+			Votes:                     make([]ExtensionVote, 0),
+			ApplyFailures:             make([]ExtensionApplyFailure, 0),
+		}
+	} else if err != nil {
+		return err
+	}
+	change(history)
+
+	//no unmarshallable types, so can't error
+	data, _ := json.Marshal(history)
+	return rawdb.WriteExtensionHistory(store.db, managementContract, data)
+}
@@ -0,0 +1,165 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/extension/extensionContracts"
+)
+
+func newVoteLog(t *testing.T, managementContract common.Address, voter common.Address, vote bool, blockNumber uint64) types.Log {
+	data, err := extensionContracts.ContractExtenderParsedABI.Events["NewVote"].Inputs.Pack(vote, voter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Address:     managementContract,
+		Topics:      []common.Hash{common.HexToHash(extensionContracts.NewVoteTopicHash)},
+		Data:        data,
+		BlockNumber: blockNumber,
+	}
+}
+
+func eventLog(managementContract common.Address, topic string, blockNumber uint64) types.Log {
+	return types.Log{
+		Address:     managementContract,
+		Topics:      []common.Hash{common.HexToHash(topic)},
+		BlockNumber: blockNumber,
+	}
+}
+
+func TestExtensionHistoryWhenCompleted(t *testing.T) {
+	var (
+		store              = NewExtensionHistoryStore(rawdb.NewMemoryDatabase())
+		managementContract = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		initiator          = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		recipient          = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	)
+	extension := &ExtensionContract{
+		ContractExtended:          common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Initiator:                 initiator,
+		Recipient:                 recipient,
+		ManagementContractAddress: managementContract,
+	}
+	if err := store.RecordCreation(extension, 1); err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []types.Log{
+		newVoteLog(t, managementContract, initiator, true, 2),
+		newVoteLog(t, managementContract, recipient, true, 3),
+		eventLog(managementContract, extensionContracts.StateSharedTopicHash, 4),
+		eventLog(managementContract, extensionContracts.ExtensionFinishedTopicHash, 4),
+	} {
+		if err := store.RecordEvent(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the failures seen while processing a block are recorded once it is written
+	blockHash := common.HexToHash("0x04")
+	store.QueueApplyFailure(&types.Log{Address: managementContract, BlockNumber: 4, BlockHash: blockHash}, "invalid shared state")
+	if history, _ := store.Get(managementContract); len(history.ApplyFailures) != 0 {
+		t.Errorf("expected no apply failure before the block is written, got %v", history.ApplyFailures)
+	}
+	if err := store.CommitApplyFailures(blockHash); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CommitApplyFailures(blockHash); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := store.Get(managementContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.Status != extensionCompleted {
+		t.Errorf("expected status %s, got %s", extensionCompleted, history.Status)
+	}
+	if history.Initiator != initiator || history.CreationBlock != 1 {
+		t.Errorf("unexpected creation %v at block %d", history.Initiator, history.CreationBlock)
+	}
+	if len(history.Votes) != 2 || history.Votes[1].Voter != recipient || !history.Votes[1].Vote || history.Votes[1].BlockNumber != 3 {
+		t.Errorf("unexpected votes %v", history.Votes)
+	}
+	if history.StateShareBlock != 4 || history.FinishBlock != 4 {
+		t.Errorf("expected state share and finish at block 4, got %d and %d", history.StateShareBlock, history.FinishBlock)
+	}
+	if len(history.ApplyFailures) != 1 || history.ApplyFailures[0].Reason != "invalid shared state" {
+		t.Errorf("unexpected apply failures %v", history.ApplyFailures)
+	}
+}
+
+func TestExtensionHistoryWhenRejectedOrCancelled(t *testing.T) {
+	var (
+		store     = NewExtensionHistoryStore(rawdb.NewMemoryDatabase())
+		rejected  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		cancelled = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		extended  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	)
+	for _, managementContract := range []common.Address{rejected, cancelled} {
+		if err := store.RecordCreation(&ExtensionContract{ContractExtended: extended, ManagementContractAddress: managementContract}, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a vote against the extension finishes it before the vote event is emitted
+	for _, l := range []types.Log{
+		eventLog(rejected, extensionContracts.ExtensionFinishedTopicHash, 2),
+		newVoteLog(t, rejected, common.Address{1}, false, 2),
+		eventLog(cancelled, extensionContracts.ExtensionFinishedTopicHash, 3),
+	} {
+		if err := store.RecordEvent(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	histories, err := store.All(&extended)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(histories) != 2 {
+		t.Fatalf("expected 2 histories, got %d", len(histories))
+	}
+	for _, history := range histories {
+		expected := extensionRejected
+		if history.ManagementContractAddress == cancelled {
+			expected = extensionCancelled
+		}
+		if history.Status != expected {
+			t.Errorf("%s: expected status %s, got %s", history.ManagementContractAddress.Hex(), expected, history.Status)
+		}
+	}
+	other := common.HexToAddress("0x5555555555555555555555555555555555555555")
+	if histories, _ := store.All(&other); len(histories) != 0 {
+		t.Errorf("expected no history for another contract, got %d", len(histories))
+	}
+	if _, err := store.Get(other); err != errExtensionHistoryNotFound {
+		t.Errorf("expected %v, got %v", errExtensionHistoryNotFound, err)
+	}
+}
+
+func TestExtensionHistoryWhenCreationNotSeen(t *testing.T) {
+	var (
+		store              = NewExtensionHistoryStore(rawdb.NewMemoryDatabase())
+		managementContract = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	)
+	for _, l := range []types.Log{
+		newVoteLog(t, managementContract, common.Address{1}, true, 2),
+		eventLog(managementContract, extensionContracts.StateSharedTopicHash, 3),
+		eventLog(managementContract, extensionContracts.ExtensionFinishedTopicHash, 3),
+	} {
+		if err := store.RecordEvent(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.RecordApplyFailure(&types.Log{Address: managementContract, BlockNumber: 3}, "invalid shared state"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Get(managementContract); err != errExtensionHistoryNotFound {
+		t.Errorf("expected %v, got %v", errExtensionHistoryNotFound, err)
+	}
+	if histories, _ := store.All(nil); len(histories) != 0 {
+		t.Errorf("expected no history, got %d", len(histories))
+	}
+}
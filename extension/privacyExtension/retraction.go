@@ -0,0 +1,84 @@
+package privacyExtension
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	extension "github.com/ethereum/go-ethereum/extension/extensionContracts"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func logContainsRetractionTopic(receivedLog *types.Log) bool {
+	if len(receivedLog.Topics) != 1 {
+		return false
+	}
+	return receivedLog.Topics[0].String() == extension.ContractRetractedTopicHash
+}
+
+// applyRetraction records the outcome of a retraction in the privacy metadata
+// of the contract in the private state: the node of the removed party freezes
+// the contract, the nodes of the remaining parties record the key of the
+// removed party so that it isn't sent future transactions. Events which
+// weren't emitted by a retraction management contract are ignored.
+func (handler *ExtensionHandler) applyRetraction(txLog *types.Log, privateState *state.StateDB) error {
+	if privateState.GetCodeHash(txLog.Address) != extension.ContractRetractorCodeHash {
+		log.Warn("Retraction: ignoring a retraction event not emitted by a retraction management contract", "address", txLog.Address.Hex())
+		return nil
+	}
+	toRetract, removedKey, marker, err := extension.UnpackContractRetractedLog(txLog.Data)
+	if err != nil {
+		log.Error("Retraction: could not unpack the retraction log", "error", err)
+		return nil
+	}
+	if len(privateState.GetCode(toRetract)) == 0 {
+		log.Warn("Retraction: the retracted contract doesn't exist in the private state", "contract", toRetract.Hex())
+		return nil
+	}
+	removed, err := handler.isRemovedParty(toRetract, marker)
+	if err != nil {
+		log.Error("Retraction: could not check whether this node was removed from the parties of the contract", "contract", toRetract.Hex(), "error", err)
+		return err
+	}
+	pm := privateState.GetPrivacyMetadata(toRetract)
+	if pm == nil {
+		pm = &state.PrivacyMetadata{}
+	}
+	if removed {
+		log.Info("Retraction: this node was removed from the parties of the contract, freezing it", "contract", toRetract.Hex())
+		pm.Frozen = true
+	} else {
+		log.Info("Retraction: party removed from the contract", "contract", toRetract.Hex(), "party", removedKey)
+		for _, party := range pm.RetractedParties {
+			if party == removedKey {
+				return nil
+			}
+		}
+		pm.RetractedParties = append(append([]string{}, pm.RetractedParties...), removedKey)
+	}
+	privateState.SetPrivacyMetadata(toRetract, pm)
+	return nil
+}
+
+// isRemovedParty checks whether this node is the removed party, which is the
+// only recipient of the marker sent by the initiator of the retraction. The
+// errors of the transaction manager are returned rather than taken as this
+// node not being removed.
+func (handler *ExtensionHandler) isRemovedParty(toRetract common.Address, marker string) (bool, error) {
+	markerHash := common.BytesToEncryptedPayloadHash(common.FromHex(marker))
+	data, _, err := handler.ptm.Receive(markerHash)
+	if err != nil {
+		return false, err
+	}
+	if data == nil {
+		return false, nil
+	}
+	if common.BytesToAddress(data) != toRetract {
+		log.Error("Retraction: wrong address in retrieved marker")
+		return false, nil
+	}
+	isSender, err := handler.ptm.IsSender(markerHash)
+	if err != nil {
+		return false, err
+	}
+	return !isSender, nil
+}
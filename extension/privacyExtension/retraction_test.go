@@ -0,0 +1,123 @@
+package privacyExtension
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/extension/extensionContracts"
+	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
+)
+
+var (
+	retractorAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	toRetract        = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+type markerPrivateTransactionManager struct {
+	private.PrivateTransactionManager
+	payloads map[common.EncryptedPayloadHash][]byte
+	err      error
+}
+
+func (ptm *markerPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	return ptm.payloads[txHash], nil, ptm.err
+}
+
+func (ptm *markerPrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
+	return false, nil
+}
+
+func retractionLog(t *testing.T, emitter common.Address, removedKey string, marker common.EncryptedPayloadHash) *types.Log {
+	data, err := extensionContracts.ContractRetractorParsedABI.Events["ContractRetracted"].Inputs.Pack(toRetract, removedKey, marker.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Log{
+		Address: emitter,
+		Topics:  []common.Hash{common.HexToHash(extensionContracts.ContractRetractedTopicHash)},
+		Data:    data,
+	}
+}
+
+// newRetractionPrivateState returns a private state holding the contract to
+// retract and the retraction management contract at retractorAddress
+func newRetractionPrivateState(t *testing.T) *state.StateDB {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := common.FromHex(extensionContracts.ContractRetractorBin)
+	// the runtime code is the part of the constructor it copies and returns
+	statedb.SetCode(retractorAddress, bin[0x142:0x142+0x5e3])
+	statedb.SetCode(toRetract, []byte{1})
+	return statedb
+}
+
+func TestCheckExtensionAndSetPrivateState_whenRetracted(t *testing.T) {
+	var (
+		marker    = common.EncryptedPayloadHash{1}
+		removed   = &markerPrivateTransactionManager{payloads: map[common.EncryptedPayloadHash][]byte{marker: toRetract.Bytes()}}
+		remaining = &markerPrivateTransactionManager{}
+	)
+	removedState, remainingState := newRetractionPrivateState(t), newRetractionPrivateState(t)
+	if removedState.GetCodeHash(retractorAddress) != extensionContracts.ContractRetractorCodeHash {
+		t.Fatalf("wrong retraction management contract code")
+	}
+
+	if err := NewExtensionHandler(removed, nil).CheckExtensionAndSetPrivateState([]*types.Log{retractionLog(t, retractorAddress, "removedKey", marker)}, removedState); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewExtensionHandler(remaining, nil).CheckExtensionAndSetPrivateState([]*types.Log{retractionLog(t, retractorAddress, "removedKey", marker)}, remainingState); err != nil {
+		t.Fatal(err)
+	}
+
+	if pm := removedState.GetPrivacyMetadata(toRetract); pm == nil || !pm.Frozen || len(pm.RetractedParties) != 0 {
+		t.Errorf("expected the removed node to freeze the contract, got %v", pm)
+	}
+	pm := remainingState.GetPrivacyMetadata(toRetract)
+	if pm == nil || pm.Frozen {
+		t.Fatalf("expected the remaining node not to freeze the contract, got %v", pm)
+	}
+	if len(pm.RetractedParties) != 1 || pm.RetractedParties[0] != "removedKey" {
+		t.Errorf("expected the remaining node to record the removed party, got %v", pm.RetractedParties)
+	}
+}
+
+func TestCheckExtensionAndSetPrivateState_whenRetractionForged(t *testing.T) {
+	var (
+		marker  = common.EncryptedPayloadHash{1}
+		removed = &markerPrivateTransactionManager{payloads: map[common.EncryptedPayloadHash][]byte{marker: toRetract.Bytes()}}
+		forger  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	)
+	privateState := newRetractionPrivateState(t)
+	privateState.SetCode(forger, []byte{1})
+
+	if err := NewExtensionHandler(removed, nil).CheckExtensionAndSetPrivateState([]*types.Log{retractionLog(t, forger, "removedKey", marker)}, privateState); err != nil {
+		t.Fatal(err)
+	}
+
+	if pm := privateState.GetPrivacyMetadata(toRetract); pm != nil {
+		t.Errorf("expected a retraction event of another contract to be ignored, got %v", pm)
+	}
+}
+
+func TestCheckExtensionAndSetPrivateState_whenTransactionManagerFails(t *testing.T) {
+	var (
+		marker = common.EncryptedPayloadHash{1}
+		ptm    = &markerPrivateTransactionManager{err: &engine.TransportError{Op: "receive"}}
+	)
+	privateState := newRetractionPrivateState(t)
+
+	err := NewExtensionHandler(ptm, nil).CheckExtensionAndSetPrivateState([]*types.Log{retractionLog(t, retractorAddress, "removedKey", marker)}, privateState)
+
+	if !engine.IsTransportError(err) {
+		t.Errorf("expected the transport error to be returned, got %v", err)
+	}
+	if pm := privateState.GetPrivacyMetadata(toRetract); pm != nil {
+		t.Errorf("expected no retraction to be recorded, got %v", pm)
+	}
+}
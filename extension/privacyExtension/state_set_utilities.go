@@ -1,13 +1,18 @@
 package privacyExtension
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	extension "github.com/ethereum/go-ethereum/extension/extensionContracts"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
 func setState(privateState *state.StateDB, accounts map[string]extension.AccountWithMetadata) bool {
@@ -16,6 +21,12 @@ func setState(privateState *state.StateDB, accounts map[string]extension.Account
 		stateDump := value.State
 
 		contractAddress := common.HexToAddress(key)
+		// a contract the extended one depends on may already be known, e.g.
+		// a library this node is a party to, its own state is then kept
+		if privateState.GetCodeSize(contractAddress) > 0 {
+			log.Debug("Extension: keep the existing state of a dependent contract", "address", key)
+			continue
+		}
 
 		newBalance, errBalanceSet := new(big.Int).SetString(stateDump.Balance, 10)
 		if !errBalanceSet {
@@ -39,18 +50,66 @@ func logContainsExtensionTopic(receivedLog *types.Log) bool {
 	return receivedLog.Topics[0].String() == extension.StateSharedTopicHash
 }
 
-// validateAccountsExist checks that all the accounts in the expected list are
-// present in the state map, and that no  other accounts exist in the state map
-// that are unexpected
-func validateAccountsExist(expectedAccounts []common.Address, actualAccounts map[string]extension.AccountWithMetadata) bool {
-	if len(expectedAccounts) != len(actualAccounts) {
+// validateAccountsExist checks that the state map holds exactly the accounts
+// anchored on the management contract, which must include the extended one,
+// so that no account outside of them can be planted
+func validateAccountsExist(extended common.Address, expectedAccounts []common.Address, actualAccounts map[string]extension.AccountWithMetadata) bool {
+	expected := make(map[string]bool)
+	for _, account := range expectedAccounts {
+		expected[account.String()] = true
+	}
+	if !expected[extended.String()] || len(expected) != len(actualAccounts) {
 		return false
 	}
-	for _, account := range expectedAccounts {
-		_, exists := actualAccounts[account.String()]
-		if !exists {
+	for key := range actualAccounts {
+		if !expected[key] {
 			return false
 		}
 	}
 	return true
 }
+
+// validateAccountsIntegrity checks that the code and storage of each account
+// match the code hash and storage root anchored on the management contract,
+// so that a tampered state dump can't plant arbitrary code or storage
+func validateAccountsIntegrity(accounts map[string]extension.AccountWithMetadata, anchoredAccounts map[common.Address]extension.AnchoredAccount) error {
+	for key, value := range accounts {
+		stateDump := value.State
+
+		anchored, found := anchoredAccounts[common.HexToAddress(key)]
+		if !found {
+			return fmt.Errorf("account %s not anchored on the management contract", key)
+		}
+		if codeHash := crypto.Keccak256Hash(common.Hex2Bytes(stateDump.Code)); codeHash != anchored.CodeHash {
+			return fmt.Errorf("code hash mismatch for account %s: expected %x, computed %x", key, anchored.CodeHash, codeHash)
+		}
+		storageRoot, err := storageRootOf(stateDump.Storage)
+		if err != nil {
+			return err
+		}
+		if storageRoot != anchored.StorageRoot {
+			return fmt.Errorf("storage root mismatch for account %s: expected %x, computed %x", key, anchored.StorageRoot, storageRoot)
+		}
+	}
+	return nil
+}
+
+// storageRootOf computes the root of the storage trie holding the storage of
+// the state dump, encoded as the state objects do
+func storageRootOf(storage map[common.Hash]string) (common.Hash, error) {
+	storageTrie, err := trie.NewSecure(common.Hash{}, trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	for key, value := range storage {
+		word := common.HexToHash(value)
+		if word == (common.Hash{}) {
+			continue
+		}
+		encoded, _ := rlp.EncodeToBytes(common.TrimLeftZeroes(word[:]))
+		if err := storageTrie.TryUpdate(key[:], encoded); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	return storageTrie.Hash(), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	extension "github.com/ethereum/go-ethereum/extension/extensionContracts"
 )
 
@@ -106,3 +107,75 @@ func TestStateSetWithListedAccountsFailsOnInvalidBalance(t *testing.T) {
 		t.Errorf("error expected when setting state")
 	}
 }
+
+func TestValidateAccountsExistWithDependencies(t *testing.T) {
+	var (
+		extended   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		dependency = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		planted    = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	)
+	accounts := map[string]extension.AccountWithMetadata{
+		extended.Hex():   {},
+		dependency.Hex(): {},
+	}
+
+	if !validateAccountsExist(extended, []common.Address{extended, dependency}, accounts) {
+		t.Errorf("expected the accounts to be valid")
+	}
+	if validateAccountsExist(extended, []common.Address{dependency}, accounts) {
+		t.Errorf("expected accounts without the extended one to be invalid")
+	}
+	if validateAccountsExist(extended, []common.Address{extended, dependency, planted}, accounts) {
+		t.Errorf("expected a missing account to be invalid")
+	}
+	if validateAccountsExist(extended, []common.Address{extended}, accounts) {
+		t.Errorf("expected an account which isn't anchored to be invalid")
+	}
+	accounts[planted.Hex()] = extension.AccountWithMetadata{}
+	delete(accounts, dependency.Hex())
+	if validateAccountsExist(extended, []common.Address{extended, dependency}, accounts) {
+		t.Errorf("expected a planted account to be invalid")
+	}
+}
+
+func TestValidateAccountsIntegrity(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	address := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	statedb.SetCode(address, []byte{3, 3, 3})
+	statedb.SetState(address, common.Hash{1}, common.HexToHash("0x0100"))
+	statedb.SetState(address, common.Hash{2}, common.HexToHash("0x02"))
+	statedb.Commit(false)
+
+	dump, _ := statedb.DumpAddress(address)
+	validAccount := func() extension.AccountWithMetadata {
+		account := extension.AccountWithMetadata{State: dump}
+		account.State.Storage = make(map[common.Hash]string)
+		for key, value := range dump.Storage {
+			account.State.Storage[key] = value
+		}
+		return account
+	}
+	anchoredAccounts := map[common.Address]extension.AnchoredAccount{
+		address: {CodeHash: statedb.GetCodeHash(address), StorageRoot: statedb.StorageTrie(address).Hash()},
+	}
+
+	if err := validateAccountsIntegrity(map[string]extension.AccountWithMetadata{address.Hex(): validAccount()}, anchoredAccounts); err != nil {
+		t.Errorf("expected valid state, got %v", err)
+	}
+	if err := validateAccountsIntegrity(map[string]extension.AccountWithMetadata{address.Hex(): validAccount()}, nil); err == nil {
+		t.Errorf("expected an account which isn't anchored to fail the integrity check")
+	}
+
+	plantedStorage := validAccount()
+	plantedStorage.State.Storage[common.Hash{3}] = "03"
+	if err := validateAccountsIntegrity(map[string]extension.AccountWithMetadata{address.Hex(): plantedStorage}, anchoredAccounts); err == nil {
+		t.Errorf("expected planted storage to fail the integrity check")
+	}
+	// the code hash of the dump doesn't matter, only the anchored one
+	plantedCode := validAccount()
+	plantedCode.State.Code = "04"
+	plantedCode.State.CodeHash = common.Bytes2Hex(crypto.Keccak256([]byte{4}))
+	if err := validateAccountsIntegrity(map[string]extension.AccountWithMetadata{address.Hex(): plantedCode}, anchoredAccounts); err == nil {
+		t.Errorf("expected planted code to fail the integrity check")
+	}
+}
@@ -13,18 +13,31 @@ import (
 	"github.com/ethereum/go-ethereum/private"
 )
 
-var DefaultExtensionHandler = NewExtensionHandler(private.P)
+// ApplyFailureHandler is notified when the state shared by the management
+// contract of the log can't be applied
+type ApplyFailureHandler func(txLog *types.Log, reason string)
 
 type ExtensionHandler struct {
-	ptm private.PrivateTransactionManager
+	ptm            private.PrivateTransactionManager
+	onApplyFailure ApplyFailureHandler
 }
 
-func NewExtensionHandler(transactionManager private.PrivateTransactionManager) *ExtensionHandler {
-	return &ExtensionHandler{ptm: transactionManager}
+func NewExtensionHandler(transactionManager private.PrivateTransactionManager, onApplyFailure ApplyFailureHandler) *ExtensionHandler {
+	return &ExtensionHandler{ptm: transactionManager, onApplyFailure: onApplyFailure}
 }
 
-func (handler *ExtensionHandler) CheckExtensionAndSetPrivateState(txLogs []*types.Log, privateState *state.StateDB) {
+// CheckExtensionAndSetPrivateState applies the shared states and retractions
+// of the logs to the private state. An error is returned when the transaction
+// manager can't tell whether a retraction applies to this node, as the block
+// can't be processed consistently then.
+func (handler *ExtensionHandler) CheckExtensionAndSetPrivateState(txLogs []*types.Log, privateState *state.StateDB) error {
 	for _, txLog := range txLogs {
+		if logContainsRetractionTopic(txLog) {
+			if err := handler.applyRetraction(txLog, privateState); err != nil {
+				return err
+			}
+			continue
+		}
 		if logContainsExtensionTopic(txLog) {
 			//this is a direct state share
 			address, hash, uuid, err := extension.UnpackStateSharedLog(txLog.Data)
@@ -36,20 +49,51 @@ func (handler *ExtensionHandler) CheckExtensionAndSetPrivateState(txLogs []*type
 			if privateState.GetCode(address) != nil {
 				continue
 			}
-			accounts, found := handler.FetchStateData(txLog.Address, hash, uuid)
+			ptmHash, anchoredAccounts, err := extension.DecodeSharedStateHash(hash)
+			if err != nil {
+				log.Error("Extension: could not decode the shared state hash", "hash", hash, "error", err)
+				handler.applyFailed(txLog, "malformed shared state hash")
+				continue
+			}
+			// the state can only be checked against the accounts anchored on
+			// the management contract by the sharing node
+			if anchoredAccounts == nil {
+				log.Error("Extension: the shared state isn't anchored on the management contract", "hash", hash)
+				handler.applyFailed(txLog, "shared state not anchored on the management contract")
+				continue
+			}
+			sharedAccounts := make([]common.Address, 0, len(anchoredAccounts))
+			for account := range anchoredAccounts {
+				sharedAccounts = append(sharedAccounts, account)
+			}
+			accounts, found := handler.FetchStateData(txLog.Address, ptmHash, uuid)
 			if !found {
 				continue
 			}
-			if !validateAccountsExist([]common.Address{address}, accounts) {
-				log.Error("Account mismatch", "expected", address, "found", accounts)
+			if !validateAccountsExist(address, sharedAccounts, accounts) {
+				log.Error("Account mismatch", "expected", sharedAccounts, "found", accounts)
+				handler.applyFailed(txLog, "shared state doesn't match the extended contract")
+				continue
+			}
+			if err := validateAccountsIntegrity(accounts, anchoredAccounts); err != nil {
+				log.Error("Extension: shared state failed the integrity check", "error", err)
+				handler.applyFailed(txLog, err.Error())
 				continue
 			}
 			snapshotId := privateState.Snapshot()
 			if success := setState(privateState, accounts); !success {
 				privateState.RevertToSnapshot(snapshotId)
+				handler.applyFailed(txLog, "invalid shared state")
 			}
 		}
 	}
+	return nil
+}
+
+func (handler *ExtensionHandler) applyFailed(txLog *types.Log, reason string) {
+	if handler.onApplyFailure != nil {
+		handler.onApplyFailure(txLog, reason)
+	}
 }
 
 func (handler *ExtensionHandler) FetchStateData(address common.Address, hash string, uuid string) (map[string]extension.AccountWithMetadata, bool) {
@@ -76,7 +120,7 @@ func (handler *ExtensionHandler) FetchStateData(address common.Address, hash str
 
 func (handler *ExtensionHandler) FetchDataFromPTM(hash string) ([]byte, bool) {
 	ptmHash, _ := base64.StdEncoding.DecodeString(hash)
-	stateData, err := handler.ptm.Receive(common.BytesToEncryptedPayloadHash(ptmHash))
+	stateData, _, err := handler.ptm.Receive(common.BytesToEncryptedPayloadHash(ptmHash))
 
 	if stateData == nil {
 		log.Error("No state data found in PTM", "ptm hash", hash)
@@ -102,7 +146,7 @@ func (handler *ExtensionHandler) UuidIsOwn(address common.Address, uuid string)
 		log.Debug("Extension: could not determine if we are sender", "err", err.Error())
 		return false
 	}
-	data, _ := handler.ptm.Receive(encryptedTxHash)
+	data, _, _ := handler.ptm.Receive(encryptedTxHash)
 	retrievedAddress := common.BytesToAddress(data)
 	if !bytes.Equal(retrievedAddress.Bytes(), address.Bytes()) {
 		log.Error("Extension: wrong address in retrieved UUID")
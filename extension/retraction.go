@@ -0,0 +1,200 @@
+package extension
+
+import (
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/extension/extensionContracts"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The retraction of a contract mirrors its extension: a retraction management
+// contract is deployed privately to all the parties of the contract, including
+// the one to remove, and the voters of the remaining parties approve it. The
+// initiator then sends the removed party a marker which only its node can read,
+// and completes the retraction. Upon the completion, the removed node freezes
+// the contract and the other nodes stop sending its transactions to the
+// removed party, see privacyExtension.
+
+func (service *PrivacyService) watchForNewRetractions() error {
+	incomingLogs, subscription, err := service.extClient.SubscribeToLogs(newRetractionQuery)
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		stopChan, stopSubscription := service.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+		for {
+			select {
+			case err := <-subscription.Err():
+				log.Error("Contract retraction watcher subscription error", "error", err)
+				return
+
+			case foundLog := <-incomingLogs:
+				tx, _ := service.extClient.TransactionByHash(foundLog.TxHash)
+				from, _ := types.QuorumPrivateTxSigner{}.Sender(tx)
+
+				newRetractionEvent, err := extensionContracts.UnpackNewRetractionCreatedLog(foundLog.Data)
+				if err != nil {
+					log.Error("Error unpacking retraction creation log", "error", err)
+					continue
+				}
+
+				newContractRetraction := RetractionContract{
+					ContractRetracted:         newRetractionEvent.ToRetract,
+					Initiator:                 from,
+					RemovedPtmKey:             newRetractionEvent.RemovedPTMKey,
+					ManagementContractAddress: foundLog.Address,
+					CreationData:              tx.Data(),
+				}
+
+				service.mu.Lock()
+				service.currentRetractions[foundLog.Address] = &newContractRetraction
+				err = service.dataHandler.SaveRetractions(service.currentRetractions)
+				service.mu.Unlock()
+				if err != nil {
+					log.Error("Error writing retraction data to file", "error", err)
+					continue
+				}
+
+				// if party is sender then complete self voting
+				data := common.BytesToEncryptedPayloadHash(newContractRetraction.CreationData)
+				if isSender, _ := service.ptm.IsSender(data); !isSender {
+					continue
+				}
+				fetchedParties, err := service.ptm.GetParticipants(data)
+				if err != nil || len(fetchedParties) == 0 {
+					log.Error("Retraction: unable to fetch all parties for retraction management contract", "error", err)
+					continue
+				}
+				txArgs := ethapi.SendTxArgs{From: from, PrivateTxArgs: ethapi.PrivateTxArgs{PrivateFor: fetchedParties}}
+				if _, err := NewPrivateExtensionAPI(service).ApproveRetraction(foundLog.Address, true, txArgs); err != nil {
+					log.Error("Retraction: initiator vote on management contract failed", "error", err)
+				}
+
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (service *PrivacyService) watchForFinishedRetractions() error {
+	incomingLogs, subscription, err := service.extClient.SubscribeToLogs(finishedRetractionQuery)
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		stopChan, stopSubscription := service.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+		for {
+			select {
+			case err := <-subscription.Err():
+				log.Error("Contract retraction finish watcher subscription error", "error", err)
+				return
+			case l := <-incomingLogs:
+				service.mu.Lock()
+				if _, ok := service.currentRetractions[l.Address]; ok {
+					delete(service.currentRetractions, l.Address)
+					if err := service.dataHandler.SaveRetractions(service.currentRetractions); err != nil {
+						log.Error("Failed to store list of contracts being retracted", "error", err)
+					}
+				}
+				service.mu.Unlock()
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (service *PrivacyService) watchForRetractionApprovals() error {
+	incomingLogs, subscription, err := service.extClient.SubscribeToLogs(canPerformRetractionQuery)
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		stopChan, stopSubscription := service.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+		for {
+			select {
+			case err := <-subscription.Err():
+				log.Error("Contract retraction approval watcher subscription error", "error", err)
+				return
+			case l := <-incomingLogs:
+				log.Debug("Retraction: Received an approval event", "address", l.Address.Hex(), "blockNumber", l.BlockNumber)
+				service.mu.Lock()
+				retractionEntry, ok := service.currentRetractions[l.Address]
+				service.mu.Unlock()
+				if !ok {
+					// we didn't have this management contract, so ignore it
+					log.Debug("Retraction: this node doesn't participate in the contract retractor", "address", l.Address.Hex())
+					continue
+				}
+				if err := service.completeRetraction(retractionEntry); err != nil {
+					log.Error("Retraction: failed to complete the retraction", "address", l.Address.Hex(), "error", err)
+				}
+			case <-stopChan:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// completeRetraction sends the marker to the removed party and completes the
+// retraction, if this node has the account of the initiator
+func (service *PrivacyService) completeRetraction(retraction *RetractionContract) error {
+	caller, err := service.managementContractFacade.RetractorCaller(retraction.ManagementContractAddress)
+	if err != nil {
+		return err
+	}
+	contractCreator, err := caller.Creator(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := service.accountManager.Find(accounts.Account{Address: contractCreator}); err != nil {
+		log.Debug("Retraction: this node doesn't have the account that created the contract retractor", "account", contractCreator.Hex())
+		return nil
+	}
+
+	payload := common.BytesToEncryptedPayloadHash(retraction.CreationData)
+	fetchedParties, err := service.ptm.GetParticipants(payload)
+	if err != nil {
+		return err
+	}
+	txArgs, err := service.GenerateTransactOptions(ethapi.SendTxArgs{From: contractCreator, PrivateTxArgs: ethapi.PrivateTxArgs{PrivateFor: fetchedParties}})
+	if err != nil {
+		return err
+	}
+
+	// only the node of the removed party can read the marker, which is how it
+	// recognises itself when the retraction is completed
+	marker, err := service.ptm.Send(retraction.ContractRetracted.Bytes(), "", []string{retraction.RemovedPtmKey}, nil)
+	if err != nil {
+		return err
+	}
+
+	transactor, err := service.managementContractFacade.RetractorTransactor(retraction.ManagementContractAddress)
+	if err != nil {
+		return err
+	}
+	tx, err := transactor.Retract(txArgs, retraction.RemovedPtmKey, marker.String())
+	if err != nil {
+		return err
+	}
+	log.Debug("Retraction: transaction completing the retraction", "txhash", tx.Hash(), "private", tx.IsPrivate())
+	return nil
+}
@@ -14,6 +14,7 @@ type ServicesFactory interface {
 	AccountManager() *accounts.Manager
 	DataHandler() DataHandler
 	StateFetcher() *StateFetcher
+	HistoryStore() *ExtensionHistoryStore
 }
 
 type DefaultServicesFactory struct {
@@ -21,6 +22,7 @@ type DefaultServicesFactory struct {
 	accountManager *accounts.Manager
 	dataHandler    *JsonFileDataHandler
 	stateFetcher   *StateFetcher
+	historyStore   *ExtensionHistoryStore
 }
 
 func NewServicesFactory(node *node.Node, ptm private.PrivateTransactionManager, ethService *eth.Ethereum) (*DefaultServicesFactory, error) {
@@ -29,14 +31,16 @@ func NewServicesFactory(node *node.Node, ptm private.PrivateTransactionManager,
 	factory.accountManager = ethService.AccountManager()
 	factory.dataHandler = NewJsonFileDataHandler(node.InstanceDir())
 	factory.stateFetcher = NewStateFetcher(ethService.BlockChain())
+	factory.historyStore = NewExtensionHistoryStore(ethService.ChainDb())
 
-	backendService, err := New(ptm, factory.AccountManager(), factory.DataHandler(), factory.StateFetcher())
+	backendService, err := New(ptm, factory.AccountManager(), factory.DataHandler(), factory.StateFetcher(), factory.HistoryStore())
 	if err != nil {
 		return nil, err
 	}
 	factory.backendService = backendService
 
-	ethService.BlockChain().PopulateSetPrivateState(privacyExtension.DefaultExtensionHandler.CheckExtensionAndSetPrivateState)
+	ethService.BlockChain().PopulateSetPrivateState(privacyExtension.NewExtensionHandler(ptm, factory.historyStore.QueueApplyFailure).CheckExtensionAndSetPrivateState)
+	backendService.watchForWrittenBlocks(ethService.BlockChain())
 
 	go backendService.initialise(node)
 
@@ -58,3 +62,7 @@ func (factory *DefaultServicesFactory) DataHandler() DataHandler {
 func (factory *DefaultServicesFactory) StateFetcher() *StateFetcher {
 	return factory.stateFetcher
 }
+
+func (factory *DefaultServicesFactory) HistoryStore() *ExtensionHistoryStore {
+	return factory.historyStore
+}
@@ -1,12 +1,15 @@
 package extension
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/extension/extensionContracts"
 )
 
@@ -33,17 +36,20 @@ func NewStateFetcher(chainAccessor ChainAccessor) *StateFetcher {
 
 // GetAddressStateFromBlock is a public method that combines the other
 // functions of a StateFetcher, retrieving the state of an address at a given
-// block, represented in JSON.
-func (fetcher *StateFetcher) GetAddressStateFromBlock(blockHash common.Hash, addressToFetch common.Address) ([]byte, error) {
+// block, along with the state of the private contracts it depends on given by
+// the initiator of the extension, represented in JSON. The code hashes and
+// storage roots of the accounts are returned to be anchored on the management
+// contract.
+func (fetcher *StateFetcher) GetAddressStateFromBlock(blockHash common.Hash, addressToFetch common.Address, dependencies []common.Address) ([]byte, map[common.Address]extensionContracts.AnchoredAccount, error) {
 	privateState, err := fetcher.privateState(blockHash)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	stateData, err := fetcher.addressStateAsJson(privateState, addressToFetch)
+	stateData, anchoredAccounts, err := fetcher.addressStateAsJson(privateState, addressToFetch, dependencies)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return stateData, nil
+	return stateData, anchoredAccounts, nil
 }
 
 // privateState returns the private state database for a given block hash.
@@ -55,18 +61,70 @@ func (fetcher *StateFetcher) privateState(blockHash common.Hash) (*state.StateDB
 }
 
 // addressStateAsJson returns the state of an address, including the balance,
-// nonce, code and state data as a JSON map.
-func (fetcher *StateFetcher) addressStateAsJson(privateState *state.StateDB, addressToShare common.Address) ([]byte, error) {
+// nonce, code and state data as a JSON map. The state of the given private
+// contracts the address depends on is included so that they are extended
+// together, along with the code hashes and storage roots of the accounts.
+func (fetcher *StateFetcher) addressStateAsJson(privateState *state.StateDB, addressToShare common.Address, dependencies []common.Address) ([]byte, map[common.Address]extensionContracts.AnchoredAccount, error) {
 	keepAddresses := make(map[string]extensionContracts.AccountWithMetadata)
+	anchoredAccounts := make(map[common.Address]extensionContracts.AnchoredAccount)
 
-	if account, found := privateState.DumpAddress(addressToShare); found {
-		keepAddresses[addressToShare.Hex()] = extensionContracts.AccountWithMetadata{
+	for _, address := range append([]common.Address{addressToShare}, dependencies...) {
+		account, found := privateState.DumpAddress(address)
+		if !found {
+			return nil, nil, fmt.Errorf("error in contract state fetch")
+		}
+		keepAddresses[address.Hex()] = extensionContracts.AccountWithMetadata{
 			State: account,
 		}
-	} else {
-		return nil, fmt.Errorf("error in contract state fetch")
+		anchoredAccounts[address] = extensionContracts.AnchoredAccount{
+			CodeHash:    privateState.GetCodeHash(address),
+			StorageRoot: privateState.StorageTrie(address).Hash(),
+		}
 	}
 	//types can be marshalled, so errors can't occur
 	out, _ := json.Marshal(&keepAddresses)
-	return out, nil
+	return out, anchoredAccounts, nil
+}
+
+// referencedContracts returns the private contracts the account may depend
+// on: the contracts it created, and the contracts whose address is in its code,
+// e.g. linked libraries, or in its storage, e.g. child contracts. Any word
+// looking like the address of a contract is taken, so these are only reported
+// to the initiator of an extension and never shared without being listed.
+func referencedContracts(privateState *state.StateDB, address common.Address) []common.Address {
+	account, found := privateState.DumpAddress(address)
+	if !found {
+		return nil
+	}
+	var candidates []common.Address
+	// the nonce of a contract is incremented for each contract it creates
+	for nonce := uint64(0); nonce < account.Nonce; nonce++ {
+		candidates = append(candidates, crypto.CreateAddress(address, nonce))
+	}
+	code := common.Hex2Bytes(account.Code)
+	for i := 0; i < len(code); i++ {
+		op := vm.OpCode(code[i])
+		if !op.IsPush() {
+			continue
+		}
+		size := int(op-vm.PUSH1) + 1
+		if op == vm.PUSH20 && i+1+size <= len(code) {
+			candidates = append(candidates, common.BytesToAddress(code[i+1:i+1+size]))
+		}
+		i += size
+	}
+	for _, value := range account.Storage {
+		word := common.HexToHash(value)
+		if bytes.Equal(word[:common.HashLength-common.AddressLength], make([]byte, common.HashLength-common.AddressLength)) {
+			candidates = append(candidates, common.BytesToAddress(word[common.HashLength-common.AddressLength:]))
+		}
+	}
+
+	var referenced []common.Address
+	for _, candidate := range candidates {
+		if candidate != address && privateState.GetCodeSize(candidate) > 0 {
+			referenced = append(referenced, candidate)
+		}
+	}
+	return referenced
 }
@@ -1,12 +1,16 @@
 package extension
 
 import (
+	"encoding/json"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/extension/extensionContracts"
 )
 
 func TestDumpAddressWhenFound(t *testing.T) {
@@ -23,13 +27,20 @@ func TestDumpAddressWhenFound(t *testing.T) {
 	statedb.SetCode(address, []byte{3, 3, 3, 3, 3, 3, 3})
 	statedb.Commit(false)
 
-	out, _ := stateFetcher.addressStateAsJson(statedb, address)
+	out, anchoredAccounts, _ := stateFetcher.addressStateAsJson(statedb, address, nil)
 
 	want := `{"0x2222222222222222222222222222222222222222":{"state":{"balance":"22","nonce":0,"root":"56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","codeHash":"87874902497a5bb968da31a2998d8f22e949d1ef6214bcdedd8bae24cca4b9e3","code":"03030303030303"}}}`
 
 	if string(out) != want {
 		t.Errorf("dump mismatch:\ngot: %s\nwant: %s\n", string(out), want)
 	}
+	wantAnchored := extensionContracts.AnchoredAccount{
+		CodeHash:    common.HexToHash("87874902497a5bb968da31a2998d8f22e949d1ef6214bcdedd8bae24cca4b9e3"),
+		StorageRoot: common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
+	}
+	if len(anchoredAccounts) != 1 || anchoredAccounts[address] != wantAnchored {
+		t.Errorf("anchored accounts mismatch:\ngot: %v\nwant: %v\n", anchoredAccounts, wantAnchored)
+	}
 }
 
 func TestDumpAddressWhenNotFound(t *testing.T) {
@@ -41,9 +52,99 @@ func TestDumpAddressWhenNotFound(t *testing.T) {
 	stateFetcher := NewStateFetcher(nil)
 
 	address := common.HexToAddress("0x2222222222222222222222222222222222222222")
-	out, _ := stateFetcher.addressStateAsJson(statedb, address)
+	out, _, _ := stateFetcher.addressStateAsJson(statedb, address, nil)
 
 	if out != nil {
 		t.Errorf("dump mismatch:\ngot: %s\nwant: nil\n", string(out))
 	}
 }
+
+func TestDumpAddressIncludesListedDependencies(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+
+	var (
+		address  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		listed   = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		unlisted = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	)
+	statedb.SetCode(address, append([]byte{byte(vm.PUSH20)}, unlisted.Bytes()...))
+	statedb.SetState(address, common.Hash{}, listed.Hash())
+	statedb.SetCode(listed, []byte{3})
+	statedb.SetCode(unlisted, []byte{3})
+	statedb.Commit(false)
+
+	out, anchoredAccounts, err := NewStateFetcher(nil).addressStateAsJson(statedb, address, []common.Address{listed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var accounts map[string]extensionContracts.AccountWithMetadata
+	if err := json.Unmarshal(out, &accounts); err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Errorf("expected 2 accounts, got %d", len(accounts))
+	}
+	for _, expected := range []common.Address{address, listed} {
+		if _, ok := accounts[expected.Hex()]; !ok {
+			t.Errorf("expected account %s in the dump", expected.Hex())
+		}
+		if _, ok := anchoredAccounts[expected]; !ok {
+			t.Errorf("expected account %s to be anchored", expected.Hex())
+		}
+	}
+
+	if _, _, err := NewStateFetcher(nil).addressStateAsJson(statedb, address, []common.Address{{5}}); err == nil {
+		t.Errorf("expected a missing dependency to fail the dump")
+	}
+}
+
+func TestReferencedContracts(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+
+	var (
+		address    = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		referenced = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		linked     = common.HexToAddress("0x4444444444444444444444444444444444444444")
+		created    = crypto.CreateAddress(address, 1)
+		unknown    = common.HexToAddress("0x5555555555555555555555555555555555555555")
+		last       = common.HexToAddress("0x6666666666666666666666666666666666666666")
+	)
+	// PUSH20 <linked> PUSH20 <unknown> PUSH1 0x73 (PUSH20 as push data) PUSH20 <last>
+	code := append([]byte{byte(vm.PUSH20)}, linked.Bytes()...)
+	code = append(code, byte(vm.PUSH20))
+	code = append(code, unknown.Bytes()...)
+	code = append(code, byte(vm.PUSH1), byte(vm.PUSH20))
+	code = append(code, byte(vm.PUSH20))
+	code = append(code, last.Bytes()...)
+	statedb.SetCode(address, code)
+	statedb.SetNonce(address, 2)
+	statedb.SetState(address, common.Hash{}, referenced.Hash())
+	statedb.SetState(address, common.Hash{1}, common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	for _, dependency := range []common.Address{referenced, linked, created, last} {
+		statedb.SetCode(dependency, []byte{3})
+	}
+	statedb.Commit(false)
+
+	found := make(map[common.Address]bool)
+	for _, address := range referencedContracts(statedb, address) {
+		found[address] = true
+	}
+	if len(found) != 4 {
+		t.Errorf("expected 4 referenced contracts, got %d", len(found))
+	}
+	for _, expected := range []common.Address{referenced, linked, created, last} {
+		if !found[expected] {
+			t.Errorf("expected contract %s to be referenced", expected.Hex())
+		}
+	}
+
+	// the push data of a truncated PUSH20 isn't an address
+	truncated := common.HexToAddress("0x7777777777777777777777777777777777777777")
+	statedb.SetCode(truncated, append([]byte{byte(vm.PUSH20)}, last.Bytes()[:common.AddressLength-1]...))
+	statedb.Commit(false)
+	if referenced := referencedContracts(statedb, truncated); len(referenced) != 0 {
+		t.Errorf("expected no referenced contract, got %v", referenced)
+	}
+}
@@ -28,6 +28,39 @@ var (
 		Topics:    [][]common.Hash{{common.HexToHash(extensionContracts.CanPerformStateShareTopicHash)}},
 		Addresses: []common.Address{},
 	}
+
+	newRetractionQuery = ethereum.FilterQuery{
+		FromBlock: nil,
+		ToBlock:   nil,
+		Topics:    [][]common.Hash{{common.HexToHash(extensionContracts.NewContractRetractionCreatedTopicHash)}},
+		Addresses: []common.Address{},
+	}
+
+	finishedRetractionQuery = ethereum.FilterQuery{
+		FromBlock: nil,
+		ToBlock:   nil,
+		Topics:    [][]common.Hash{{common.HexToHash(extensionContracts.RetractionFinishedTopicHash)}},
+		Addresses: []common.Address{},
+	}
+
+	canPerformRetractionQuery = ethereum.FilterQuery{
+		FromBlock: nil,
+		ToBlock:   nil,
+		Topics:    [][]common.Hash{{common.HexToHash(extensionContracts.CanPerformRetractionTopicHash)}},
+		Addresses: []common.Address{},
+	}
+
+	historyQuery = ethereum.FilterQuery{
+		FromBlock: nil,
+		ToBlock:   nil,
+		Topics: [][]common.Hash{{
+			common.HexToHash(extensionContracts.NewContractExtensionContractCreatedTopicHash),
+			common.HexToHash(extensionContracts.NewVoteTopicHash),
+			common.HexToHash(extensionContracts.StateSharedTopicHash),
+			common.HexToHash(extensionContracts.ExtensionFinishedTopicHash),
+		}},
+		Addresses: []common.Address{},
+	}
 )
 
 type ExtensionContract struct {
@@ -38,3 +71,11 @@ type ExtensionContract struct {
 	RecipientPtmKey           string         `json:"recipientPtmKey"`
 	CreationData              []byte         `json:"creationData"`
 }
+
+type RetractionContract struct {
+	ContractRetracted         common.Address `json:"contractRetracted"`
+	Initiator                 common.Address `json:"initiator"`
+	RemovedPtmKey             string         `json:"removedPtmKey"`
+	ManagementContractAddress common.Address `json:"managementContractAddress"`
+	CreationData              []byte         `json:"creationData"`
+}
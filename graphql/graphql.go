@@ -30,7 +30,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
-	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -358,7 +357,11 @@ func (t *Transaction) PrivateInputData(ctx context.Context) (*hexutil.Bytes, err
 		return &hexutil.Bytes{}, err
 	}
 	if tx.IsPrivate() {
-		privateInputData, err := private.P.Receive(common.BytesToEncryptedPayloadHash(tx.Data()))
+		ptm := t.backend.PrivateTransactionManagerOf(ethapi.PrivateStateIdentifierFromContext(ctx))
+		if ptm == nil {
+			return &hexutil.Bytes{}, errors.New("PrivateTransactionManager is not enabled")
+		}
+		privateInputData, _, err := ptm.Receive(common.BytesToEncryptedPayloadHash(tx.Data()))
 		if err != nil || tx == nil {
 			return &hexutil.Bytes{}, err
 		}
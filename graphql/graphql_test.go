@@ -24,7 +24,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
 )
 
 func TestBuildSchema(t *testing.T) {
@@ -37,23 +39,21 @@ func TestBuildSchema(t *testing.T) {
 // Quorum
 // Test Quorum specific GraphQL schema for private transaction
 func TestQuorumSchema(t *testing.T) {
-	saved := private.P
-	defer func() {
-		private.P = saved
-	}()
 	arbitraryPayloadHash := common.BytesToEncryptedPayloadHash([]byte("arbitrary key"))
-	private.P = &StubPrivateTransactionManager{
-		responses: map[common.EncryptedPayloadHash][]interface{}{
-			arbitraryPayloadHash: {
-				[]byte("private payload"), // equals to 0x70726976617465207061796c6f6164 after converting to bytes
-				nil,
+	backend := &StubBackend{
+		ptm: &StubPrivateTransactionManager{
+			responses: map[common.EncryptedPayloadHash][]interface{}{
+				arbitraryPayloadHash: {
+					[]byte("private payload"), // equals to 0x70726976617465207061796c6f6164 after converting to bytes
+					nil,
+				},
 			},
 		},
 	}
 	// Test private transaction
 	privateTx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), arbitraryPayloadHash.Bytes())
 	privateTx.SetPrivate()
-	privateTxQuery := &Transaction{tx: privateTx}
+	privateTxQuery := &Transaction{backend: backend, tx: privateTx}
 	isPrivate, err := privateTxQuery.IsPrivate(context.Background())
 	if err != nil {
 		t.Fatalf("Expect no error: %v", err)
@@ -70,7 +70,7 @@ func TestQuorumSchema(t *testing.T) {
 	}
 	// Test public transaction
 	publicTx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), []byte("key"))
-	publicTxQuery := &Transaction{tx: publicTx}
+	publicTxQuery := &Transaction{backend: backend, tx: publicTx}
 	isPrivate, err = publicTxQuery.IsPrivate(context.Background())
 	if err != nil {
 		t.Fatalf("Expect no error: %v", err)
@@ -87,11 +87,25 @@ func TestQuorumSchema(t *testing.T) {
 	}
 }
 
+// StubBackend only provides the private transaction manager
+type StubBackend struct {
+	ethapi.Backend
+	ptm private.PrivateTransactionManager
+}
+
+func (sb *StubBackend) PrivateTransactionManager() private.PrivateTransactionManager {
+	return sb.ptm
+}
+
+func (sb *StubBackend) PrivateTransactionManagerOf(psi types.PrivateStateIdentifier) private.PrivateTransactionManager {
+	return sb.ptm
+}
+
 type StubPrivateTransactionManager struct {
 	responses map[common.EncryptedPayloadHash][]interface{}
 }
 
-func (spm *StubPrivateTransactionManager) Send(data []byte, from string, to []string) (common.EncryptedPayloadHash, error) {
+func (spm *StubPrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
 	return common.EncryptedPayloadHash{}, fmt.Errorf("to be implemented")
 }
 
@@ -99,19 +113,23 @@ func (spm *StubPrivateTransactionManager) StoreRaw(data []byte, from string) (co
 	return common.EncryptedPayloadHash{}, fmt.Errorf("to be implemented")
 }
 
-func (spm *StubPrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string) ([]byte, error) {
+func (spm *StubPrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
 	return nil, fmt.Errorf("to be implemented")
 }
 
-func (spm *StubPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, error) {
+func (spm *StubPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
 	res := spm.responses[txHash]
 	if err, ok := res[1].(error); ok {
-		return nil, err
+		return nil, nil, err
 	}
 	if ret, ok := res[0].([]byte); ok {
-		return ret, nil
+		return ret, nil, nil
 	}
-	return nil, nil
+	return nil, nil, nil
+}
+
+func (spm *StubPrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return engine.ReceiveConcurrently(txHashes, 1, spm.Receive)
 }
 
 func (spm *StubPrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
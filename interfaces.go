@@ -119,6 +119,13 @@ type CallMsg struct {
 	GasPrice *big.Int        // wei <-> gas exchange ratio
 	Value    *big.Int        // amount of wei sent along with the call
 	Data     []byte          // input data, usually an ABI-encoded contract method invocation
+
+	// Quorum
+	// PrivateFor simulates a private transaction, which is executed on the
+	// private state, sent from PrivateFrom to the given public keys of the
+	// private transaction manager.
+	PrivateFrom string
+	PrivateFor  []string
 }
 
 // A ContractCaller provides contract calls, essentially transactions that are executed by
@@ -148,6 +155,14 @@ type FilterQuery struct {
 	// {{A}, {B}}         matches topic A in first position AND B in second position
 	// {{A, B}, {C, D}}   matches topic (A OR B) in first position AND (C OR D) in second position
 	Topics [][]common.Hash
+
+	// Quorum
+	// PrivateOnly restricts matches to events of private transactions and
+	// Participants to events of private transactions sent to at least one of
+	// the given public keys of the private transaction manager. Pending logs
+	// never match either, as their transactions aren't stored yet.
+	PrivateOnly  bool
+	Participants []string
 }
 
 // LogFilterer provides access to contract log events using a one-off query or continuous
@@ -41,13 +41,14 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/tyler-smith/go-bip39"
@@ -59,6 +60,15 @@ const (
 	maxPrivateIntrinsicDataHex = "11111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111"
 )
 
+// Quorum
+var (
+	errPrivateTransactionManagerNotEnabled = errors.New("PrivateTransactionManager is not enabled")
+	errNotPrivateTransaction               = errors.New("transaction is not private")
+	errNotPartyToTransaction               = errors.New("this node is not a party to the private transaction")
+	errFrozenContract                      = errors.New("contract is frozen as this node was removed from its parties")
+	errStateNotAvailable                   = errors.New("state not available")
+)
+
 // PublicEthereumAPI provides an API to access Ethereum related information.
 // It offers only methods that operate on public data that is freely available to anyone.
 type PublicEthereumAPI struct {
@@ -441,7 +451,7 @@ func (s *PrivateAccountAPI) SendTransaction(ctx context.Context, args SendTxArgs
 
 	// Quorum
 	if args.IsPrivate() {
-		err := args.setPrivateTransactionHash(true)
+		err := args.setPrivateTransactionHash(ctx, s.b, true)
 		if err != nil {
 			return common.Hash{}, err
 		}
@@ -813,6 +823,39 @@ func (s *PublicBlockChainAPI) GetStorageAt(ctx context.Context, address common.A
 	return res[:], state.Error()
 }
 
+// Quorum
+//
+// GetPrivateStorageHash returns a deterministic hash of the storage of a
+// contract at the given block, its private storage for a private contract,
+// which participants to the contract compare to check that they agree on its
+// state. The storage slots are also returned if includeStorage is set, so that
+// a mismatch can be investigated with DiffPrivateStorage.
+func (s *PublicBlockChainAPI) GetPrivateStorageHash(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash, includeStorage *bool) (*state.StorageReport, error) {
+	apiState, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if apiState == nil || err != nil {
+		return nil, err
+	}
+	storageTrie := apiState.StorageTrie(address)
+	if storageTrie == nil {
+		return nil, fmt.Errorf("contract %s not found", address.Hex())
+	}
+	return state.NewStorageReport(address, apiState.GetCodeHash(address), storageTrie, includeStorage != nil && *includeStorage)
+}
+
+// Quorum
+//
+// DiffPrivateStorage compares the storage of a contract at the given block
+// with the one reported by another participant, see GetPrivateStorageHash, and
+// returns the storage slots whose value differ.
+func (s *PublicBlockChainAPI) DiffPrivateStorage(ctx context.Context, remote state.StorageReport, blockNrOrHash rpc.BlockNumberOrHash) ([]state.StorageSlotDiff, error) {
+	includeStorage := true
+	local, err := s.GetPrivateStorageHash(ctx, remote.Address, blockNrOrHash, &includeStorage)
+	if err != nil {
+		return nil, err
+	}
+	return state.DiffStorage(local, &remote), nil
+}
+
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
 	From     *common.Address `json:"from"`
@@ -821,6 +864,16 @@ type CallArgs struct {
 	GasPrice *hexutil.Big    `json:"gasPrice"`
 	Value    *hexutil.Big    `json:"value"`
 	Data     *hexutil.Bytes  `json:"data"`
+
+	// Quorum
+	// PrivateFrom and PrivateFor simulate a private transaction, which is
+	// executed on the private state
+	PrivateFrom string   `json:"privateFrom"`
+	PrivateFor  []string `json:"privateFor"`
+}
+
+func (args CallArgs) IsPrivate() bool {
+	return args.PrivateFor != nil
 }
 
 // account indicates the overriding fields of account during the execution of
@@ -912,7 +965,14 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNrOrHash rpc.Blo
 	}
 
 	// Create new call message
-	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false)
+	var msg core.Message = types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false)
+	// Quorum
+	if args.IsPrivate() {
+		if value.Sign() != 0 {
+			return nil, 0, false, core.ErrEtherValueUnsupported
+		}
+		msg = core.PrivateCallMessage{Message: msg.(types.Message)}
+	}
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -1017,6 +1077,23 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 	}
 
 	//QUORUM
+	homestead := b.ChainConfig().IsHomestead(new(big.Int).SetInt64(int64(rpc.PendingBlockNumber)))
+	istanbul := b.ChainConfig().IsIstanbul(new(big.Int).SetInt64(int64(rpc.PendingBlockNumber)))
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+
+	//A private transaction pays the intrinsic gas of the hash of its encrypted payload
+	//instead of the one of its data, which was executed on the private state above
+	if args.IsPrivate() {
+		intrinsicGasPublic, _ := core.IntrinsicGas(data, args.To == nil, homestead, istanbul)
+		intrinsicGasPrivate, _ := core.IntrinsicGas(common.Hex2Bytes(maxPrivateIntrinsicDataHex), args.To == nil, homestead, istanbul)
+		if intrinsicGasPrivate > intrinsicGasPublic && math.MaxUint64-hi < intrinsicGasPrivate-intrinsicGasPublic {
+			return 0, fmt.Errorf("private intrinsic gas addition exceeds allowance")
+		}
+		return hexutil.Uint64(hi - intrinsicGasPublic + intrinsicGasPrivate), nil
+	}
 
 	//We don't know if this is going to be a private or public transaction
 	//It is possible to have a data field that has a lower intrinsic value than the PTM hash
@@ -1026,14 +1103,6 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 
 	//if the transaction has a value then it cannot be private, so we can skip this check
 	if args.Value != nil && args.Value.ToInt().Cmp(big.NewInt(0)) == 0 {
-		homestead := b.ChainConfig().IsHomestead(new(big.Int).SetInt64(int64(rpc.PendingBlockNumber)))
-		istanbul := b.ChainConfig().IsIstanbul(new(big.Int).SetInt64(int64(rpc.PendingBlockNumber)))
-		var data []byte
-		if args.Data == nil {
-			data = nil
-		} else {
-			data = []byte(*args.Data)
-		}
 		intrinsicGasPublic, _ := core.IntrinsicGas(data, args.To == nil, homestead, istanbul)
 		intrinsicGasPrivate, _ := core.IntrinsicGas(common.Hex2Bytes(maxPrivateIntrinsicDataHex), args.To == nil, homestead, istanbul)
 
@@ -1410,8 +1479,11 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	if len(receipts) <= int(index) {
 		return nil, nil
 	}
-	receipt := receipts[index]
+	return receiptFields(tx, blockHash, blockNumber, index, receipts[index]), nil
+}
 
+// receiptFields returns the RPC representation of the receipt of a transaction
+func receiptFields(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, index uint64, receipt *types.Receipt) map[string]interface{} {
 	var signer types.Signer = types.HomesteadSigner{}
 	if tx.Protected() && !tx.IsPrivate() {
 		signer = types.NewEIP155Signer(tx.ChainId())
@@ -1421,7 +1493,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	fields := map[string]interface{}{
 		"blockHash":         blockHash,
 		"blockNumber":       hexutil.Uint64(blockNumber),
-		"transactionHash":   hash,
+		"transactionHash":   tx.Hash(),
 		"transactionIndex":  hexutil.Uint64(index),
 		"from":              from,
 		"to":                tx.To(),
@@ -1445,6 +1517,125 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	if receipt.ContractAddress != (common.Address{}) {
 		fields["contractAddress"] = receipt.ContractAddress
 	}
+	return fields
+}
+
+// Quorum
+//
+// RPCPrivateTransaction is a private transaction together with its decrypted
+// payload and how it is shared in the private transaction manager
+type RPCPrivateTransaction struct {
+	*RPCTransaction
+	PrivateInput hexutil.Bytes          `json:"privateInput"`
+	PrivacyFlag  engine.PrivacyFlagType `json:"privacyFlag"`
+	MandatoryFor []string               `json:"mandatoryFor,omitempty"`
+	Participants []string               `json:"participants"`
+	IsSender     bool                   `json:"isSender"`
+}
+
+// privatePayload is the decrypted payload of a private transaction and how it
+// is shared in the private transaction manager
+type privatePayload struct {
+	data         []byte
+	extra        *engine.ExtraMetadata
+	participants []string
+	isSender     bool
+}
+
+// receivePrivatePayload retrieves the payload of the private transaction from
+// the private transaction manager as seen by the caller. It fails if the
+// caller is not a party to the transaction.
+func receivePrivatePayload(ctx context.Context, b Backend, tx *types.Transaction) (*privatePayload, error) {
+	ptm := privateTransactionManagerOf(ctx, b)
+	if ptm == nil {
+		return nil, errPrivateTransactionManagerNotEnabled
+	}
+	txHash := common.BytesToEncryptedPayloadHash(tx.Data())
+	data, extra, err := ptm.Receive(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errNotPartyToTransaction
+	}
+	participants, err := ptm.GetParticipants(txHash)
+	if err != nil {
+		return nil, err
+	}
+	isSender, err := ptm.IsSender(txHash)
+	if err != nil {
+		return nil, err
+	}
+	return &privatePayload{data: data, extra: extra, participants: participants, isSender: isSender}, nil
+}
+
+// GetPrivateTransaction returns the private transaction for the given hash,
+// with its decrypted payload. It fails if the caller is not a party to it.
+func (s *PublicTransactionPoolAPI) GetPrivateTransaction(ctx context.Context, hash common.Hash) (*RPCPrivateTransaction, error) {
+	tx, blockHash, blockNumber, index, err := s.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	var rpcTx *RPCTransaction
+	if tx != nil {
+		rpcTx = newRPCTransaction(tx, blockHash, blockNumber, index)
+	} else if tx = s.b.GetPoolTransaction(hash); tx != nil {
+		rpcTx = newRPCPendingTransaction(tx)
+	} else {
+		return nil, nil
+	}
+	if !tx.IsPrivate() {
+		return nil, errNotPrivateTransaction
+	}
+	payload, err := receivePrivatePayload(ctx, s.b, tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &RPCPrivateTransaction{
+		RPCTransaction: rpcTx,
+		PrivateInput:   payload.data,
+		Participants:   payload.participants,
+		IsSender:       payload.isSender,
+	}
+	if payload.extra != nil {
+		result.PrivacyFlag, result.MandatoryFor = payload.extra.PrivacyFlag, payload.extra.MandatoryRecipients
+	}
+	return result, nil
+}
+
+// GetPrivateTransactionReceipt returns the private receipt of the private
+// transaction for the given hash, as executed on the private state of the
+// caller, along with how the transaction is shared in the private transaction
+// manager. It fails if the caller is not a party to the transaction.
+func (s *PublicTransactionPoolAPI) GetPrivateTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(s.b.ChainDb(), hash)
+	if tx == nil {
+		return nil, nil
+	}
+	if !tx.IsPrivate() {
+		return nil, errNotPrivateTransaction
+	}
+	payload, err := receivePrivatePayload(ctx, s.b, tx)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) <= int(index) {
+		return nil, nil
+	}
+	fields := receiptFields(tx, blockHash, blockNumber, index, receipts[index])
+	fields["participants"] = payload.participants
+	fields["isSender"] = payload.isSender
+	fields["privacyFlag"] = engine.PrivacyFlagStandardPrivate
+	if payload.extra != nil {
+		fields["privacyFlag"] = payload.extra.PrivacyFlag
+		if len(payload.extra.MandatoryRecipients) > 0 {
+			fields["mandatoryFor"] = payload.extra.MandatoryRecipients
+		}
+	}
 	return fields, nil
 }
 
@@ -1494,7 +1685,12 @@ func (s SendTxArgs) IsPrivate() bool {
 
 // SendRawTxArgs represents the arguments to submit a new signed private transaction into the transaction pool.
 type SendRawTxArgs struct {
-	PrivateFor []string `json:"privateFor"`
+	// PrivateFrom is the public key the payload was stored from, only
+	// required with the privacy flags checking the participants
+	PrivateFrom  string                 `json:"privateFrom"`
+	PrivateFor   []string               `json:"privateFor"`
+	PrivacyFlag  engine.PrivacyFlagType `json:"privacyFlag"`
+	MandatoryFor []string               `json:"mandatoryFor"`
 }
 
 // Additional arguments used in private transactions
@@ -1508,6 +1704,15 @@ type PrivateTxArgs struct {
 	// The transaction payload is only visible to those party to the transaction.
 	PrivateFor    []string `json:"privateFor"`
 	PrivateTxType string   `json:"restriction"`
+	// PrivacyFlag is the level of privacy enforcement of the transaction and,
+	// for contract creations, of the created contracts.
+	// 0: standard private, 1: party protection, 2: mandatory recipients,
+	// 3: private state validation
+	PrivacyFlag engine.PrivacyFlagType `json:"privacyFlag"`
+	// MandatoryFor is the list of public keys, all in PrivateFor, which must be
+	// party to every later transaction affecting the created contracts.
+	// It requires the mandatory recipients privacy flag.
+	MandatoryFor []string `json:"mandatoryFor"`
 }
 
 // setDefaults is a helper function that fills in default values for unspecified tx fields.
@@ -1559,6 +1764,11 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 			Value:    args.Value,
 			Data:     input,
 		}
+		// Quorum: estimate a private transaction on the private state
+		if args.IsPrivate() {
+			callArgs.PrivateFrom = args.PrivateFrom
+			callArgs.PrivateFor = args.PrivateFor
+		}
 		pendingBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
 		estimated, err := DoEstimateGas(ctx, b, callArgs, pendingBlockNr, b.RPCGasCap())
 		if err != nil {
@@ -1589,7 +1799,10 @@ func (args *SendTxArgs) toTransaction() *types.Transaction {
 }
 
 // setPrivateTransactionHash send the actual private transaction payload to Tessera and returns the tm hash
-func (args *SendTxArgs) setPrivateTransactionHash(sendTxn bool) error {
+func (args *SendTxArgs) setPrivateTransactionHash(ctx context.Context, b Backend, sendTxn bool) error {
+	if err := validatePrivacyArgs(args.PrivacyFlag, args.MandatoryFor, args.PrivateFrom, args.PrivateFor); err != nil {
+		return err
+	}
 	var input []byte
 	if args.Input != nil {
 		input = *args.Input
@@ -1600,16 +1813,29 @@ func (args *SendTxArgs) setPrivateTransactionHash(sendTxn bool) error {
 	}
 
 	if len(input) > 0 {
+		// the payload is sent from the keys of the private state of the caller
+		ptm := privateTransactionManagerOf(ctx, b)
+		if ptm == nil {
+			return errPrivateTransactionManagerNotEnabled
+		}
 		var data common.EncryptedPayloadHash
 		var err error
 		if sendTxn {
+			if err := checkRetractedParties(ctx, b, args.To, args.PrivateFor); err != nil {
+				return err
+			}
+			var extra *engine.ExtraMetadata
+			extra, err = privacyMetadata(ctx, b, args.From, args.To, input, args.PrivacyFlag, args.MandatoryFor, args.PrivateFrom, args.PrivateFor)
+			if err != nil {
+				return err
+			}
 			//Send private transaction to local Constellation node
-			log.Debug("sending private tx", "privatefrom", args.PrivateFrom, "privatefor", args.PrivateFor)
-			data, err = private.P.Send(input, args.PrivateFrom, args.PrivateFor)
+			log.Debug("sending private tx", "privatefrom", args.PrivateFrom, "privatefor", args.PrivateFor, "privacyflag", args.PrivacyFlag)
+			data, err = ptm.Send(input, args.PrivateFrom, args.PrivateFor, extra)
 			log.Debug("sent private tx", "privatefrom", args.PrivateFrom, "privatefor", args.PrivateFor)
 		} else {
 			log.Debug("storing private tx", "privatefrom", args.PrivateFrom)
-			data, err = private.P.StoreRaw(input, args.PrivateFrom)
+			data, err = ptm.StoreRaw(input, args.PrivateFrom)
 			log.Debug("stored private tx", "privatefrom", args.PrivateFrom)
 		}
 
@@ -1622,6 +1848,143 @@ func (args *SendTxArgs) setPrivateTransactionHash(sendTxn bool) error {
 	return nil
 }
 
+// validatePrivacyArgs checks the privacy flag, that the sender key is given
+// with the flags checking the participants and that mandatory recipients are
+// only given, and then must be, with the mandatory recipients privacy flag
+func validatePrivacyArgs(flag engine.PrivacyFlagType, mandatoryFor []string, privateFrom string, privateFor []string) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	if checksParticipants(flag) && privateFrom == "" {
+		return fmt.Errorf("privacy flag %d requires privateFrom", flag)
+	}
+	if flag != engine.PrivacyFlagMandatoryRecipients {
+		if len(mandatoryFor) > 0 {
+			return fmt.Errorf("mandatoryFor requires privacy flag %d", engine.PrivacyFlagMandatoryRecipients)
+		}
+		return nil
+	}
+	if len(mandatoryFor) == 0 {
+		return core.ErrNoMandatoryRecipients
+	}
+	for _, m := range mandatoryFor {
+		if !containsString(privateFor, m) {
+			return fmt.Errorf("mandatory recipient %s must be in privateFor", m)
+		}
+	}
+	return nil
+}
+
+// checkRetractedParties checks that a private transaction to a contract isn't
+// sent to the parties removed from it, nor sent by this node if it was removed,
+// as recorded in the privacy metadata of the contract in the latest private
+// state of the caller
+func checkRetractedParties(ctx context.Context, b Backend, to *common.Address, privateFor []string) error {
+	if to == nil {
+		return nil
+	}
+	state, _, err := b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return errStateNotAvailable
+	}
+	pm := state.GetPrivacyMetadata(*to)
+	if pm == nil {
+		return nil
+	}
+	if pm.Frozen {
+		return errFrozenContract
+	}
+	for _, party := range pm.RetractedParties {
+		if containsString(privateFor, party) {
+			return fmt.Errorf("party %s was removed from contract %s", party, to.Hex())
+		}
+	}
+	return nil
+}
+
+func containsString(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// checksParticipants returns whether the transactions sent with the privacy
+// flag are checked against their participants
+func checksParticipants(flag engine.PrivacyFlagType) bool {
+	return flag == engine.PrivacyFlagStateValidation || flag == engine.PrivacyFlagMandatoryRecipients
+}
+
+// participantsOf returns the keys of the sender and the recipients of a
+// private transaction, without duplicates
+func participantsOf(privateFrom string, privateFor []string) []string {
+	participants := []string{privateFrom}
+	for _, p := range privateFor {
+		if !containsString(participants, p) {
+			participants = append(participants, p)
+		}
+	}
+	return participants
+}
+
+// privacyMetadata returns the extra metadata to store with a private payload
+// sent with the given privacy flag. A call to existing contracts is simulated
+// against the pending private state to collect the creation transactions of
+// the contracts it affects, so the transaction manager and the other parties
+// can verify that the sender is a party to all of them. The mandatory
+// recipients of these contracts must be in mandatoryFor. The participants are
+// stored with the payload for the flags checking them, so that every party
+// checks the transaction against the same participants.
+func privacyMetadata(ctx context.Context, b Backend, from common.Address, to *common.Address, data []byte, flag engine.PrivacyFlagType, mandatoryFor []string, privateFrom string, privateFor []string) (*engine.ExtraMetadata, error) {
+	extra := &engine.ExtraMetadata{
+		ACHashes:            make(common.EncryptedPayloadHashes),
+		PrivacyFlag:         flag,
+		MandatoryRecipients: mandatoryFor,
+	}
+	if checksParticipants(flag) {
+		extra.Participants = participantsOf(privateFrom, privateFor)
+	}
+	if flag.IsStandardPrivate() || to == nil {
+		return extra, nil
+	}
+	state, header, err := b.StateAndHeaderByNumber(ctx, rpc.PendingBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, errStateNotAvailable
+	}
+	msg := types.NewMessage(from, to, 0, new(big.Int), math.MaxUint64/2, new(big.Int), data, false)
+	evm, vmError, err := b.GetEVM(ctx, msg, state, header)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64)); err != nil {
+		return nil, err
+	}
+	if err := vmError(); err != nil {
+		return nil, err
+	}
+	for _, addr := range evm.AffectedContracts() {
+		pm := evm.PrivateState().GetPrivacyMetadata(addr)
+		if pm == nil || pm.PrivacyFlag != flag {
+			return nil, fmt.Errorf("%v: contract %s", core.ErrPrivacyFlagMismatch, addr.Hex())
+		}
+		for _, m := range pm.MandatoryRecipients {
+			if !containsString(mandatoryFor, m) {
+				return nil, fmt.Errorf("%v: contract %s, missing %s", core.ErrMandatoryRecipientsMissing, addr.Hex(), m)
+			}
+		}
+		extra.ACHashes.Add(pm.CreationTxHash)
+	}
+	return extra, nil
+}
+
 // TODO: this submits a signed transaction, if it is a signed private transaction that should already be recorded in the tx.
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
@@ -1674,7 +2037,7 @@ func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args Sen
 
 	// Quorum
 	if args.IsPrivate() {
-		err = args.setPrivateTransactionHash(true)
+		err = args.setPrivateTransactionHash(ctx, s.b, true)
 		if err != nil {
 			return common.Hash{}, err
 		}
@@ -1711,7 +2074,7 @@ func (s *PublicTransactionPoolAPI) FillTransaction(ctx context.Context, args Sen
 	// Assemble the transaction and obtain rlp
 	// Quorum
 	if args.IsPrivate() {
-		err := args.setPrivateTransactionHash(false)
+		err := args.setPrivateTransactionHash(ctx, s.b, false)
 		if err != nil {
 			return nil, err
 		}
@@ -1756,9 +2119,25 @@ func (s *PublicTransactionPoolAPI) SendRawPrivateTransaction(ctx context.Context
 
 	if isPrivate {
 		if len(txHash) > 0 {
+			// only the payloads stored from the private state of the caller
+			// are sent
+			ptm := privateTransactionManagerOf(ctx, s.b)
+			if ptm == nil {
+				return common.Hash{}, errPrivateTransactionManagerNotEnabled
+			}
+			if err := validatePrivacyArgs(args.PrivacyFlag, args.MandatoryFor, args.PrivateFrom, args.PrivateFor); err != nil {
+				return common.Hash{}, err
+			}
+			if err := checkRetractedParties(ctx, s.b, tx.To(), args.PrivateFor); err != nil {
+				return common.Hash{}, err
+			}
+			extra, err := rawPrivacyMetadata(ctx, s.b, tx, args)
+			if err != nil {
+				return common.Hash{}, err
+			}
 			//Send private transaction to privacy manager
-			log.Info("sending private tx", "data", fmt.Sprintf("%x", txHash), "privatefor", args.PrivateFor)
-			result, err := private.P.SendSignedTx(common.BytesToEncryptedPayloadHash(txHash), args.PrivateFor)
+			log.Info("sending private tx", "data", fmt.Sprintf("%x", txHash), "privatefor", args.PrivateFor, "privacyflag", args.PrivacyFlag)
+			result, err := ptm.SendSignedTx(common.BytesToEncryptedPayloadHash(txHash), args.PrivateFor, extra)
 			log.Info("sent private tx", "result", fmt.Sprintf("%x", result), "privatefor", args.PrivateFor)
 			if err != nil {
 				return common.Hash{}, err
@@ -1770,6 +2149,27 @@ func (s *PublicTransactionPoolAPI) SendRawPrivateTransaction(ctx context.Context
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// rawPrivacyMetadata returns the extra metadata for a signed private
+// transaction whose payload was previously stored in the transaction manager
+func rawPrivacyMetadata(ctx context.Context, b Backend, tx *types.Transaction, args SendRawTxArgs) (*engine.ExtraMetadata, error) {
+	if args.PrivacyFlag.IsStandardPrivate() || tx.To() == nil {
+		return privacyMetadata(ctx, b, common.Address{}, tx.To(), nil, args.PrivacyFlag, args.MandatoryFor, args.PrivateFrom, args.PrivateFor)
+	}
+	from, err := types.Sender(types.QuorumPrivateTxSigner{}, tx)
+	if err != nil {
+		return nil, err
+	}
+	ptm := privateTransactionManagerOf(ctx, b)
+	if ptm == nil {
+		return nil, errPrivateTransactionManagerNotEnabled
+	}
+	data, _, err := ptm.Receive(common.BytesToEncryptedPayloadHash(tx.Data()))
+	if err != nil {
+		return nil, err
+	}
+	return privacyMetadata(ctx, b, from, tx.To(), data, args.PrivacyFlag, args.MandatoryFor, args.PrivateFrom, args.PrivateFor)
+}
+
 // Sign calculates an ECDSA signature for:
 // keccack256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -2168,9 +2568,10 @@ func (s *PublicTransactionPoolAPI) SendTransactionAsync(ctx context.Context, arg
 }
 
 // GetQuorumPayload returns the contents of a private transaction
-func (s *PublicBlockChainAPI) GetQuorumPayload(digestHex string) (string, error) {
-	if private.P == nil {
-		return "", fmt.Errorf("PrivateTransactionManager is not enabled")
+func (s *PublicBlockChainAPI) GetQuorumPayload(ctx context.Context, digestHex string) (string, error) {
+	ptm := privateTransactionManagerOf(ctx, s.b)
+	if ptm == nil {
+		return "", errPrivateTransactionManagerNotEnabled
 	}
 	if len(digestHex) < 3 {
 		return "", fmt.Errorf("Invalid digest hex")
@@ -2185,7 +2586,7 @@ func (s *PublicBlockChainAPI) GetQuorumPayload(digestHex string) (string, error)
 	if len(b) != 64 {
 		return "", fmt.Errorf("Expected a Quorum digest of length 64, but got %d", len(b))
 	}
-	data, err := private.P.Receive(common.BytesToEncryptedPayloadHash(b))
+	data, _, err := ptm.Receive(common.BytesToEncryptedPayloadHash(b))
 	if err != nil {
 		return "", err
 	}
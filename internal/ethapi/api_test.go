@@ -0,0 +1,315 @@
+package ethapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/ethereum/go-ethereum/rpc"
+	testifyassert "github.com/stretchr/testify/assert"
+)
+
+var (
+	arbitraryTo = common.HexToAddress("0x9e8b5c1c0ab9cdd8ae2d6dc3fbf27e9b5b0c1a3c")
+
+	payloadHashA = common.BytesToEncryptedPayloadHash([]byte("tenantA payload"))
+	payloadHashB = common.BytesToEncryptedPayloadHash([]byte("tenantB payload"))
+	payloadHashC = common.BytesToEncryptedPayloadHash([]byte("not a party payload"))
+)
+
+// newTestPrivateTransactionPoolAPI returns the API over a chain of one block
+// holding a private transaction of tenantA, a private transaction of tenantB,
+// a private transaction this node is not a party to and a public transaction,
+// plus a pending private transaction of tenantA
+func newTestPrivateTransactionPoolAPI() (*PublicTransactionPoolAPI, types.Transactions, *types.Transaction) {
+	txs := types.Transactions{
+		privateTx(0, payloadHashA),
+		privateTx(1, payloadHashB),
+		privateTx(2, payloadHashC),
+		types.NewTransaction(3, arbitraryTo, big.NewInt(0), 21000, big.NewInt(0), nil),
+	}
+	pending := privateTx(4, payloadHashA)
+	receipts := make(types.Receipts, len(txs))
+	for i, tx := range txs {
+		receipts[i] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), GasUsed: 21000, Logs: []*types.Log{}}
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, txs, nil, receipts)
+
+	db := rawdb.NewMemoryDatabase()
+	rawdb.WriteBlock(db, block)
+	rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	rawdb.WriteTxLookupEntries(db, block)
+
+	b := &stubBackend{
+		db:       db,
+		receipts: map[common.Hash]types.Receipts{block.Hash(): receipts},
+		pool:     map[common.Hash]*types.Transaction{pending.Hash(): pending},
+		ptm: &stubPrivateTransactionManager{
+			payloads: map[common.EncryptedPayloadHash][]byte{
+				payloadHashA: []byte("input of tenantA"),
+				payloadHashB: []byte("input of tenantB"),
+			},
+			participants: map[common.EncryptedPayloadHash][]string{
+				payloadHashA: {"keyA"},
+				payloadHashB: {"keyB"},
+			},
+		},
+		keys: map[types.PrivateStateIdentifier][]string{
+			"tenantA": {"keyA"},
+			"tenantB": {"keyB"},
+		},
+	}
+	return NewPublicTransactionPoolAPI(b, new(AddrLocker)), txs, pending
+}
+
+func privateTx(nonce uint64, payloadHash common.EncryptedPayloadHash) *types.Transaction {
+	tx := types.NewTransaction(nonce, arbitraryTo, big.NewInt(0), 21000, big.NewInt(0), payloadHash.Bytes())
+	tx.SetPrivate()
+	return tx
+}
+
+func TestGetPrivateTransaction_whenParty(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, txs, _ := newTestPrivateTransactionPoolAPI()
+
+	result, err := api.GetPrivateTransaction(context.Background(), txs[0].Hash())
+
+	assert.NoError(err)
+	assert.Equal(hexutil.Bytes("input of tenantA"), result.PrivateInput)
+	assert.Equal([]string{"keyA"}, result.Participants)
+	assert.True(result.IsSender)
+	assert.Equal(engine.PrivacyFlagPartyProtection, result.PrivacyFlag)
+	assert.Equal(txs[0].Hash(), result.Hash)
+}
+
+func TestGetPrivateTransaction_whenNotParty(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, txs, _ := newTestPrivateTransactionPoolAPI()
+
+	_, err := api.GetPrivateTransaction(context.Background(), txs[2].Hash())
+
+	assert.Equal(errNotPartyToTransaction, err)
+}
+
+func TestGetPrivateTransaction_whenPublic(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, txs, _ := newTestPrivateTransactionPoolAPI()
+
+	_, err := api.GetPrivateTransaction(context.Background(), txs[3].Hash())
+
+	assert.Equal(errNotPrivateTransaction, err)
+}
+
+func TestGetPrivateTransaction_whenPending(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, _, pending := newTestPrivateTransactionPoolAPI()
+
+	result, err := api.GetPrivateTransaction(context.Background(), pending.Hash())
+
+	assert.NoError(err)
+	assert.Equal(hexutil.Bytes("input of tenantA"), result.PrivateInput)
+	assert.Nil(result.BlockHash)
+}
+
+func TestGetPrivateTransaction_whenUnknown(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, _, _ := newTestPrivateTransactionPoolAPI()
+
+	result, err := api.GetPrivateTransaction(context.Background(), common.HexToHash("0x1"))
+
+	assert.NoError(err)
+	assert.Nil(result)
+}
+
+func TestGetPrivateTransaction_whenOtherTenant(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, txs, _ := newTestPrivateTransactionPoolAPI()
+	ctxA := rpc.ContextWithPrivateStateIdentifier(context.Background(), "tenantA")
+	ctxB := rpc.ContextWithPrivateStateIdentifier(context.Background(), "tenantB")
+
+	result, err := api.GetPrivateTransaction(ctxA, txs[0].Hash())
+	assert.NoError(err)
+	assert.Equal(hexutil.Bytes("input of tenantA"), result.PrivateInput)
+
+	_, err = api.GetPrivateTransaction(ctxB, txs[0].Hash())
+	assert.Equal(errNotPartyToTransaction, err, "tenantB must not read the payload of tenantA")
+
+	_, err = api.GetPrivateTransactionReceipt(ctxB, txs[0].Hash())
+	assert.Equal(errNotPartyToTransaction, err, "tenantB must not read the receipt of tenantA")
+
+	_, err = api.GetPrivateTransaction(rpc.ContextWithPrivateStateIdentifier(context.Background(), ""), txs[1].Hash())
+	assert.Equal(errNotPartyToTransaction, err, "callers without a private state must not read any payload")
+}
+
+func TestGetQuorumPayload_whenOtherTenant(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, _, _ := newTestPrivateTransactionPoolAPI()
+	chainAPI := NewPublicBlockChainAPI(api.b)
+
+	payload, err := chainAPI.GetQuorumPayload(rpc.ContextWithPrivateStateIdentifier(context.Background(), "tenantA"), payloadHashA.Hex())
+	assert.NoError(err)
+	assert.Equal(hexutil.Encode([]byte("input of tenantA")), payload)
+
+	payload, err = chainAPI.GetQuorumPayload(rpc.ContextWithPrivateStateIdentifier(context.Background(), "tenantB"), payloadHashA.Hex())
+	assert.NoError(err)
+	assert.Equal("0x", payload, "tenantB must not read the payload of tenantA")
+}
+
+func TestGetPrivateTransactionReceipt_whenParty(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, txs, _ := newTestPrivateTransactionPoolAPI()
+
+	receipt, err := api.GetPrivateTransactionReceipt(context.Background(), txs[1].Hash())
+
+	assert.NoError(err)
+	assert.Equal(txs[1].Hash(), receipt["transactionHash"])
+	assert.Equal(hexutil.Uint(types.ReceiptStatusSuccessful), receipt["status"])
+	assert.Equal([]string{"keyB"}, receipt["participants"])
+	assert.Equal(true, receipt["isSender"])
+	assert.Equal(engine.PrivacyFlagPartyProtection, receipt["privacyFlag"])
+}
+
+func TestGetPrivateTransactionReceipt_whenNotPartyOrPublic(t *testing.T) {
+	assert := testifyassert.New(t)
+	api, txs, pending := newTestPrivateTransactionPoolAPI()
+
+	_, err := api.GetPrivateTransactionReceipt(context.Background(), txs[2].Hash())
+	assert.Equal(errNotPartyToTransaction, err)
+
+	_, err = api.GetPrivateTransactionReceipt(context.Background(), txs[3].Hash())
+	assert.Equal(errNotPrivateTransaction, err)
+
+	receipt, err := api.GetPrivateTransactionReceipt(context.Background(), pending.Hash())
+	assert.NoError(err)
+	assert.Nil(receipt, "pending transactions have no receipt")
+}
+
+func TestValidatePrivacyArgs_whenStateValidationWithoutPrivateFrom(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	assert.Error(validatePrivacyArgs(engine.PrivacyFlagStateValidation, nil, "", []string{"keyB"}))
+	assert.NoError(validatePrivacyArgs(engine.PrivacyFlagStateValidation, nil, "keyA", []string{"keyB"}))
+	assert.NoError(validatePrivacyArgs(engine.PrivacyFlagPartyProtection, nil, "", []string{"keyB"}))
+	assert.Error(validatePrivacyArgs(engine.PrivacyFlagMandatoryRecipients, []string{"keyB"}, "", []string{"keyB"}))
+	assert.NoError(validatePrivacyArgs(engine.PrivacyFlagMandatoryRecipients, []string{"keyB"}, "keyA", []string{"keyB"}))
+}
+
+func TestPrivacyMetadata_whenStateValidation(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	extra, err := privacyMetadata(context.Background(), &stubBackend{}, common.Address{}, nil, nil, engine.PrivacyFlagStateValidation, nil, "keyA", []string{"keyB", "keyA", "keyC"})
+
+	assert.NoError(err)
+	assert.Equal([]string{"keyA", "keyB", "keyC"}, extra.Participants, "the participants must be stored with the payload")
+
+	extra, err = privacyMetadata(context.Background(), &stubBackend{}, common.Address{}, nil, nil, engine.PrivacyFlagMandatoryRecipients, []string{"keyB"}, "keyA", []string{"keyB"})
+
+	assert.NoError(err)
+	assert.Equal([]string{"keyA", "keyB"}, extra.Participants, "the mandatory recipients are checked against the stored participants")
+
+	extra, err = privacyMetadata(context.Background(), &stubBackend{}, common.Address{}, nil, nil, engine.PrivacyFlagPartyProtection, nil, "keyA", []string{"keyB"})
+
+	assert.NoError(err)
+	assert.Empty(extra.Participants)
+}
+
+func TestPrivacyMetadata_whenStateNotAvailable(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	extra, err := privacyMetadata(context.Background(), &stubBackend{}, common.Address{}, &common.Address{1}, nil, engine.PrivacyFlagPartyProtection, nil, "keyA", []string{"keyB"})
+
+	assert.Equal(errStateNotAvailable, err)
+	assert.Nil(extra)
+}
+
+// stubBackend serves the transactions and receipts of a single block
+type stubBackend struct {
+	Backend
+	db       ethdb.Database
+	receipts map[common.Hash]types.Receipts
+	pool     map[common.Hash]*types.Transaction
+	ptm      private.PrivateTransactionManager
+	keys     map[types.PrivateStateIdentifier][]string
+}
+
+func (b *stubBackend) StateAndHeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (vm.MinimalApiState, *types.Header, error) {
+	return nil, nil, nil
+}
+
+func (b *stubBackend) ChainDb() ethdb.Database {
+	return b.db
+}
+
+func (b *stubBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
+	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(b.db, txHash)
+	return tx, blockHash, blockNumber, index, nil
+}
+
+func (b *stubBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
+	return b.pool[txHash]
+}
+
+func (b *stubBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	return b.receipts[hash], nil
+}
+
+func (b *stubBackend) PrivateTransactionManager() private.PrivateTransactionManager {
+	return b.ptm
+}
+
+func (b *stubBackend) PrivateTransactionManagerOf(psi types.PrivateStateIdentifier) private.PrivateTransactionManager {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return b.ptm
+	}
+	return core.NewPrivateStatePrivateTransactionManager(b.ptm, b.keys[psi])
+}
+
+// stubPrivateTransactionManager holds the payloads this node is a party to,
+// all of them sent by this node with party protection
+type stubPrivateTransactionManager struct {
+	payloads     map[common.EncryptedPayloadHash][]byte
+	participants map[common.EncryptedPayloadHash][]string
+}
+
+func (spm *stubPrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
+	return common.EncryptedPayloadHash{}, fmt.Errorf("to be implemented")
+}
+
+func (spm *stubPrivateTransactionManager) StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error) {
+	return common.EncryptedPayloadHash{}, fmt.Errorf("to be implemented")
+}
+
+func (spm *stubPrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	return nil, fmt.Errorf("to be implemented")
+}
+
+func (spm *stubPrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	data, ok := spm.payloads[txHash]
+	if !ok {
+		return nil, nil, nil
+	}
+	return data, &engine.ExtraMetadata{PrivacyFlag: engine.PrivacyFlagPartyProtection}, nil
+}
+
+func (spm *stubPrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return engine.ReceiveConcurrently(txHashes, 1, spm.Receive)
+}
+
+func (spm *stubPrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
+	_, ok := spm.payloads[txHash]
+	return ok, nil
+}
+
+func (spm *stubPrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
+	return spm.participants[txHash], nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -80,9 +81,15 @@ type Backend interface {
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
+	SubscribePrivateStateLogsEvent(ch chan<- core.PrivateStateLogsEvent) event.Subscription
 
 	ChainConfig() *params.ChainConfig
 	CurrentBlock() *types.Block
+
+	// Quorum
+	PrivateTransactionManager() private.PrivateTransactionManager // nil if not configured
+	// PrivateTransactionManagerOf only reveals the payloads sent to the keys of the private state, nil if not configured
+	PrivateTransactionManagerOf(psi types.PrivateStateIdentifier) private.PrivateTransactionManager
 }
 
 func GetAPIs(apiBackend Backend) []rpc.API {
@@ -0,0 +1,32 @@
+package ethapi
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Quorum
+//
+// PrivateStateIdentifierFromContext returns the private state of the caller of
+// an RPC method. Callers which aren't authenticated use the default private
+// state, while authenticated callers only access the one granted in their token.
+func PrivateStateIdentifierFromContext(ctx context.Context) types.PrivateStateIdentifier {
+	psi, authenticated := rpc.PrivateStateIdentifierFromContext(ctx)
+	switch {
+	case !authenticated:
+		return types.DefaultPrivateStateIdentifier
+	case psi == "":
+		return types.EmptyPrivateStateIdentifier
+	default:
+		return types.PrivateStateIdentifier(psi)
+	}
+}
+
+// privateTransactionManagerOf returns the private transaction manager as seen
+// by the caller, which only reveals the payloads of its private state
+func privateTransactionManagerOf(ctx context.Context, b Backend) private.PrivateTransactionManager {
+	return b.PrivateTransactionManagerOf(PrivateStateIdentifierFromContext(ctx))
+}
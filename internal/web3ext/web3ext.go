@@ -575,6 +575,28 @@ web3._extend({
 			params: 1,
 			inputFormatter: [null]
 		}),
+		new web3._extend.Method({
+			name: 'getPrivateTransaction',
+			call: 'eth_getPrivateTransaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getPrivateTransactionReceipt',
+			call: 'eth_getPrivateTransactionReceipt',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getPrivateStorageHash',
+			call: 'eth_getPrivateStorageHash',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'diffPrivateStorage',
+			call: 'eth_diffPrivateStorage',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		// END-QUORUM
 	],
 	properties: [
@@ -949,12 +971,36 @@ web3._extend({
                        params: 3,
                        inputFormatter: [null, web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputTransactionFormatter]
                }),
+               new web3._extend.Method({
+                       name: 'addContractAccess',
+                       call: 'quorumPermission_addContractAccess',
+                       params: 5,
+                       inputFormatter: [null, null, web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputTransactionFormatter]
+               }),
+               new web3._extend.Method({
+                       name: 'removeContractAccess',
+                       call: 'quorumPermission_removeContractAccess',
+                       params: 5,
+                       inputFormatter: [null, null, web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputTransactionFormatter]
+               }),
                new web3._extend.Method({
                        name: 'getOrgDetails',
                        call: 'quorumPermission_getOrgDetails',
                        params: 1,
                        inputFormatter: [null]
                }),
+               new web3._extend.Method({
+                       name: 'simulate',
+                       call: 'quorumPermission_simulate',
+                       params: 1,
+                       inputFormatter: [null]
+               }),
+               new web3._extend.Method({
+                       name: 'auditTrail',
+                       call: 'quorumPermission_auditTrail',
+                       params: 1,
+                       inputFormatter: [null]
+               }),
 
        ],
        properties:
@@ -975,6 +1021,10 @@ web3._extend({
 					   name: 'acctList',
 				       getter: 'quorumPermission_acctList'
 			  }), 
+              new web3._extend.Property({
+					   name: 'contractAccessList',
+				       getter: 'quorumPermission_contractAccessList'
+			  }),
        ]
 })
 `
@@ -1129,6 +1179,12 @@ web3._extend({
 			params: 4,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'extendContractWithDependencies',
+			call: 'quorumExtension_extendContractWithDependencies',
+			params: 5,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputTransactionFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'cancelExtension',
 			call: 'quorumExtension_cancelExtension',
@@ -1141,6 +1197,42 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'extensionHistory',
+			call: 'quorumExtension_extensionHistory',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'retractContract',
+			call: 'quorumExtension_retractContract',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'approveRetraction',
+			call: 'quorumExtension_approveRetraction',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'cancelRetraction',
+			call: 'quorumExtension_cancelRetraction',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getRetractionStatus',
+			call: 'quorumExtension_getRetractionStatus',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'extensionHistories',
+			call: 'quorumExtension_extensionHistories',
+			params: 1,
+			inputFormatter: [null]
+		}),
 
 	],
 	properties:
@@ -1148,6 +1240,10 @@ web3._extend({
 		new web3._extend.Property({
 			name: 'activeExtensionContracts',
 			getter: 'quorumExtension_activeExtensionContracts'
+		}),
+		new web3._extend.Property({
+			name: 'activeRetractionContracts',
+			getter: 'quorumExtension_activeRetractionContracts'
 		})
 	]
 });
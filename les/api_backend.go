@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/light"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -230,6 +231,15 @@ func (b *LesApiBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEven
 	return b.eth.blockchain.SubscribeRemovedLogsEvent(ch)
 }
 
+// SubscribePrivateStateLogsEvent never fires as the light client keeps no
+// private states
+func (b *LesApiBackend) SubscribePrivateStateLogsEvent(ch chan<- core.PrivateStateLogsEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
 func (b *LesApiBackend) Downloader() *downloader.Downloader {
 	return b.eth.Downloader()
 }
@@ -242,6 +252,20 @@ func (b *LesApiBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return b.gpo.SuggestPrice(ctx)
 }
 
+// Quorum
+func (b *LesApiBackend) PrivateTransactionManager() private.PrivateTransactionManager {
+	return b.eth.ptm
+}
+
+// the light client only keeps the default private state, so the payloads of
+// other private states are not revealed
+func (b *LesApiBackend) PrivateTransactionManagerOf(psi types.PrivateStateIdentifier) private.PrivateTransactionManager {
+	if psi == types.DefaultPrivateStateIdentifier {
+		return b.eth.ptm
+	}
+	return core.NewPrivateStatePrivateTransactionManager(b.eth.ptm, nil)
+}
+
 func (b *LesApiBackend) ChainDb() ethdb.Database {
 	return b.eth.chainDb
 }
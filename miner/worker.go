@@ -77,6 +77,11 @@ const (
 	staleThreshold = 7
 )
 
+// Quorum: errPrivateStateNotApplied is returned when the private state changes
+// made by the logs of a transaction, e.g. by contract extensions, couldn't be
+// applied
+var errPrivateStateNotApplied = errors.New("private state changes of the transaction not applied")
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
 	signer types.Signer
@@ -779,16 +784,19 @@ func (w *worker) commitTransaction(tx *types.Transaction, coinbase common.Addres
 		w.current.privateState.RevertToSnapshot(privateSnap)
 		return nil, err
 	}
-	w.current.txs = append(w.current.txs, tx)
-	w.current.receipts = append(w.current.receipts, receipt)
-	log.EmitCheckpoint(log.TxCompleted, "tx", tx.Hash().Hex(), "time", time.Since(txnStart))
-
 	logs := receipt.Logs
 	if privateReceipt != nil {
 		logs = append(receipt.Logs, privateReceipt.Logs...)
+		if err := w.chain.CheckAndSetPrivateState(logs, w.current.privateState); err != nil {
+			log.Error("Failed to apply the private state changes of a transaction", "hash", tx.Hash(), "err", err)
+			return nil, errPrivateStateNotApplied
+		}
 		w.current.privateReceipts = append(w.current.privateReceipts, privateReceipt)
-		w.chain.CheckAndSetPrivateState(logs, w.current.privateState)
 	}
+	w.current.txs = append(w.current.txs, tx)
+	w.current.receipts = append(w.current.receipts, receipt)
+	log.EmitCheckpoint(log.TxCompleted, "tx", tx.Hash().Hex(), "time", time.Since(txnStart))
+
 	return logs, nil
 }
 
@@ -869,6 +877,11 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 			log.Trace("Skipping account with hight nonce", "sender", from, "nonce", tx.Nonce())
 			txs.Pop()
 
+		case errPrivateStateNotApplied:
+			// Quorum: the states were already finalised and can't be reverted
+			log.Warn("Abandoning the block being built", "hash", tx.Hash())
+			return true
+
 		case nil:
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
@@ -25,6 +25,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/private/engine"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -268,6 +270,9 @@ type PublicAdminAPI struct {
 type QuorumNodeInfo struct {
 	*p2p.NodeInfo
 	Plugins interface{} `json:"plugins"`
+	// PrivateTransactionManager is the health of the private transaction
+	// manager, if it is monitored
+	PrivateTransactionManager *engine.HealthStatus `json:"privateTransactionManager,omitempty"`
 }
 
 // NewPublicAdminAPI creates a new API definition for the public admin methods
@@ -293,10 +298,15 @@ func (api *PublicAdminAPI) NodeInfo() (*QuorumNodeInfo, error) {
 	if server == nil {
 		return nil, ErrNodeStopped
 	}
-	return &QuorumNodeInfo{
+	info := &QuorumNodeInfo{
 		NodeInfo: server.NodeInfo(),
 		Plugins:  api.node.PluginManager().PluginsInfo(),
-	}, nil
+	}
+	if checker, ok := api.node.PrivateTransactionManager().(private.HealthChecker); ok {
+		status := checker.Health()
+		info.PrivateTransactionManager = &status
+	}
+	return info, nil
 }
 
 // Datadir retrieves the current data directory the node is using.
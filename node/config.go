@@ -197,6 +197,8 @@ type Config struct {
 	Plugins                *plugin.Settings `toml:",omitempty"`
 	// Quorum: EnableNodePermission comes from EnableNodePermissionFlag --permissioned.
 	EnableNodePermission bool `toml:",omitempty"`
+	// Quorum: EnableRPCPermission comes from EnableRPCPermissionFlag --permissioned.rpc.
+	EnableRPCPermission bool `toml:",omitempty"`
 }
 
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
@@ -29,6 +29,9 @@ var (
 	ErrNodeRunning    = errors.New("node already running")
 	ErrServiceUnknown = errors.New("unknown service")
 
+	// Quorum
+	ErrRPCPermissionUnavailable = errors.New("RPC permission enabled without the permission service, see --permissioned")
+
 	datadirInUseErrnos = map[uint]bool{11: true, 32: true, 35: true}
 )
 
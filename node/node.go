@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/plugin"
 	"github.com/ethereum/go-ethereum/plugin/security"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/prometheus/tsdb/fileutil"
 )
@@ -73,7 +74,9 @@ type Node struct {
 	wsListener net.Listener // Websocket RPC listener socket to server API requests
 	wsHandler  *rpc.Server  // Websocket RPC request handler to process the API requests
 
-	pluginManager *plugin.PluginManager // Manage all plugins for this node. If plugin is not enabled, an EmptyPluginManager is set.
+	pluginManager *plugin.PluginManager             // Manage all plugins for this node. If plugin is not enabled, an EmptyPluginManager is set.
+	ptm           private.PrivateTransactionManager // Quorum: private transaction manager used by the services of this node, nil if not configured
+	accessChecker rpc.AccountAccessChecker          // Quorum: authorizes the RPC callers against their permission account, nil if not enabled
 
 	stop chan struct{} // Channel to wait for termination notifications
 	lock sync.RWMutex
@@ -208,6 +211,7 @@ func (n *Node) Start() error {
 			services:       make(map[reflect.Type]Service),
 			EventMux:       n.eventmux,
 			AccountManager: n.accman,
+			ptm:            n.ptm,
 		}
 		for kind, s := range services { // copy needed for threaded access
 			ctx.services[kind] = s
@@ -255,11 +259,25 @@ func (n *Node) Start() error {
 	} else {
 		n.pluginManager = plugin.NewEmptyPluginManager()
 	}
+	// Quorum: start the background work of the private transaction manager
+	lifecycle, hasLifecycle := n.ptm.(privateTransactionManagerLifecycle)
+	if hasLifecycle {
+		if err := lifecycle.Start(); err != nil {
+			for _, service := range services {
+				service.Stop()
+			}
+			running.Stop()
+			return err
+		}
+	}
 	// Lastly start the configured RPC interfaces
 	if err := n.startRPC(services); err != nil {
 		for _, service := range services {
 			service.Stop()
 		}
+		if hasLifecycle {
+			lifecycle.Stop()
+		}
 		running.Stop()
 		return err
 	}
@@ -298,6 +316,10 @@ func (n *Node) openDataDir() error {
 // startup. It's not meant to be called at any time afterwards as it makes certain
 // assumptions about the state of the node.
 func (n *Node) startRPC(services map[reflect.Type]Service) error {
+	// Quorum: the access checker must be in place before any endpoint is serving
+	if err := n.setupAccountAccessChecker(services); err != nil {
+		return err
+	}
 	// Gather all the possible APIs to surface
 	apis := n.apis()
 	for _, service := range services {
@@ -392,6 +414,30 @@ func (n *Node) wsScheme() string {
 	return "ws"
 }
 
+// Quorum
+//
+// setupAccountAccessChecker retrieves the service authorizing the RPC callers
+// against their permission account when the RPC permission is enabled
+func (n *Node) setupAccountAccessChecker(services map[reflect.Type]Service) error {
+	n.accessChecker = nil
+	if !n.config.EnableRPCPermission {
+		return nil
+	}
+	for _, service := range services {
+		if checker, ok := service.(rpc.AccountAccessChecker); ok {
+			n.accessChecker = checker
+			break
+		}
+	}
+	if n.accessChecker == nil {
+		return ErrRPCPermissionUnavailable
+	}
+	if !n.pluginManager.IsEnabled(plugin.SecurityPluginInterfaceName) {
+		log.Warn("RPC permission is enabled but the security plugin is not, RPC callers are not authenticated hence not checked")
+	}
+	return nil
+}
+
 func (n *Node) getSecuritySupports() (tlsConfigSource security.TLSConfigurationSource, authManager security.AuthenticationManager, err error) {
 	if n.pluginManager.IsEnabled(plugin.SecurityPluginInterfaceName) {
 		sp := new(plugin.SecurityPluginTemplate)
@@ -420,7 +466,7 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if err != nil {
 		return err
 	}
-	listener, handler, isTlsEnabled, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts, tlsConfigSource, authManager)
+	listener, handler, isTlsEnabled, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts, tlsConfigSource, authManager, n.accessChecker)
 	if err != nil {
 		return err
 	}
@@ -458,7 +504,7 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 	if err != nil {
 		return err
 	}
-	listener, handler, isTlsEnabled, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll, tlsConfigSource, authManager)
+	listener, handler, isTlsEnabled, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll, tlsConfigSource, authManager, n.accessChecker)
 	if err != nil {
 		return err
 	}
@@ -514,6 +560,14 @@ func (n *Node) Stop() error {
 	n.services = nil
 	n.server = nil
 
+	// Quorum: stop the background work of the private transaction manager
+	// once no service can use it anymore, it is started again on restart
+	if lifecycle, ok := n.ptm.(privateTransactionManagerLifecycle); ok {
+		if err := lifecycle.Stop(); err != nil {
+			n.log.Error("Can't stop the private transaction manager", "err", err)
+		}
+	}
+
 	// Release instance directory lock.
 	if n.instanceDirLock != nil {
 		if err := n.instanceDirLock.Release(); err != nil {
@@ -748,3 +802,40 @@ func (n *Node) PluginManager() *plugin.PluginManager {
 
 	return n.pluginManager
 }
+
+// Quorum
+//
+// privateTransactionManagerLifecycle is implemented by the private transaction
+// managers doing background work while the node runs
+type privateTransactionManagerLifecycle interface {
+	Start() error
+	Stop() error
+}
+
+// Quorum
+//
+// SetPrivateTransactionManager sets the private transaction manager handed to
+// the services of this node. It must be called before the node is started.
+// Its background work is started and stopped along with the node if it
+// implements Start and Stop.
+func (n *Node) SetPrivateTransactionManager(ptm private.PrivateTransactionManager) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.server != nil {
+		return ErrNodeRunning
+	}
+	n.ptm = ptm
+	return nil
+}
+
+// Quorum
+//
+// PrivateTransactionManager returns the private transaction manager of this
+// node, nil if none is configured.
+func (n *Node) PrivateTransactionManager() private.PrivateTransactionManager {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.ptm
+}
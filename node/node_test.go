@@ -24,8 +24,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -597,3 +599,88 @@ func TestAPIGather(t *testing.T) {
 		}
 	}
 }
+
+type runningPrivateTransactionManager struct {
+	private.PrivateTransactionManager
+	running bool
+	started int
+}
+
+func (ptm *runningPrivateTransactionManager) Start() error {
+	ptm.running = true
+	ptm.started++
+	return nil
+}
+
+func (ptm *runningPrivateTransactionManager) Stop() error {
+	ptm.running = false
+	return nil
+}
+
+// Tests that the private transaction manager runs along with the node, across
+// restarts.
+func TestNodeRunsPrivateTransactionManager(t *testing.T) {
+	stack, err := New(testNodeConfig())
+	if err != nil {
+		t.Fatalf("failed to create protocol stack: %v", err)
+	}
+	defer stack.Close()
+
+	ptm := new(runningPrivateTransactionManager)
+	if err := stack.SetPrivateTransactionManager(ptm); err != nil {
+		t.Fatalf("failed to set the private transaction manager: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	if !ptm.running {
+		t.Fatalf("private transaction manager not started with the node")
+	}
+	if err := stack.Restart(); err != nil {
+		t.Fatalf("failed to restart node: %v", err)
+	}
+	if !ptm.running || ptm.started != 2 {
+		t.Fatalf("private transaction manager not restarted with the node: running %v, started %d times", ptm.running, ptm.started)
+	}
+	if err := stack.Stop(); err != nil {
+		t.Fatalf("failed to stop node: %v", err)
+	}
+	if ptm.running {
+		t.Fatalf("private transaction manager still running after the node stopped")
+	}
+}
+
+type accountAccessCheckerService struct{ NoopService }
+
+func (s *accountAccessCheckerService) CheckAccountAccess(common.Address, string, string) error {
+	return nil
+}
+
+// Tests that the RPC permission requires a service checking the account access
+// of the RPC callers.
+func TestRPCPermission(t *testing.T) {
+	config := testNodeConfig()
+	config.EnableRPCPermission = true
+
+	stack, err := New(config)
+	if err != nil {
+		t.Fatalf("failed to create protocol stack: %v", err)
+	}
+	defer stack.Close()
+
+	if err := stack.Start(); err != ErrRPCPermissionUnavailable {
+		t.Fatalf("start failure mismatch: have %v, want %v", err, ErrRPCPermissionUnavailable)
+	}
+	checker := new(accountAccessCheckerService)
+	if err := stack.Register(func(*ServiceContext) (Service, error) { return checker, nil }); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	defer stack.Stop()
+
+	if stack.accessChecker != checker {
+		t.Fatalf("access checker mismatch: have %v, want %v", stack.accessChecker, checker)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -37,6 +38,8 @@ type ServiceContext struct {
 	services       map[reflect.Type]Service // Index of the already constructed services
 	EventMux       *event.TypeMux           // Event multiplexer used for decoupled notifications
 	AccountManager *accounts.Manager        // Account manager created by the node.
+
+	ptm private.PrivateTransactionManager // Quorum: private transaction manager of the node
 }
 
 // OpenDatabase opens an existing database with the given name (or creates one
@@ -76,6 +79,14 @@ func (ctx *ServiceContext) ResolvePath(path string) string {
 	return ctx.config.ResolvePath(path)
 }
 
+// Quorum
+//
+// PrivateTransactionManager returns the private transaction manager of the
+// node, nil if none is configured.
+func (ctx *ServiceContext) PrivateTransactionManager() private.PrivateTransactionManager {
+	return ctx.ptm
+}
+
 // Service retrieves a currently running service registered of a specific type.
 func (ctx *ServiceContext) Service(service interface{}) error {
 	element := reflect.ValueOf(service).Elem()
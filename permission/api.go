@@ -46,6 +46,8 @@ const (
 	InitiateAccountRecovery
 	ApproveNodeRecovery
 	ApproveAccountRecovery
+	AddContractAccess
+	RemoveContractAccess
 )
 
 type AccountUpdateAction int
@@ -94,6 +96,8 @@ type txArgs struct {
 	voter      common.Address
 	morgId     string
 	tmKey      string
+	contract   common.Address
+	funcSig    types.FunctionSig
 	txa        ethapi.SendTxArgs
 }
 
@@ -125,6 +129,10 @@ func (q *QuorumControlsAPI) AcctList() []types.AccountInfo {
 	return types.AcctInfoMap.GetAcctList()
 }
 
+func (q *QuorumControlsAPI) ContractAccessList() []types.ContractAccessInfo {
+	return types.ContractAccessMap.GetRuleList()
+}
+
 func (q *QuorumControlsAPI) GetOrgDetails(orgId string) (types.OrgDetailInfo, error) {
 	o, err := types.OrgInfoMap.GetOrg(orgId)
 	if err != nil {
@@ -163,6 +171,23 @@ func (q *QuorumControlsAPI) GetOrgDetails(orgId string) (types.OrgDetailInfo, er
 	return types.OrgDetailInfo{NodeList: nodeList, RoleList: roleList, AcctList: acctList, SubOrgList: orgRec.SubOrgList}, nil
 }
 
+// AuditTrail returns the permission events matching the filter, oldest
+// first, with the account that triggered them and the status of the org,
+// node, account, role or contract access rule before and after each event
+func (q *QuorumControlsAPI) AuditTrail(filter PermissionEventFilter) ([]PermissionEvent, error) {
+	if q.permCtrl.audit == nil {
+		return nil, errAuditTrailNotReady
+	}
+	return q.permCtrl.audit.Events(filter)
+}
+
+// Simulate applies the given permission operations to a copy of the current
+// permission contracts and returns the resulting permission tree along with
+// the operations rejected by the contracts. Nothing is sent to the network.
+func (q *QuorumControlsAPI) Simulate(ops []SimulationOp) (*SimulationResult, error) {
+	return q.permCtrl.simulate(ops)
+}
+
 func (q *QuorumControlsAPI) initOp(txa ethapi.SendTxArgs) (*pbind.PermInterfaceSession, error) {
 	var err error
 	var w accounts.Wallet
@@ -176,6 +201,17 @@ func (q *QuorumControlsAPI) initOp(txa ethapi.SendTxArgs) (*pbind.PermInterfaceS
 	return pinterf, nil
 }
 
+func (q *QuorumControlsAPI) initContractAccessOp(txa ethapi.SendTxArgs) (*pbind.ContractAccessManagerSession, error) {
+	if q.permCtrl.permContractAccess == nil {
+		return nil, types.ErrContractAccessDisabled
+	}
+	w, err := q.validateAccount(txa.From)
+	if err != nil {
+		return nil, types.ErrInvalidAccount
+	}
+	return q.newContractAccessSession(w, txa), nil
+}
+
 func reportExecError(action PermAction, err error) (string, error) {
 	log.Error("Failed to execute permission action", "action", action, "err", err)
 	msg := fmt.Sprintf("failed to execute permissions action: %v", err)
@@ -504,6 +540,42 @@ func (q *QuorumControlsAPI) ApproveBlackListedAccountRecovery(orgId string, acct
 	return actionSuccess, nil
 }
 
+func (q *QuorumControlsAPI) AddContractAccess(orgId string, roleId string, contract common.Address, funcSig types.FunctionSig, txa ethapi.SendTxArgs) (string, error) {
+	caSession, err := q.initContractAccessOp(txa)
+	if err != nil {
+		return "", err
+	}
+	args := txArgs{orgId: orgId, roleId: roleId, contract: contract, funcSig: funcSig, txa: txa}
+
+	if err := q.valAddContractAccess(args); err != nil {
+		return "", err
+	}
+	tx, err := caSession.AddContractAccess(args.roleId, args.orgId, args.contract, args.funcSig)
+	if err != nil {
+		return reportExecError(AddContractAccess, err)
+	}
+	log.Debug("executed permission action", "action", AddContractAccess, "tx", tx)
+	return actionSuccess, nil
+}
+
+func (q *QuorumControlsAPI) RemoveContractAccess(orgId string, roleId string, contract common.Address, funcSig types.FunctionSig, txa ethapi.SendTxArgs) (string, error) {
+	caSession, err := q.initContractAccessOp(txa)
+	if err != nil {
+		return "", err
+	}
+	args := txArgs{orgId: orgId, roleId: roleId, contract: contract, funcSig: funcSig, txa: txa}
+
+	if err := q.valRemoveContractAccess(args); err != nil {
+		return "", err
+	}
+	tx, err := caSession.RemoveContractAccess(args.roleId, args.orgId, args.contract, args.funcSig)
+	if err != nil {
+		return reportExecError(RemoveContractAccess, err)
+	}
+	log.Debug("executed permission action", "action", RemoveContractAccess, "tx", tx)
+	return actionSuccess, nil
+}
+
 // check if the account is network admin
 func (q *QuorumControlsAPI) isNetworkAdmin(account common.Address) bool {
 	ac, _ := types.AcctInfoMap.GetAccount(account)
@@ -555,8 +627,10 @@ func (q *QuorumControlsAPI) checkPendingOp(orgId string, pinterf *pbind.PermInte
 	return err == nil && op.Int64() != 0
 }
 
-func (q *QuorumControlsAPI) checkOrgStatus(orgId string, op uint8) error {
-	org, _ := types.OrgInfoMap.GetOrg(orgId)
+// checkOrgStatus checks that the status of the master org orgId allows the
+// given status update
+func checkOrgStatus(orgs *types.OrgCache, orgId string, op uint8) error {
+	org, _ := orgs.GetOrg(orgId)
 
 	if org == nil {
 		return types.ErrOrgDoesNotExists
@@ -572,17 +646,17 @@ func (q *QuorumControlsAPI) checkOrgStatus(orgId string, op uint8) error {
 	return nil
 }
 
-func (q *QuorumControlsAPI) valNodeStatusChange(orgId, url string, op NodeUpdateAction, permAction PermAction) error {
+func valNodeStatusChange(nodes *types.NodeCache, orgId, url string, op NodeUpdateAction, permAction PermAction) error {
 	// validates if the enode is linked the passed organization
 	// validate node id and
 	if len(url) == 0 {
 		return types.ErrInvalidNode
 	}
-	if err := q.valNodeDetails(url); err != nil && err.Error() != types.ErrNodePresent.Error() {
+	if err := valNodeDetails(nodes, url); err != nil && err.Error() != types.ErrNodePresent.Error() {
 		return err
 	}
 
-	node, err := types.NodeInfoMap.GetNodeByUrl(url)
+	node, err := nodes.GetNodeByUrl(url)
 	if err != nil {
 		return err
 	}
@@ -632,14 +706,17 @@ func (q *QuorumControlsAPI) validateRole(orgId, roleId string) bool {
 	return r != nil && r.Active
 }
 
-func (q *QuorumControlsAPI) valAccountStatusChange(orgId string, account common.Address, permAction PermAction, op AccountUpdateAction) error {
+func valAccountStatusChange(config *types.PermissionConfig, accounts *types.AcctCache, orgId string, account common.Address, permAction PermAction, op AccountUpdateAction) error {
 	// validates if the enode is linked the passed organization
-	ac, err := types.AcctInfoMap.GetAccount(account)
+	ac, err := accounts.GetAccount(account)
 	if err != nil {
 		return err
 	}
+	if ac == nil {
+		return types.ErrAccountNotThere
+	}
 
-	if ac.IsOrgAdmin && (ac.RoleId == q.permCtrl.permConfig.NwAdminRole || ac.RoleId == q.permCtrl.permConfig.OrgAdminRole) && (op == 1 || op == 3) {
+	if ac.IsOrgAdmin && (ac.RoleId == config.NwAdminRole || ac.RoleId == config.OrgAdminRole) && (op == 1 || op == 3) {
 		return types.ErrOpNotAllowed
 	}
 
@@ -695,13 +772,13 @@ func (q *QuorumControlsAPI) valSubOrgBreadthDepth(porgId string) error {
 	return nil
 }
 
-func (q *QuorumControlsAPI) checkNodeExists(url, enodeId string) bool {
-	node, _ := types.NodeInfoMap.GetNodeByUrl(url)
+func checkNodeExists(nodes *types.NodeCache, url, enodeId string) bool {
+	node, _ := nodes.GetNodeByUrl(url)
 	if node != nil {
 		return true
 	}
 	// check if the same nodeid is in use with different port numbers
-	nodeList := types.NodeInfoMap.GetNodeList()
+	nodeList := nodes.GetNodeList()
 	for _, n := range nodeList {
 		if enodeDet, er := enode.ParseV4(n.Url); er == nil {
 			if enodeDet.EnodeID() == enodeId {
@@ -712,7 +789,7 @@ func (q *QuorumControlsAPI) checkNodeExists(url, enodeId string) bool {
 	return false
 }
 
-func (q *QuorumControlsAPI) valNodeDetails(url string) error {
+func valNodeDetails(nodes *types.NodeCache, url string) error {
 	// validate node id and
 	if len(url) != 0 {
 		enodeDet, err := enode.ParseV4(url)
@@ -720,7 +797,7 @@ func (q *QuorumControlsAPI) valNodeDetails(url string) error {
 			return types.ErrInvalidNode
 		}
 		// check if node already there
-		if q.checkNodeExists(url, enodeDet.EnodeID()) {
+		if checkNodeExists(nodes, url, enodeDet.EnodeID()) {
 			return types.ErrNodePresent
 		}
 	}
@@ -752,7 +829,7 @@ func (q *QuorumControlsAPI) valAddOrg(args txArgs, pinterf *pbind.PermInterfaceS
 	}
 
 	// validate node id and
-	if er := q.valNodeDetails(args.url); er != nil {
+	if er := valNodeDetails(types.NodeInfoMap, args.url); er != nil {
 		return er
 	}
 
@@ -798,7 +875,7 @@ func (q *QuorumControlsAPI) valAddSubOrg(args txArgs, pinterf *pbind.PermInterfa
 		return er
 	}
 
-	if er := q.valNodeDetails(args.url); er != nil {
+	if er := valNodeDetails(types.NodeInfoMap, args.url); er != nil {
 		return er
 	}
 	return nil
@@ -819,7 +896,7 @@ func (q *QuorumControlsAPI) valUpdateOrgStatus(args txArgs, pinterf *pbind.PermI
 		return types.ErrOpNotAllowed
 	}
 	// check if status update can be performed. Org should be approved for suspension
-	if er := q.checkOrgStatus(args.orgId, args.action); er != nil {
+	if er := checkOrgStatus(types.OrgInfoMap, args.orgId, args.action); er != nil {
 		return er
 	}
 	return nil
@@ -854,7 +931,7 @@ func (q *QuorumControlsAPI) valAddNode(args txArgs, pinterf *pbind.PermInterface
 		return er
 	}
 
-	if er := q.valNodeDetails(args.url); er != nil {
+	if er := valNodeDetails(types.NodeInfoMap, args.url); er != nil {
 		return er
 	}
 	return nil
@@ -868,7 +945,7 @@ func (q *QuorumControlsAPI) valUpdateNodeStatus(args txArgs, permAction PermActi
 	}
 
 	// validation status change is with in allowed set
-	if er := q.valNodeStatusChange(args.orgId, args.url, NodeUpdateAction(args.action), permAction); er != nil {
+	if er := valNodeStatusChange(types.NodeInfoMap, args.orgId, args.url, NodeUpdateAction(args.action), permAction); er != nil {
 		return er
 	}
 	return nil
@@ -990,7 +1067,7 @@ func (q *QuorumControlsAPI) valUpdateAccountStatus(args txArgs, permAction PermA
 		return er
 	}
 	// validation status change is with in allowed set
-	if er := q.valAccountStatusChange(args.orgId, args.acctId, permAction, AccountUpdateAction(args.action)); er != nil {
+	if er := valAccountStatusChange(q.permCtrl.permConfig, types.AcctInfoMap, args.orgId, args.acctId, permAction, AccountUpdateAction(args.action)); er != nil {
 		return er
 	}
 	return nil
@@ -1007,7 +1084,7 @@ func (q *QuorumControlsAPI) valRecoverNode(args txArgs, pinterf *pbind.PermInter
 	}
 
 	if action == InitiateNodeRecovery {
-		if err := q.valNodeStatusChange(args.orgId, args.url, 4, InitiateAccountRecovery); err != nil {
+		if err := valNodeStatusChange(types.NodeInfoMap, args.orgId, args.url, 4, InitiateAccountRecovery); err != nil {
 			return err
 		}
 		// check no pending approval items
@@ -1016,7 +1093,7 @@ func (q *QuorumControlsAPI) valRecoverNode(args txArgs, pinterf *pbind.PermInter
 		}
 	} else {
 		// validate inputs - org id is valid, node is valid pending recovery state
-		if err := q.valNodeStatusChange(args.orgId, args.url, 5, ApproveNodeRecovery); err != nil {
+		if err := valNodeStatusChange(types.NodeInfoMap, args.orgId, args.url, 5, ApproveNodeRecovery); err != nil {
 			return err
 		}
 
@@ -1044,7 +1121,7 @@ func (q *QuorumControlsAPI) valRecoverAccount(args txArgs, pinterf *pbind.PermIn
 		opAction = ApproveBlacklistedAccountRecovery
 	}
 
-	if err := q.valAccountStatusChange(args.orgId, args.acctId, action, opAction); err != nil {
+	if err := valAccountStatusChange(q.permCtrl.permConfig, types.AcctInfoMap, args.orgId, args.acctId, action, opAction); err != nil {
 		return err
 	}
 
@@ -1058,6 +1135,47 @@ func (q *QuorumControlsAPI) valRecoverAccount(args txArgs, pinterf *pbind.PermIn
 	return nil
 }
 
+func (q *QuorumControlsAPI) valContractAccess(args txArgs) error {
+	if args.roleId == "" || args.contract == (common.Address{}) {
+		return types.ErrInvalidInput
+	}
+	// check if the caller is org admin
+	if er := q.isOrgAdmin(args.txa.From, args.orgId); er != nil {
+		return er
+	}
+	// admin roles always have full access
+	if args.roleId == q.permCtrl.permConfig.OrgAdminRole || args.roleId == q.permCtrl.permConfig.NwAdminRole {
+		return types.ErrOpNotAllowed
+	}
+	r, _ := types.RoleInfoMap.GetRole(args.orgId, args.roleId)
+	if r == nil {
+		return types.ErrInvalidRole
+	} else if !r.Active {
+		return types.ErrInactiveRole
+	}
+	return nil
+}
+
+func (q *QuorumControlsAPI) valAddContractAccess(args txArgs) error {
+	if er := q.valContractAccess(args); er != nil {
+		return er
+	}
+	if r := types.ContractAccessMap.GetRule(args.orgId, args.roleId, args.contract, args.funcSig); r != nil && r.Active {
+		return types.ErrContractAccessExists
+	}
+	return nil
+}
+
+func (q *QuorumControlsAPI) valRemoveContractAccess(args txArgs) error {
+	if er := q.valContractAccess(args); er != nil {
+		return er
+	}
+	if r := types.ContractAccessMap.GetRule(args.orgId, args.roleId, args.contract, args.funcSig); r == nil || !r.Active {
+		return types.ErrContractAccessNotFound
+	}
+	return nil
+}
+
 // validateAccount validates the account and returns the wallet associated with that for signing the transaction
 func (q *QuorumControlsAPI) validateAccount(from common.Address) (accounts.Wallet, error) {
 	acct := accounts.Account{Address: from}
@@ -1085,6 +1203,22 @@ func (q *QuorumControlsAPI) newPermInterfaceSession(w accounts.Wallet, txa ethap
 	return ps
 }
 
+func (q *QuorumControlsAPI) newContractAccessSession(w accounts.Wallet, txa ethapi.SendTxArgs) *pbind.ContractAccessManagerSession {
+	frmAcct, transactOpts, gasLimit, gasPrice := q.getTxParams(txa, w)
+	return &pbind.ContractAccessManagerSession{
+		Contract: q.permCtrl.permContractAccess,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+		TransactOpts: bind.TransactOpts{
+			From:     frmAcct.Address,
+			GasLimit: gasLimit,
+			GasPrice: gasPrice,
+			Signer:   transactOpts.Signer,
+		},
+	}
+}
+
 // getTxParams extracts the transaction related parameters
 func (q *QuorumControlsAPI) getTxParams(txa ethapi.SendTxArgs, w accounts.Wallet) (accounts.Account, *bind.TransactOpts, uint64, *big.Int) {
 	fromAcct := accounts.Account{Address: txa.From}
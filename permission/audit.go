@@ -0,0 +1,358 @@
+package permission
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+var errAuditTrailNotReady = errors.New("permission audit trail is not available before the permission service is started")
+
+var orgStatusNames = map[types.OrgStatus]string{
+	types.OrgPendingApproval:   "PendingApproval",
+	types.OrgApproved:          "Approved",
+	types.OrgPendingSuspension: "PendingSuspension",
+	types.OrgSuspended:         "Suspended",
+}
+
+var nodeStatusNames = map[types.NodeStatus]string{
+	types.NodePendingApproval:   "PendingApproval",
+	types.NodeApproved:          "Approved",
+	types.NodeDeactivated:       "Deactivated",
+	types.NodeBlackListed:       "Blacklisted",
+	types.NodeRecoveryInitiated: "RecoveryInitiated",
+}
+
+var acctStatusNames = map[types.AcctStatus]string{
+	types.AcctPendingApproval:   "PendingApproval",
+	types.AcctActive:            "Active",
+	types.AcctInactive:          "Inactive",
+	types.AcctSuspended:         "Suspended",
+	types.AcctBlacklisted:       "Blacklisted",
+	types.AdminRevoked:          "AdminRevoked",
+	types.AcctRecoveryInitiated: "RecoveryInitiated",
+	types.AcctRecoveryCompleted: "RecoveryCompleted",
+}
+
+const (
+	roleActive            = "Active"
+	roleRevoked           = "Revoked"
+	contractAccessGranted = "Granted"
+	contractAccessRevoked = "Revoked"
+)
+
+// PermissionEvent is an entry of the permission audit trail. It records an
+// event of the permission contracts along with the account that sent the
+// transaction emitting it and the status of the org, node, account, role or
+// contract access rule before and after it.
+type PermissionEvent struct {
+	Event       string             `json:"event"`
+	Actor       common.Address     `json:"actor"`
+	TxHash      common.Hash        `json:"txHash"`
+	BlockNumber uint64             `json:"blockNumber"`
+	LogIndex    uint               `json:"logIndex"`
+	Timestamp   uint64             `json:"timestamp"`
+	OrgId       string             `json:"orgId"`
+	RoleId      string             `json:"roleId,omitempty"`
+	Account     *common.Address    `json:"account,omitempty"`
+	Node        string             `json:"node,omitempty"`
+	Contract    *common.Address    `json:"contract,omitempty"`
+	FunctionSig *types.FunctionSig `json:"funcSig,omitempty"`
+	OldStatus   string             `json:"oldStatus,omitempty"`
+	NewStatus   string             `json:"newStatus"`
+}
+
+// subject identifies what the status of the event applies to
+func (e *PermissionEvent) subject() string {
+	switch {
+	case e.Contract != nil:
+		return "rule:" + e.OrgId + ":" + e.RoleId + ":" + e.Contract.Hex() + ":" + common.Bytes2Hex(e.FunctionSig[:])
+	case e.Account != nil:
+		return "account:" + e.Account.Hex()
+	case e.Node != "":
+		return "node:" + nodeIdentity(e.Node)
+	case e.RoleId != "":
+		return "role:" + e.OrgId + ":" + e.RoleId
+	}
+	return "org:" + e.OrgId
+}
+
+// nodeIdentity returns the node id of an enode url, or the url itself if it
+// can't be parsed
+func nodeIdentity(url string) string {
+	if n, err := enode.ParseV4(url); err == nil {
+		return n.ID().String()
+	}
+	return url
+}
+
+// PermissionEventFilter restricts the permission events returned by the audit
+// trail. Empty fields match all the events. FromTime and ToTime are unix
+// timestamps.
+type PermissionEventFilter struct {
+	OrgId    string          `json:"orgId"`
+	Account  *common.Address `json:"account"`
+	Node     string          `json:"node"`
+	FromTime uint64          `json:"fromTime"`
+	ToTime   uint64          `json:"toTime"`
+}
+
+func (f *PermissionEventFilter) matches(e *PermissionEvent) bool {
+	if f.OrgId != "" && e.OrgId != f.OrgId {
+		return false
+	}
+	if f.Account != nil && (e.Account == nil || *e.Account != *f.Account) {
+		return false
+	}
+	if f.Node != "" && (e.Node == "" || nodeIdentity(e.Node) != nodeIdentity(f.Node)) {
+		return false
+	}
+	if e.Timestamp < f.FromTime || (f.ToTime != 0 && e.Timestamp > f.ToTime) {
+		return false
+	}
+	return true
+}
+
+// the audit trail is indexed by org, account, node, subject and time so that
+// the events matching a filter are found without scanning the whole trail
+const (
+	orgIndex     = 'o'
+	accountIndex = 'a'
+	nodeIndex    = 'n'
+	subjectIndex = 's'
+	timeIndex    = 't'
+)
+
+func indexKey(kind byte, value []byte) []byte {
+	return append([]byte{kind}, value...)
+}
+
+func timeIndexKey(timestamp uint64) []byte {
+	key := make([]byte, 9)
+	key[0] = timeIndex
+	binary.BigEndian.PutUint64(key[1:], timestamp)
+	return key
+}
+
+// indexKeys returns the keys the event is indexed under
+func (e *PermissionEvent) indexKeys() [][]byte {
+	keys := [][]byte{indexKey(subjectIndex, []byte(e.subject())), timeIndexKey(e.Timestamp)}
+	if e.OrgId != "" {
+		keys = append(keys, indexKey(orgIndex, []byte(e.OrgId)))
+	}
+	if e.Account != nil {
+		keys = append(keys, indexKey(accountIndex, e.Account.Bytes()))
+	}
+	if e.Node != "" {
+		keys = append(keys, indexKey(nodeIndex, []byte(nodeIdentity(e.Node))))
+	}
+	return keys
+}
+
+// PermissionAuditStore keeps the history of the permission events in the
+// chain database, filled from the events watched by the permission service
+type PermissionAuditStore struct {
+	db ethdb.Database
+	mu sync.Mutex // serializes the updates of the audit trail
+}
+
+func NewPermissionAuditStore(db ethdb.Database) *PermissionAuditStore {
+	return &PermissionAuditStore{db: db}
+}
+
+// Record adds the event to the audit trail along with the status set by the
+// previous event of the same org, node, account, role or contract access
+// rule, events being recorded in the order they are emitted. Events seen
+// again when the watches are restarted overwrite the existing entry and
+// events of blocks that are no longer canonical are removed.
+func (store *PermissionAuditStore) Record(evt *PermissionEvent, removed bool) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if removed {
+		return store.delete(evt.BlockNumber, evt.LogIndex)
+	}
+	oldStatus, err := store.previousStatus(evt)
+	if err != nil {
+		return err
+	}
+	evt.OldStatus = oldStatus
+	//no unmarshallable types, so can't error
+	data, _ := json.Marshal(evt)
+	batch := store.db.NewBatch()
+	if err := rawdb.WritePermissionEvent(batch, evt.BlockNumber, evt.LogIndex, data); err != nil {
+		return err
+	}
+	for _, key := range evt.indexKeys() {
+		if err := rawdb.WritePermissionEventIndex(batch, key, evt.BlockNumber, evt.LogIndex); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// previousStatus returns the status set by the last event of the subject of
+// evt emitted before it
+func (store *PermissionAuditStore) previousStatus(evt *PermissionEvent) (string, error) {
+	key := indexKey(subjectIndex, []byte(evt.subject()))
+	var previous *rawdb.PermissionEventPosition
+	for _, pos := range rawdb.ReadPermissionEventIndex(store.db, key, key) {
+		if pos.Number > evt.BlockNumber || (pos.Number == evt.BlockNumber && pos.LogIndex >= evt.LogIndex) {
+			break
+		}
+		pos := pos
+		previous = &pos
+	}
+	if previous == nil {
+		return "", nil
+	}
+	prevEvt, err := store.read(*previous)
+	if err != nil || prevEvt == nil {
+		return "", err
+	}
+	return prevEvt.NewStatus, nil
+}
+
+// delete removes the event emitted by the log at the given index of the block
+// and its index entries
+func (store *PermissionAuditStore) delete(number uint64, logIndex uint) error {
+	evt, err := store.read(rawdb.PermissionEventPosition{Number: number, LogIndex: logIndex})
+	if err != nil || evt == nil {
+		return err
+	}
+	batch := store.db.NewBatch()
+	for _, key := range evt.indexKeys() {
+		if err := rawdb.DeletePermissionEventIndex(batch, key, number, logIndex); err != nil {
+			return err
+		}
+	}
+	if err := rawdb.DeletePermissionEvent(batch, number, logIndex); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// read returns the event at the given position, nil if there is none
+func (store *PermissionAuditStore) read(pos rawdb.PermissionEventPosition) (*PermissionEvent, error) {
+	data := rawdb.ReadPermissionEvent(store.db, pos.Number, pos.LogIndex)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var evt PermissionEvent
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return nil, err
+	}
+	return &evt, nil
+}
+
+// Events returns the events matching the filter in the order they were
+// emitted. The events are looked up through the index of the account, node,
+// org or time range of the filter, in this order of preference, and only the
+// whole trail is read when the filter is empty.
+func (store *PermissionAuditStore) Events(filter PermissionEventFilter) ([]PermissionEvent, error) {
+	var positions []rawdb.PermissionEventPosition
+	switch {
+	case filter.Account != nil:
+		key := indexKey(accountIndex, filter.Account.Bytes())
+		positions = rawdb.ReadPermissionEventIndex(store.db, key, key)
+	case filter.Node != "":
+		key := indexKey(nodeIndex, []byte(nodeIdentity(filter.Node)))
+		positions = rawdb.ReadPermissionEventIndex(store.db, key, key)
+	case filter.OrgId != "":
+		key := indexKey(orgIndex, []byte(filter.OrgId))
+		positions = rawdb.ReadPermissionEventIndex(store.db, key, key)
+	case filter.FromTime != 0 || filter.ToTime != 0:
+		toTime := filter.ToTime
+		if toTime == 0 {
+			toTime = math.MaxUint64
+		}
+		positions = rawdb.ReadPermissionEventIndex(store.db, timeIndexKey(filter.FromTime), timeIndexKey(toTime))
+	default:
+		return store.allEvents()
+	}
+
+	events := make([]PermissionEvent, 0, len(positions))
+	for _, pos := range positions {
+		evt, err := store.read(pos)
+		if err != nil {
+			return nil, err
+		}
+		// the event may have been removed since its position was read
+		if evt != nil && filter.matches(evt) {
+			events = append(events, *evt)
+		}
+	}
+	return events, nil
+}
+
+// allEvents returns the whole audit trail
+func (store *PermissionAuditStore) allEvents() ([]PermissionEvent, error) {
+	events := make([]PermissionEvent, 0)
+	for _, data := range rawdb.ReadAllPermissionEvents(store.db) {
+		var evt PermissionEvent
+		if err := json.Unmarshal(data, &evt); err != nil {
+			return nil, err
+		}
+		events = append(events, evt)
+	}
+	return events, nil
+}
+
+// recordPermissionEvent completes the event with the details of the
+// transaction and block of the log emitting it and adds it to the audit
+// trail. failures are logged as the audit trail must not hold up the
+// permission cache updates.
+func (p *PermissionCtrl) recordPermissionEvent(raw types.Log, evt PermissionEvent) {
+	if p.audit == nil {
+		return
+	}
+	evt.TxHash = raw.TxHash
+	evt.BlockNumber = raw.BlockNumber
+	evt.LogIndex = raw.Index
+	if !raw.Removed {
+		chain := p.eth.BlockChain()
+		if header := chain.GetHeaderByHash(raw.BlockHash); header != nil {
+			evt.Timestamp = header.Time
+		}
+		if tx, _, _, _ := rawdb.ReadTransaction(p.eth.ChainDb(), raw.TxHash); tx != nil {
+			signer := types.MakeSigner(chain.Config(), new(big.Int).SetUint64(raw.BlockNumber))
+			if from, err := types.Sender(signer, tx); err == nil {
+				evt.Actor = from
+			}
+		}
+	}
+	if err := p.audit.Record(&evt, raw.Removed); err != nil {
+		log.Error("Failed to record permission event", "event", evt.Event, "tx", raw.TxHash, "err", err)
+	}
+}
+
+func (p *PermissionCtrl) recordOrgEvent(event string, raw types.Log, orgId, porgId string, status types.OrgStatus) {
+	fullOrgId := orgId
+	if porgId != "" {
+		fullOrgId = porgId + "." + orgId
+	}
+	p.recordPermissionEvent(raw, PermissionEvent{Event: event, OrgId: fullOrgId, NewStatus: orgStatusNames[status]})
+}
+
+func (p *PermissionCtrl) recordNodeEvent(event string, raw types.Log, orgId, enodeId string, status types.NodeStatus) {
+	p.recordPermissionEvent(raw, PermissionEvent{Event: event, OrgId: orgId, Node: enodeId, NewStatus: nodeStatusNames[status]})
+}
+
+func (p *PermissionCtrl) recordAccountEvent(event string, raw types.Log, orgId, roleId string, account common.Address, status types.AcctStatus) {
+	p.recordPermissionEvent(raw, PermissionEvent{Event: event, OrgId: orgId, RoleId: roleId, Account: &account, NewStatus: acctStatusNames[status]})
+}
+
+func (p *PermissionCtrl) recordContractAccessEvent(event string, raw types.Log, orgId, roleId string, contract common.Address, sig [4]byte, status string) {
+	funcSig := types.FunctionSig(sig)
+	p.recordPermissionEvent(raw, PermissionEvent{Event: event, OrgId: orgId, RoleId: roleId, Contract: &contract, FunctionSig: &funcSig, NewStatus: status})
+}
@@ -0,0 +1,584 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package permission
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ContractAccessManagerABI is the input ABI used to generate the binding from.
+const ContractAccessManagerABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getNumberOfRules\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_contract\",\"type\":\"address\"},{\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"removeContractAccess\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"_ruleIndex\",\"type\":\"uint256\"}],\"name\":\"getRuleFromIndex\",\"outputs\":[{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_contract\",\"type\":\"address\"},{\"name\":\"_functionSig\",\"type\":\"bytes4\"},{\"name\":\"_active\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_roleId\",\"type\":\"string\"},{\"name\":\"_orgId\",\"type\":\"string\"},{\"name\":\"_contract\",\"type\":\"address\"},{\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"addContractAccess\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"_permUpgradable\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"_roleId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_orgId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_contract\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"ContractAccessGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"name\":\"_roleId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_orgId\",\"type\":\"string\"},{\"indexed\":false,\"name\":\"_contract\",\"type\":\"address\"},{\"indexed\":false,\"name\":\"_functionSig\",\"type\":\"bytes4\"}],\"name\":\"ContractAccessRevoked\",\"type\":\"event\"}]"
+
+var ContractAccessManagerParsedABI, _ = abi.JSON(strings.NewReader(ContractAccessManagerABI))
+
+// ContractAccessManagerBin is the compiled bytecode used for deploying new contracts.
+var ContractAccessManagerBin = "0x341561000a57600080fd5b60206105da60003960005173ffffffffffffffffffffffffffffffffffffffff1660005561059e8061003c6000396000f3341561000a57600080fd5b60043610610062576000357c010000000000000000000000000000000000000000000000000000000090048063694c5a6214610270578063cf6d62dd1461033f57806317d8d87b1461006757806385716c00146103ba575b600080fd5b60015460005260206000f35b60843610610062576004356004018035610120526020016101005261012051601f0160209004602002610140526024356004018035610180526020016101605261018051601f01602090046020026101a0526080610300526101405160a0016103205260443573ffffffffffffffffffffffffffffffffffffffff16610340526064357fffffffff000000000000000000000000000000000000000000000000000000001661036052610120516103805261012051610100516103a03761018051610140516103a001526101805161016051610140516103c001376101a0516101405160c001016101c05260006020527f0e32cf90000000000000000000000000000000000000000000000000000000006000526020806004600060005473ffffffffffffffffffffffffffffffffffffffff165afa5060205173ffffffffffffffffffffffffffffffffffffffff16610260526101c051610300017f9bd38101000000000000000000000000000000000000000000000000000000008152338160040152604081602401526101805181604401526101805161016051826064013760008052602060006101a05160640183610260515afa60203d101516600051151516905061024a576084806104526000396000fd5b6101c05161030020806101e0526000526003602052604060002080610200525461022052565b610278610073565b610220516102e357600154600101806001558061020051556000526002602052604060002061024052600161024051556101c051610240516001015560005b6101c0518110156102dd57806103000151602082046002016102405101556020016102b7565b50610314565b61022051600052600260205260406000208061024052541561030c576064806104d66000396000fd5b600161024051555b7f10775d1c60ffcbf8b0cee748a7e86b9fd109cd36ff20f38b67846461d3e34f186101c051610300a1005b610347610073565b61022051151561035e5760648061053a6000396000fd5b610220516000526002602052604060002080610240525415156103885760648061053a6000396000fd5b600061024051557fd10e7259a9cfb1f8a6ef6605e4668f4f6e7194348f67f53f1177a39cd10fa3676101c051610300a1005b6024361061006257600154600435106103d257600080fd5b600435600101600052600260205260406000206102405261024051600101546101c05260005b6101c05181101561041d576020810460020161024051015481602001526020016103f8565b50602051602001600052604051602001602052606051604052608051606052610240515415156080526101c0516020016000f3fe08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000226163636f756e74206973206e6f742061206f72672061646d696e206163636f756e7400000000000000000000000000000000000000000000000000000000000008c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001872756c652065786973747320666f722074686520726f6c65000000000000000008c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001372756c6520646f6573206e6f7420657869737400000000000000000000000000"
+
+// DeployContractAccessManager deploys a new Ethereum contract, binding an instance of ContractAccessManager to it.
+func DeployContractAccessManager(auth *bind.TransactOpts, backend bind.ContractBackend, _permUpgradable common.Address) (common.Address, *types.Transaction, *ContractAccessManager, error) {
+	parsed, err := abi.JSON(strings.NewReader(ContractAccessManagerABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(ContractAccessManagerBin), backend, _permUpgradable)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ContractAccessManager{ContractAccessManagerCaller: ContractAccessManagerCaller{contract: contract}, ContractAccessManagerTransactor: ContractAccessManagerTransactor{contract: contract}, ContractAccessManagerFilterer: ContractAccessManagerFilterer{contract: contract}}, nil
+}
+
+// ContractAccessManager is an auto generated Go binding around an Ethereum contract.
+type ContractAccessManager struct {
+	ContractAccessManagerCaller     // Read-only binding to the contract
+	ContractAccessManagerTransactor // Write-only binding to the contract
+	ContractAccessManagerFilterer   // Log filterer for contract events
+}
+
+// ContractAccessManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ContractAccessManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractAccessManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ContractAccessManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractAccessManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ContractAccessManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractAccessManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ContractAccessManagerSession struct {
+	Contract     *ContractAccessManager // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts          // Call options to use throughout this session
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// ContractAccessManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ContractAccessManagerCallerSession struct {
+	Contract *ContractAccessManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                // Call options to use throughout this session
+}
+
+// ContractAccessManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ContractAccessManagerTransactorSession struct {
+	Contract     *ContractAccessManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                // Transaction auth options to use throughout this session
+}
+
+// ContractAccessManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ContractAccessManagerRaw struct {
+	Contract *ContractAccessManager // Generic contract binding to access the raw methods on
+}
+
+// ContractAccessManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ContractAccessManagerCallerRaw struct {
+	Contract *ContractAccessManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ContractAccessManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ContractAccessManagerTransactorRaw struct {
+	Contract *ContractAccessManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewContractAccessManager creates a new instance of ContractAccessManager, bound to a specific deployed contract.
+func NewContractAccessManager(address common.Address, backend bind.ContractBackend) (*ContractAccessManager, error) {
+	contract, err := bindContractAccessManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAccessManager{ContractAccessManagerCaller: ContractAccessManagerCaller{contract: contract}, ContractAccessManagerTransactor: ContractAccessManagerTransactor{contract: contract}, ContractAccessManagerFilterer: ContractAccessManagerFilterer{contract: contract}}, nil
+}
+
+// NewContractAccessManagerCaller creates a new read-only instance of ContractAccessManager, bound to a specific deployed contract.
+func NewContractAccessManagerCaller(address common.Address, caller bind.ContractCaller) (*ContractAccessManagerCaller, error) {
+	contract, err := bindContractAccessManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAccessManagerCaller{contract: contract}, nil
+}
+
+// NewContractAccessManagerTransactor creates a new write-only instance of ContractAccessManager, bound to a specific deployed contract.
+func NewContractAccessManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*ContractAccessManagerTransactor, error) {
+	contract, err := bindContractAccessManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAccessManagerTransactor{contract: contract}, nil
+}
+
+// NewContractAccessManagerFilterer creates a new log filterer instance of ContractAccessManager, bound to a specific deployed contract.
+func NewContractAccessManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*ContractAccessManagerFilterer, error) {
+	contract, err := bindContractAccessManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAccessManagerFilterer{contract: contract}, nil
+}
+
+// bindContractAccessManager binds a generic wrapper to an already deployed contract.
+func bindContractAccessManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ContractAccessManagerABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ContractAccessManager *ContractAccessManagerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _ContractAccessManager.Contract.ContractAccessManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ContractAccessManager *ContractAccessManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.ContractAccessManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ContractAccessManager *ContractAccessManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.ContractAccessManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ContractAccessManager *ContractAccessManagerCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _ContractAccessManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ContractAccessManager *ContractAccessManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ContractAccessManager *ContractAccessManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetNumberOfRules is a free data retrieval call binding the contract method 0x17d8d87b.
+//
+// Solidity: function getNumberOfRules() constant returns(uint256)
+func (_ContractAccessManager *ContractAccessManagerCaller) GetNumberOfRules(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _ContractAccessManager.contract.Call(opts, out, "getNumberOfRules")
+	return *ret0, err
+}
+
+// GetNumberOfRules is a free data retrieval call binding the contract method 0x17d8d87b.
+//
+// Solidity: function getNumberOfRules() constant returns(uint256)
+func (_ContractAccessManager *ContractAccessManagerSession) GetNumberOfRules() (*big.Int, error) {
+	return _ContractAccessManager.Contract.GetNumberOfRules(&_ContractAccessManager.CallOpts)
+}
+
+// GetNumberOfRules is a free data retrieval call binding the contract method 0x17d8d87b.
+//
+// Solidity: function getNumberOfRules() constant returns(uint256)
+func (_ContractAccessManager *ContractAccessManagerCallerSession) GetNumberOfRules() (*big.Int, error) {
+	return _ContractAccessManager.Contract.GetNumberOfRules(&_ContractAccessManager.CallOpts)
+}
+
+// GetRuleFromIndex is a free data retrieval call binding the contract method 0x85716c00.
+//
+// Solidity: function getRuleFromIndex(uint256 _ruleIndex) constant returns(string _roleId, string _orgId, address _contract, bytes4 _functionSig, bool _active)
+func (_ContractAccessManager *ContractAccessManagerCaller) GetRuleFromIndex(opts *bind.CallOpts, _ruleIndex *big.Int) (struct {
+	RoleId      string
+	OrgId       string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Active      bool
+}, error) {
+	ret := new(struct {
+		RoleId      string
+		OrgId       string
+		Contract    common.Address
+		FunctionSig [4]byte
+		Active      bool
+	})
+	out := ret
+	err := _ContractAccessManager.contract.Call(opts, out, "getRuleFromIndex", _ruleIndex)
+	return *ret, err
+}
+
+// GetRuleFromIndex is a free data retrieval call binding the contract method 0x85716c00.
+//
+// Solidity: function getRuleFromIndex(uint256 _ruleIndex) constant returns(string _roleId, string _orgId, address _contract, bytes4 _functionSig, bool _active)
+func (_ContractAccessManager *ContractAccessManagerSession) GetRuleFromIndex(_ruleIndex *big.Int) (struct {
+	RoleId      string
+	OrgId       string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Active      bool
+}, error) {
+	return _ContractAccessManager.Contract.GetRuleFromIndex(&_ContractAccessManager.CallOpts, _ruleIndex)
+}
+
+// GetRuleFromIndex is a free data retrieval call binding the contract method 0x85716c00.
+//
+// Solidity: function getRuleFromIndex(uint256 _ruleIndex) constant returns(string _roleId, string _orgId, address _contract, bytes4 _functionSig, bool _active)
+func (_ContractAccessManager *ContractAccessManagerCallerSession) GetRuleFromIndex(_ruleIndex *big.Int) (struct {
+	RoleId      string
+	OrgId       string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Active      bool
+}, error) {
+	return _ContractAccessManager.Contract.GetRuleFromIndex(&_ContractAccessManager.CallOpts, _ruleIndex)
+}
+
+// AddContractAccess is a paid mutator transaction binding the contract method 0x694c5a62.
+//
+// Solidity: function addContractAccess(string _roleId, string _orgId, address _contract, bytes4 _functionSig) returns()
+func (_ContractAccessManager *ContractAccessManagerTransactor) AddContractAccess(opts *bind.TransactOpts, _roleId string, _orgId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _ContractAccessManager.contract.Transact(opts, "addContractAccess", _roleId, _orgId, _contract, _functionSig)
+}
+
+// AddContractAccess is a paid mutator transaction binding the contract method 0x694c5a62.
+//
+// Solidity: function addContractAccess(string _roleId, string _orgId, address _contract, bytes4 _functionSig) returns()
+func (_ContractAccessManager *ContractAccessManagerSession) AddContractAccess(_roleId string, _orgId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.AddContractAccess(&_ContractAccessManager.TransactOpts, _roleId, _orgId, _contract, _functionSig)
+}
+
+// AddContractAccess is a paid mutator transaction binding the contract method 0x694c5a62.
+//
+// Solidity: function addContractAccess(string _roleId, string _orgId, address _contract, bytes4 _functionSig) returns()
+func (_ContractAccessManager *ContractAccessManagerTransactorSession) AddContractAccess(_roleId string, _orgId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.AddContractAccess(&_ContractAccessManager.TransactOpts, _roleId, _orgId, _contract, _functionSig)
+}
+
+// RemoveContractAccess is a paid mutator transaction binding the contract method 0xcf6d62dd.
+//
+// Solidity: function removeContractAccess(string _roleId, string _orgId, address _contract, bytes4 _functionSig) returns()
+func (_ContractAccessManager *ContractAccessManagerTransactor) RemoveContractAccess(opts *bind.TransactOpts, _roleId string, _orgId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _ContractAccessManager.contract.Transact(opts, "removeContractAccess", _roleId, _orgId, _contract, _functionSig)
+}
+
+// RemoveContractAccess is a paid mutator transaction binding the contract method 0xcf6d62dd.
+//
+// Solidity: function removeContractAccess(string _roleId, string _orgId, address _contract, bytes4 _functionSig) returns()
+func (_ContractAccessManager *ContractAccessManagerSession) RemoveContractAccess(_roleId string, _orgId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.RemoveContractAccess(&_ContractAccessManager.TransactOpts, _roleId, _orgId, _contract, _functionSig)
+}
+
+// RemoveContractAccess is a paid mutator transaction binding the contract method 0xcf6d62dd.
+//
+// Solidity: function removeContractAccess(string _roleId, string _orgId, address _contract, bytes4 _functionSig) returns()
+func (_ContractAccessManager *ContractAccessManagerTransactorSession) RemoveContractAccess(_roleId string, _orgId string, _contract common.Address, _functionSig [4]byte) (*types.Transaction, error) {
+	return _ContractAccessManager.Contract.RemoveContractAccess(&_ContractAccessManager.TransactOpts, _roleId, _orgId, _contract, _functionSig)
+}
+
+// ContractAccessManagerContractAccessGrantedIterator is returned from FilterContractAccessGranted and is used to iterate over the raw logs and unpacked data for ContractAccessGranted events raised by the ContractAccessManager contract.
+type ContractAccessManagerContractAccessGrantedIterator struct {
+	Event *ContractAccessManagerContractAccessGranted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractAccessManagerContractAccessGrantedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractAccessManagerContractAccessGranted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractAccessManagerContractAccessGranted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractAccessManagerContractAccessGrantedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractAccessManagerContractAccessGrantedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractAccessManagerContractAccessGranted represents a ContractAccessGranted event raised by the ContractAccessManager contract.
+type ContractAccessManagerContractAccessGranted struct {
+	RoleId      string
+	OrgId       string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterContractAccessGranted is a free log retrieval operation binding the contract event 0x10775d1c60ffcbf8b0cee748a7e86b9fd109cd36ff20f38b67846461d3e34f18.
+//
+// Solidity: event ContractAccessGranted(string _roleId, string _orgId, address _contract, bytes4 _functionSig)
+func (_ContractAccessManager *ContractAccessManagerFilterer) FilterContractAccessGranted(opts *bind.FilterOpts) (*ContractAccessManagerContractAccessGrantedIterator, error) {
+
+	logs, sub, err := _ContractAccessManager.contract.FilterLogs(opts, "ContractAccessGranted")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAccessManagerContractAccessGrantedIterator{contract: _ContractAccessManager.contract, event: "ContractAccessGranted", logs: logs, sub: sub}, nil
+}
+
+var ContractAccessGrantedTopicHash = "0x10775d1c60ffcbf8b0cee748a7e86b9fd109cd36ff20f38b67846461d3e34f18"
+
+// WatchContractAccessGranted is a free log subscription operation binding the contract event 0x10775d1c60ffcbf8b0cee748a7e86b9fd109cd36ff20f38b67846461d3e34f18.
+//
+// Solidity: event ContractAccessGranted(string _roleId, string _orgId, address _contract, bytes4 _functionSig)
+func (_ContractAccessManager *ContractAccessManagerFilterer) WatchContractAccessGranted(opts *bind.WatchOpts, sink chan<- *ContractAccessManagerContractAccessGranted) (event.Subscription, error) {
+
+	logs, sub, err := _ContractAccessManager.contract.WatchLogs(opts, "ContractAccessGranted")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractAccessManagerContractAccessGranted)
+				if err := _ContractAccessManager.contract.UnpackLog(event, "ContractAccessGranted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseContractAccessGranted is a log parse operation binding the contract event 0x10775d1c60ffcbf8b0cee748a7e86b9fd109cd36ff20f38b67846461d3e34f18.
+//
+// Solidity: event ContractAccessGranted(string _roleId, string _orgId, address _contract, bytes4 _functionSig)
+func (_ContractAccessManager *ContractAccessManagerFilterer) ParseContractAccessGranted(log types.Log) (*ContractAccessManagerContractAccessGranted, error) {
+	event := new(ContractAccessManagerContractAccessGranted)
+	if err := _ContractAccessManager.contract.UnpackLog(event, "ContractAccessGranted", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ContractAccessManagerContractAccessRevokedIterator is returned from FilterContractAccessRevoked and is used to iterate over the raw logs and unpacked data for ContractAccessRevoked events raised by the ContractAccessManager contract.
+type ContractAccessManagerContractAccessRevokedIterator struct {
+	Event *ContractAccessManagerContractAccessRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ContractAccessManagerContractAccessRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ContractAccessManagerContractAccessRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ContractAccessManagerContractAccessRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ContractAccessManagerContractAccessRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ContractAccessManagerContractAccessRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ContractAccessManagerContractAccessRevoked represents a ContractAccessRevoked event raised by the ContractAccessManager contract.
+type ContractAccessManagerContractAccessRevoked struct {
+	RoleId      string
+	OrgId       string
+	Contract    common.Address
+	FunctionSig [4]byte
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterContractAccessRevoked is a free log retrieval operation binding the contract event 0xd10e7259a9cfb1f8a6ef6605e4668f4f6e7194348f67f53f1177a39cd10fa367.
+//
+// Solidity: event ContractAccessRevoked(string _roleId, string _orgId, address _contract, bytes4 _functionSig)
+func (_ContractAccessManager *ContractAccessManagerFilterer) FilterContractAccessRevoked(opts *bind.FilterOpts) (*ContractAccessManagerContractAccessRevokedIterator, error) {
+
+	logs, sub, err := _ContractAccessManager.contract.FilterLogs(opts, "ContractAccessRevoked")
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAccessManagerContractAccessRevokedIterator{contract: _ContractAccessManager.contract, event: "ContractAccessRevoked", logs: logs, sub: sub}, nil
+}
+
+var ContractAccessRevokedTopicHash = "0xd10e7259a9cfb1f8a6ef6605e4668f4f6e7194348f67f53f1177a39cd10fa367"
+
+// WatchContractAccessRevoked is a free log subscription operation binding the contract event 0xd10e7259a9cfb1f8a6ef6605e4668f4f6e7194348f67f53f1177a39cd10fa367.
+//
+// Solidity: event ContractAccessRevoked(string _roleId, string _orgId, address _contract, bytes4 _functionSig)
+func (_ContractAccessManager *ContractAccessManagerFilterer) WatchContractAccessRevoked(opts *bind.WatchOpts, sink chan<- *ContractAccessManagerContractAccessRevoked) (event.Subscription, error) {
+
+	logs, sub, err := _ContractAccessManager.contract.WatchLogs(opts, "ContractAccessRevoked")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ContractAccessManagerContractAccessRevoked)
+				if err := _ContractAccessManager.contract.UnpackLog(event, "ContractAccessRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseContractAccessRevoked is a log parse operation binding the contract event 0xd10e7259a9cfb1f8a6ef6605e4668f4f6e7194348f67f53f1177a39cd10fa367.
+//
+// Solidity: event ContractAccessRevoked(string _roleId, string _orgId, address _contract, bytes4 _functionSig)
+func (_ContractAccessManager *ContractAccessManagerFilterer) ParseContractAccessRevoked(log types.Log) (*ContractAccessManagerContractAccessRevoked, error) {
+	event := new(ContractAccessManagerContractAccessRevoked)
+	if err := _ContractAccessManager.contract.UnpackLog(event, "ContractAccessRevoked", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,107 @@
+pragma solidity ^0.5.3;
+
+import "./PermissionsUpgradable.sol";
+
+/** @title Contract access manager contract
+  * @notice This contract holds the contract level access rules of roles.
+    A rule allows the accounts linked to a role to call a contract and
+    optionally only one function of it. A role with no active rules is
+    governed by its base access only. Once a rule is added for a role,
+    its accounts can only transact with the contracts listed for it.
+    Rules can be added or removed by the org admin of the org owning
+    the role. there are few view functions exposed as public and can be
+    called directly. these are invoked by quorum for populating
+    permissions data in cache
+  */
+contract ContractAccessManager {
+    PermissionsUpgradable private permUpgradable;
+
+    struct AccessRule {
+        string roleId;
+        string orgId;
+        address contractAddress;
+        bytes4 functionSig;
+        bool active;
+    }
+
+    AccessRule[] private ruleList;
+    mapping(bytes32 => uint256) private ruleIndex;
+    uint256 private numberOfRules;
+
+    event ContractAccessGranted(string _roleId, string _orgId, address _contract,
+        bytes4 _functionSig);
+    event ContractAccessRevoked(string _roleId, string _orgId, address _contract,
+        bytes4 _functionSig);
+
+    /** @notice confirms that the caller is an org admin of the org
+      * @param _orgId org id of the role the rule belongs to
+      */
+    modifier onlyOrgAdmin(string memory _orgId) {
+        require(PermissionsImplementation(permUpgradable.getPermImpl()).isOrgAdmin(msg.sender, _orgId),
+            "account is not a org admin account");
+        _;
+    }
+
+    /** @notice constructor. sets the permissions upgradable address
+      */
+    constructor (address _permUpgradable) public {
+        permUpgradable = PermissionsUpgradable(_permUpgradable);
+    }
+
+    /** @notice function to allow a role to call a contract
+      * @param _roleId - role the rule applies to
+      * @param _orgId - org id to which the role belongs
+      * @param _contract - contract the accounts of the role may call
+      * @param _functionSig - function selector the accounts of the role may
+            call. 0x00000000 allows all functions of the contract
+      */
+    function addContractAccess(string calldata _roleId, string calldata _orgId,
+        address _contract, bytes4 _functionSig) external onlyOrgAdmin(_orgId) {
+        bytes32 key = keccak256(abi.encode(_roleId, _orgId, _contract, _functionSig));
+        if (ruleIndex[key] == 0) {
+            numberOfRules ++;
+            ruleIndex[key] = numberOfRules;
+            ruleList.push(AccessRule(_roleId, _orgId, _contract, _functionSig, true));
+        } else {
+            require(ruleList[ruleIndex[key] - 1].active == false, "rule exists for the role");
+            ruleList[ruleIndex[key] - 1].active = true;
+        }
+        emit ContractAccessGranted(_roleId, _orgId, _contract, _functionSig);
+    }
+
+    /** @notice function to remove a contract level rule from a role
+      * @param _roleId - role the rule applies to
+      * @param _orgId - org id to which the role belongs
+      * @param _contract - contract of the rule
+      * @param _functionSig - function selector of the rule
+      */
+    function removeContractAccess(string calldata _roleId, string calldata _orgId,
+        address _contract, bytes4 _functionSig) external onlyOrgAdmin(_orgId) {
+        bytes32 key = keccak256(abi.encode(_roleId, _orgId, _contract, _functionSig));
+        require(ruleIndex[key] != 0 && ruleList[ruleIndex[key] - 1].active == true,
+            "rule does not exist");
+        ruleList[ruleIndex[key] - 1].active = false;
+        emit ContractAccessRevoked(_roleId, _orgId, _contract, _functionSig);
+    }
+
+    /** @notice returns the total number of rules in the network
+      * @return total number of rules
+      */
+    function getNumberOfRules() external view returns (uint256) {
+        return numberOfRules;
+    }
+
+    /** @notice returns the rule details for a given index
+      * @param _ruleIndex index of the rule
+      * @return role id
+      * @return org id
+      * @return contract address
+      * @return function selector
+      * @return bool indicating if the rule is active
+      */
+    function getRuleFromIndex(uint256 _ruleIndex) external view returns (string memory _roleId,
+        string memory _orgId, address _contract, bytes4 _functionSig, bool _active) {
+        AccessRule storage rule = ruleList[_ruleIndex];
+        return (rule.roleId, rule.orgId, rule.contractAddress, rule.functionSig, rule.active);
+    }
+}
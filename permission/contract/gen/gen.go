@@ -7,6 +7,7 @@
 // 2. abigen (make all from root)
 
 //go:generate solc --abi --bin -o . --overwrite ../AccountManager.sol
+//go:generate solc --abi --bin -o . --overwrite ../ContractAccessManager.sol
 //go:generate solc --abi --bin -o . --overwrite ../NodeManager.sol
 //go:generate solc --abi --bin -o . --overwrite ../OrgManager.sol
 //go:generate solc --abi --bin -o . --overwrite ../PermissionsImplementation.sol
@@ -16,6 +17,7 @@
 //go:generate solc --abi --bin -o . --overwrite ../VoterManager.sol
 
 //go:generate abigen -pkg permission -abi  ./AccountManager.abi            -bin  ./AccountManager.bin            -type AcctManager   -out ../../bind/accounts.go
+//go:generate abigen -pkg permission -abi  ./ContractAccessManager.abi     -bin  ./ContractAccessManager.bin     -type ContractAccessManager -out ../../bind/contract_access.go
 //go:generate abigen -pkg permission -abi  ./NodeManager.abi               -bin  ./NodeManager.bin               -type NodeManager   -out ../../bind/nodes.go
 //go:generate abigen -pkg permission -abi  ./OrgManager.abi                -bin  ./OrgManager.bin                -type OrgManager    -out ../../bind/org.go
 //go:generate abigen -pkg permission -abi  ./PermissionsImplementation.abi -bin  ./PermissionsImplementation.bin -type PermImpl      -out ../../bind/permission_impl.go
@@ -50,6 +50,10 @@ type PermissionCtrl struct {
 	permOrg    *pbind.OrgManager
 	permConfig *types.PermissionConfig
 
+	permContractAccess *pbind.ContractAccessManager // nil unless contract level access rules are configured
+
+	audit *PermissionAuditStore // history of the permission events
+
 	startWaitGroup *sync.WaitGroup // waitgroup to make sure all dependencies are ready before we start the service
 	stopFeed       event.Feed      // broadcasting stopEvent when service is being stopped
 	errorChan      chan error      // channel to capture error when starting aysnc
@@ -172,6 +176,15 @@ func (p *PermissionCtrl) AfterStart() error {
 	if err := p.bindContract(&p.permOrg, func() (interface{}, error) { return pbind.NewOrgManager(p.permConfig.OrgAddress, p.ethClnt) }); err != nil {
 		return err
 	}
+	if p.permConfig.ContractAccessAddress != (common.Address{}) {
+		if err := p.bindContract(&p.permContractAccess, func() (interface{}, error) {
+			return pbind.NewContractAccessManager(p.permConfig.ContractAccessAddress, p.ethClnt)
+		}); err != nil {
+			return err
+		}
+	}
+
+	p.audit = NewPermissionAuditStore(p.eth.ChainDb())
 
 	// populate the initial list of permissioned nodes and account accesses
 	if err := p.populateInitPermissions(params.DEFAULT_ORGCACHE_SIZE, params.DEFAULT_ROLECACHE_SIZE,
@@ -183,11 +196,12 @@ func (p *PermissionCtrl) AfterStart() error {
 	types.SetDefaults(p.permConfig.NwAdminRole, p.permConfig.OrgAdminRole)
 
 	for _, f := range []func() error{
-		p.monitorQIP714Block,       // monitor block number to activate new permissions controls
-		p.manageOrgPermissions,     // monitor org management related events
-		p.manageNodePermissions,    // monitor org  level node management events
-		p.manageRolePermissions,    // monitor org level role management events
-		p.manageAccountPermissions, // monitor org level account management events
+		p.monitorQIP714Block,              // monitor block number to activate new permissions controls
+		p.manageOrgPermissions,            // monitor org management related events
+		p.manageNodePermissions,           // monitor org  level node management events
+		p.manageRolePermissions,           // monitor org level role management events
+		p.manageAccountPermissions,        // monitor org level account management events
+		p.manageContractAccessPermissions, // monitor contract level access rule events
 	} {
 		if err := f(); err != nil {
 			return err
@@ -256,6 +270,22 @@ func (p *PermissionCtrl) Start(srvr *p2p.Server) error {
 	return nil
 }
 
+// RPC methods sending a transaction on behalf of the caller
+var transactingRPCMethods = map[string]bool{
+	"eth_sendTransaction":             true,
+	"eth_sendTransactionAsync":        true,
+	"eth_sendRawTransaction":          true,
+	"eth_sendRawPrivateTransaction":   true,
+	"personal_sendTransaction":        true,
+	"personal_signAndSendTransaction": true,
+}
+
+// CheckAccountAccess authorizes an RPC call against the permission account
+// granted to the caller, so that the callers of a suspended org lose RPC access
+func (p *PermissionCtrl) CheckAccountAccess(account common.Address, service, method string) error {
+	return types.CheckRPCAccess(account, transactingRPCMethods[service+"_"+method])
+}
+
 func (p *PermissionCtrl) APIs() []rpc.API {
 	return []rpc.API{
 		{
@@ -343,15 +373,19 @@ func (p *PermissionCtrl) manageOrgPermissions() error {
 			select {
 			case evtPendingApproval := <-chPendingApproval:
 				types.OrgInfoMap.UpsertOrg(evtPendingApproval.OrgId, evtPendingApproval.PorgId, evtPendingApproval.UltParent, evtPendingApproval.Level, types.OrgStatus(evtPendingApproval.Status.Uint64()))
+				p.recordOrgEvent("OrgPendingApproval", evtPendingApproval.Raw, evtPendingApproval.OrgId, evtPendingApproval.PorgId, types.OrgStatus(evtPendingApproval.Status.Uint64()))
 
 			case evtOrgApproved := <-chOrgApproved:
 				types.OrgInfoMap.UpsertOrg(evtOrgApproved.OrgId, evtOrgApproved.PorgId, evtOrgApproved.UltParent, evtOrgApproved.Level, types.OrgApproved)
+				p.recordOrgEvent("OrgApproved", evtOrgApproved.Raw, evtOrgApproved.OrgId, evtOrgApproved.PorgId, types.OrgApproved)
 
 			case evtOrgSuspended := <-chOrgSuspended:
 				types.OrgInfoMap.UpsertOrg(evtOrgSuspended.OrgId, evtOrgSuspended.PorgId, evtOrgSuspended.UltParent, evtOrgSuspended.Level, types.OrgSuspended)
+				p.recordOrgEvent("OrgSuspended", evtOrgSuspended.Raw, evtOrgSuspended.OrgId, evtOrgSuspended.PorgId, types.OrgSuspended)
 
 			case evtOrgReactivated := <-chOrgReactivated:
 				types.OrgInfoMap.UpsertOrg(evtOrgReactivated.OrgId, evtOrgReactivated.PorgId, evtOrgReactivated.UltParent, evtOrgReactivated.Level, types.OrgApproved)
+				p.recordOrgEvent("OrgSuspensionRevoked", evtOrgReactivated.Raw, evtOrgReactivated.OrgId, evtOrgReactivated.PorgId, types.OrgApproved)
 			case <-stopChan:
 				log.Info("quit org contract watch")
 				return
@@ -416,28 +450,35 @@ func (p *PermissionCtrl) manageNodePermissions() error {
 			case evtNodeApproved := <-chNodeApproved:
 				p.updatePermissionedNodes(evtNodeApproved.EnodeId, NodeAdd)
 				types.NodeInfoMap.UpsertNode(evtNodeApproved.OrgId, evtNodeApproved.EnodeId, types.NodeApproved)
+				p.recordNodeEvent("NodeApproved", evtNodeApproved.Raw, evtNodeApproved.OrgId, evtNodeApproved.EnodeId, types.NodeApproved)
 
 			case evtNodeProposed := <-chNodeProposed:
 				types.NodeInfoMap.UpsertNode(evtNodeProposed.OrgId, evtNodeProposed.EnodeId, types.NodePendingApproval)
+				p.recordNodeEvent("NodeProposed", evtNodeProposed.Raw, evtNodeProposed.OrgId, evtNodeProposed.EnodeId, types.NodePendingApproval)
 
 			case evtNodeDeactivated := <-chNodeDeactivated:
 				p.updatePermissionedNodes(evtNodeDeactivated.EnodeId, NodeDelete)
 				types.NodeInfoMap.UpsertNode(evtNodeDeactivated.OrgId, evtNodeDeactivated.EnodeId, types.NodeDeactivated)
+				p.recordNodeEvent("NodeDeactivated", evtNodeDeactivated.Raw, evtNodeDeactivated.OrgId, evtNodeDeactivated.EnodeId, types.NodeDeactivated)
 
 			case evtNodeActivated := <-chNodeActivated:
 				p.updatePermissionedNodes(evtNodeActivated.EnodeId, NodeAdd)
 				types.NodeInfoMap.UpsertNode(evtNodeActivated.OrgId, evtNodeActivated.EnodeId, types.NodeApproved)
+				p.recordNodeEvent("NodeActivated", evtNodeActivated.Raw, evtNodeActivated.OrgId, evtNodeActivated.EnodeId, types.NodeApproved)
 
 			case evtNodeBlacklisted := <-chNodeBlacklisted:
 				types.NodeInfoMap.UpsertNode(evtNodeBlacklisted.OrgId, evtNodeBlacklisted.EnodeId, types.NodeBlackListed)
+				p.recordNodeEvent("NodeBlacklisted", evtNodeBlacklisted.Raw, evtNodeBlacklisted.OrgId, evtNodeBlacklisted.EnodeId, types.NodeBlackListed)
 				p.updateDisallowedNodes(evtNodeBlacklisted.EnodeId, NodeAdd)
 				p.updatePermissionedNodes(evtNodeBlacklisted.EnodeId, NodeDelete)
 
 			case evtNodeRecoveryInit := <-chNodeRecoveryInit:
 				types.NodeInfoMap.UpsertNode(evtNodeRecoveryInit.OrgId, evtNodeRecoveryInit.EnodeId, types.NodeRecoveryInitiated)
+				p.recordNodeEvent("NodeRecoveryInitiated", evtNodeRecoveryInit.Raw, evtNodeRecoveryInit.OrgId, evtNodeRecoveryInit.EnodeId, types.NodeRecoveryInitiated)
 
 			case evtNodeRecoveryDone := <-chNodeRecoveryDone:
 				types.NodeInfoMap.UpsertNode(evtNodeRecoveryDone.OrgId, evtNodeRecoveryDone.EnodeId, types.NodeApproved)
+				p.recordNodeEvent("NodeRecoveryCompleted", evtNodeRecoveryDone.Raw, evtNodeRecoveryDone.OrgId, evtNodeRecoveryDone.EnodeId, types.NodeApproved)
 				p.updateDisallowedNodes(evtNodeRecoveryDone.EnodeId, NodeDelete)
 				p.updatePermissionedNodes(evtNodeRecoveryDone.EnodeId, NodeAdd)
 
@@ -566,11 +607,14 @@ func (p *PermissionCtrl) manageAccountPermissions() error {
 			select {
 			case evtAccessModified := <-chAccessModified:
 				types.AcctInfoMap.UpsertAccount(evtAccessModified.OrgId, evtAccessModified.RoleId, evtAccessModified.Account, evtAccessModified.OrgAdmin, types.AcctStatus(int(evtAccessModified.Status.Uint64())))
+				p.recordAccountEvent("AccountAccessModified", evtAccessModified.Raw, evtAccessModified.OrgId, evtAccessModified.RoleId, evtAccessModified.Account, types.AcctStatus(int(evtAccessModified.Status.Uint64())))
 
 			case evtAccessRevoked := <-chAccessRevoked:
 				types.AcctInfoMap.UpsertAccount(evtAccessRevoked.OrgId, evtAccessRevoked.RoleId, evtAccessRevoked.Account, evtAccessRevoked.OrgAdmin, types.AcctActive)
+				p.recordAccountEvent("AccountAccessRevoked", evtAccessRevoked.Raw, evtAccessRevoked.OrgId, evtAccessRevoked.RoleId, evtAccessRevoked.Account, types.AcctActive)
 
 			case evtStatusChanged := <-chStatusChanged:
+				p.recordAccountEvent("AccountStatusChanged", evtStatusChanged.Raw, evtStatusChanged.OrgId, "", evtStatusChanged.Account, types.AcctStatus(int(evtStatusChanged.Status.Uint64())))
 				if ac, err := types.AcctInfoMap.GetAccount(evtStatusChanged.Account); ac != nil {
 					types.AcctInfoMap.UpsertAccount(evtStatusChanged.OrgId, ac.RoleId, evtStatusChanged.Account, ac.IsOrgAdmin, types.AcctStatus(int(evtStatusChanged.Status.Uint64())))
 				} else {
@@ -629,6 +673,8 @@ func (p *PermissionCtrl) instantiateCache(orgCacheSize, roleCacheSize, nodeCache
 
 	types.AcctInfoMap = types.NewAcctCache(accountCacheSize)
 	types.AcctInfoMap.PopulateCacheFunc(p.populateAccountToCache)
+
+	types.ContractAccessMap = types.NewContractAccessCache()
 }
 
 // Thus function checks if the initial network boot up status and if no
@@ -668,6 +714,7 @@ func (p *PermissionCtrl) populateInitPermissions(orgCacheSize, roleCacheSize, no
 			p.populateNodesFromContract,
 			p.populateRolesFromContract,
 			p.populateAccountsFromContract,
+			p.populateContractAccessFromContract,
 		} {
 			if err := f(auth); err != nil {
 				return err
@@ -753,6 +800,29 @@ func (p *PermissionCtrl) populateRolesFromContract(auth *bind.TransactOpts) erro
 	return nil
 }
 
+// populates the contract level access rules from contract into cache
+func (p *PermissionCtrl) populateContractAccessFromContract(auth *bind.TransactOpts) error {
+	if p.permContractAccess == nil {
+		return nil
+	}
+	permContractAccessSession := &pbind.ContractAccessManagerSession{
+		Contract: p.permContractAccess,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	numberOfRules, err := permContractAccessSession.GetNumberOfRules()
+	if err != nil {
+		return err
+	}
+	for k := uint64(0); k < numberOfRules.Uint64(); k++ {
+		if rule, err := permContractAccessSession.GetRuleFromIndex(big.NewInt(int64(k))); err == nil {
+			types.ContractAccessMap.UpsertRule(rule.OrgId, rule.RoleId, rule.Contract, types.FunctionSig(rule.FunctionSig), rule.Active)
+		}
+	}
+	return nil
+}
+
 // populates the node details from contract into cache
 func (p *PermissionCtrl) populateNodesFromContract(auth *bind.TransactOpts) error {
 	//populate nodes
@@ -859,8 +929,10 @@ func (p *PermissionCtrl) manageRolePermissions() error {
 			select {
 			case evtRoleCreated := <-chRoleCreated:
 				types.RoleInfoMap.UpsertRole(evtRoleCreated.OrgId, evtRoleCreated.RoleId, evtRoleCreated.IsVoter, evtRoleCreated.IsAdmin, types.AccessType(int(evtRoleCreated.BaseAccess.Uint64())), true)
+				p.recordPermissionEvent(evtRoleCreated.Raw, PermissionEvent{Event: "RoleCreated", OrgId: evtRoleCreated.OrgId, RoleId: evtRoleCreated.RoleId, NewStatus: roleActive})
 
 			case evtRoleRevoked := <-chRoleRevoked:
+				p.recordPermissionEvent(evtRoleRevoked.Raw, PermissionEvent{Event: "RoleRevoked", OrgId: evtRoleRevoked.OrgId, RoleId: evtRoleRevoked.RoleId, NewStatus: roleRevoked})
 				if r, _ := types.RoleInfoMap.GetRole(evtRoleRevoked.OrgId, evtRoleRevoked.RoleId); r != nil {
 					types.RoleInfoMap.UpsertRole(evtRoleRevoked.OrgId, evtRoleRevoked.RoleId, r.IsVoter, r.IsAdmin, r.Access, false)
 				} else {
@@ -875,6 +947,48 @@ func (p *PermissionCtrl) manageRolePermissions() error {
 	return nil
 }
 
+// Monitors contract level access rule events and updates cache accordingly
+func (p *PermissionCtrl) manageContractAccessPermissions() error {
+	if p.permContractAccess == nil {
+		return nil
+	}
+	chAccessGranted := make(chan *pbind.ContractAccessManagerContractAccessGranted, 1)
+	chAccessRevoked := make(chan *pbind.ContractAccessManagerContractAccessRevoked, 1)
+
+	opts := &bind.WatchOpts{}
+	var blockNumber uint64 = 1
+	opts.Start = &blockNumber
+
+	if _, err := p.permContractAccess.ContractAccessManagerFilterer.WatchContractAccessGranted(opts, chAccessGranted); err != nil {
+		return fmt.Errorf("failed WatchContractAccessGranted: %v", err)
+	}
+
+	if _, err := p.permContractAccess.ContractAccessManagerFilterer.WatchContractAccessRevoked(opts, chAccessRevoked); err != nil {
+		return fmt.Errorf("failed WatchContractAccessRevoked: %v", err)
+	}
+
+	go func() {
+		stopChan, stopSubscription := p.subscribeStopEvent()
+		defer stopSubscription.Unsubscribe()
+		for {
+			select {
+			case evtAccessGranted := <-chAccessGranted:
+				types.ContractAccessMap.UpsertRule(evtAccessGranted.OrgId, evtAccessGranted.RoleId, evtAccessGranted.Contract, types.FunctionSig(evtAccessGranted.FunctionSig), true)
+				p.recordContractAccessEvent("ContractAccessGranted", evtAccessGranted.Raw, evtAccessGranted.OrgId, evtAccessGranted.RoleId, evtAccessGranted.Contract, evtAccessGranted.FunctionSig, contractAccessGranted)
+
+			case evtAccessRevoked := <-chAccessRevoked:
+				types.ContractAccessMap.UpsertRule(evtAccessRevoked.OrgId, evtAccessRevoked.RoleId, evtAccessRevoked.Contract, types.FunctionSig(evtAccessRevoked.FunctionSig), false)
+				p.recordContractAccessEvent("ContractAccessRevoked", evtAccessRevoked.Raw, evtAccessRevoked.OrgId, evtAccessRevoked.RoleId, evtAccessRevoked.Contract, evtAccessRevoked.FunctionSig, contractAccessRevoked)
+
+			case <-stopChan:
+				log.Info("quit contract access watch")
+				return
+			}
+		}
+	}()
+	return nil
+}
+
 // getter to get an account record from the contract
 func (p *PermissionCtrl) populateAccountToCache(acctId common.Address) (*types.AccountInfo, error) {
 	permAcctInterface := &pbind.AcctManagerSession{
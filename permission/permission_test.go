@@ -19,6 +19,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth"
@@ -43,6 +45,7 @@ const (
 	arbitrarySubOrg           = "SUB1"
 	arbitrartNewRole1         = "NEW_ROLE_1"
 	arbitrartNewRole2         = "NEW_ROLE_2"
+	arbitraryContractRole     = "CONTRACT_ROLE"
 	orgCacheSize              = 4
 	roleCacheSize             = 4
 	nodeCacheSize             = 2
@@ -59,7 +62,8 @@ var (
 	guardianAccount accounts.Account
 	backend         bind.ContractBackend
 	permUpgrAddress, permInterfaceAddress, permImplAddress, voterManagerAddress,
-	nodeManagerAddress, roleManagerAddress, accountManagerAddress, orgManagerAddress common.Address
+	nodeManagerAddress, roleManagerAddress, accountManagerAddress, orgManagerAddress,
+	contractAccessManagerAddress common.Address
 	ethereum        *eth.Ethereum
 	stack           *node.Node
 	guardianAddress common.Address
@@ -115,7 +119,7 @@ func setup() {
 		Genesis: &core.Genesis{Config: params.AllEthashProtocolChanges, GasLimit: 10000000000, Alloc: genesisAlloc},
 		Miner:   miner.Config{Etherbase: guardianAddress},
 		Ethash: ethash.Config{
-			PowMode: ethash.ModeTest,
+			PowMode: ethash.ModeFake,
 		},
 	}
 
@@ -167,6 +171,10 @@ func setup() {
 	if err != nil {
 		t.Fatal(err)
 	}
+	contractAccessManagerAddress, _, _, err = pbind.DeployContractAccessManager(guardianTransactor, backend, permUpgrAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
 	// call init
 	if _, err := permUpgrInstance.Init(guardianTransactor, permInterfaceAddress, permImplAddress); err != nil {
 		t.Fatal(err)
@@ -270,6 +278,80 @@ func TestQuorumControlsAPI_ListAPIs(t *testing.T) {
 	assert.True(t, len(testObject.RoleList()) > 0, fmt.Sprintf("expected non zero org list"))
 }
 
+func TestQuorumControlsAPI_Simulate(t *testing.T) {
+	testObject := typicalQuorumControlsAPI(t)
+	// the simulation runs against the state of the latest block
+	backend.(*backends.SimulatedBackend).Commit()
+	orgAdminAddress := getArbitraryAccount()
+	orgSession := &pbind.OrgManagerSession{
+		Contract: testObject.permCtrl.permOrg,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+	numberOfOrgs, err := orgSession.GetNumberOfOrgs()
+	assert.NoError(t, err)
+
+	result, err := testObject.Simulate([]SimulationOp{
+		{Op: "addOrg", From: getArbitraryAccount(), OrgId: "SIM_ORG", Url: arbitraryNode4, Account: orgAdminAddress},
+		{Op: "addOrg", From: guardianAddress, OrgId: "SIM_ORG", Url: arbitraryNode4, Account: orgAdminAddress},
+		{Op: "approveOrg", From: guardianAddress, OrgId: "SIM_ORG", Url: arbitraryNode4, Account: orgAdminAddress},
+		{Op: "addNewRole", From: orgAdminAddress, OrgId: "SIM_ORG", RoleId: arbitrartNewRole1, Access: uint8(types.Transact)},
+		{Op: "addOrg", From: guardianAddress, OrgId: "SIM_ORG", Url: arbitraryNode3, Account: orgAdminAddress},
+		{Op: "deleteOrg", From: guardianAddress, OrgId: "SIM_ORG"},
+		// checked against the simulated state by the validators of the APIs
+		{Op: "addOrg", From: guardianAddress, OrgId: "SIM_ORG2", Url: arbitraryNode4, Account: getArbitraryAccount()},
+		{Op: "addNode", From: orgAdminAddress, OrgId: "SIM_ORG", Url: "enode://invalid"},
+		{Op: "updateOrgStatus", From: guardianAddress, OrgId: "SIM_ORG", Action: uint8(ActivateSuspendedOrg)},
+		{Op: "updateAccountStatus", From: orgAdminAddress, OrgId: "SIM_ORG", Account: orgAdminAddress, Action: uint8(SuspendAccount)},
+		{Op: "updateAccountStatus", From: orgAdminAddress, OrgId: "SIM_ORG", Account: getArbitraryAccount(), Action: uint8(SuspendAccount)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(result.Ops))
+	assert.Equal(t, 8, result.Violations)
+	assert.Equal(t, "account is not a network admin account", result.Ops[0].Error)
+	assert.True(t, result.Ops[1].Success)
+	assert.True(t, result.Ops[2].Success)
+	assert.True(t, result.Ops[3].Success)
+	assert.Equal(t, "org exists", result.Ops[4].Error)
+	assert.Equal(t, ErrUnknownSimulationOp.Error(), result.Ops[5].Error)
+	assert.Equal(t, types.ErrNodePresent.Error(), result.Ops[6].Error)
+	assert.Equal(t, types.ErrInvalidNode.Error(), result.Ops[7].Error)
+	assert.Equal(t, types.ErrOpNotAllowed.Error(), result.Ops[8].Error)
+	assert.Equal(t, types.ErrOpNotAllowed.Error(), result.Ops[9].Error)
+	assert.Equal(t, types.ErrAccountNotThere.Error(), result.Ops[10].Error)
+
+	var simOrg *types.OrgInfo
+	for i := range result.Orgs {
+		if result.Orgs[i].OrgId == "SIM_ORG" {
+			simOrg = &result.Orgs[i]
+		}
+	}
+	if assert.NotNil(t, simOrg) {
+		assert.Equal(t, types.OrgApproved, simOrg.Status)
+	}
+	var simRole, simAccount, simNode bool
+	for _, r := range result.Roles {
+		simRole = simRole || (r.OrgId == "SIM_ORG" && r.RoleId == arbitrartNewRole1)
+	}
+	for _, a := range result.Accounts {
+		simAccount = simAccount || (a.AcctId == orgAdminAddress && a.OrgId == "SIM_ORG" && a.IsOrgAdmin)
+	}
+	for _, n := range result.Nodes {
+		simNode = simNode || (n.OrgId == "SIM_ORG" && n.Url == arbitraryNode4)
+	}
+	assert.True(t, simRole)
+	assert.True(t, simAccount)
+	assert.True(t, simNode)
+
+	// nothing is sent to the network
+	numberOfOrgsAfter, err := orgSession.GetNumberOfOrgs()
+	assert.NoError(t, err)
+	assert.Equal(t, numberOfOrgs, numberOfOrgsAfter)
+	_, err = types.OrgInfoMap.GetOrg("SIM_ORG")
+	assert.Equal(t, types.ErrOrgDoesNotExists, err)
+}
+
 func TestQuorumControlsAPI_OrgAPIs(t *testing.T) {
 	testObject := typicalQuorumControlsAPI(t)
 	invalidTxa := ethapi.SendTxArgs{From: getArbitraryAccount()}
@@ -520,6 +602,87 @@ func TestQuorumControlsAPI_RoleAndAccountsAPIs(t *testing.T) {
 	assert.True(t, acctInfo != nil, "account details nil")
 }
 
+func TestQuorumControlsAPI_ContractAccessAPIs(t *testing.T) {
+	testObject := typicalQuorumControlsAPI(t)
+	invalidTxa := ethapi.SendTxArgs{From: getArbitraryAccount()}
+	txa := ethapi.SendTxArgs{From: guardianAddress}
+	contract := getArbitraryAccount()
+	transfer := types.FunctionSig{0xa9, 0x05, 0x9c, 0xbb}
+	caSession := &pbind.ContractAccessManagerSession{
+		Contract: testObject.permCtrl.permContractAccess,
+		CallOpts: bind.CallOpts{
+			Pending: true,
+		},
+	}
+
+	_, err := testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, invalidTxa)
+	assert.Equal(t, err, errors.New("Invalid account id"))
+
+	_, err = testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, txa)
+	assert.Equal(t, err, types.ErrInvalidRole)
+
+	_, err = testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryNetworkAdminRole, contract, transfer, txa)
+	assert.Equal(t, err, types.ErrOpNotAllowed)
+
+	_, err = testObject.AddNewRole(arbitraryNetworkAdminOrg, arbitraryContractRole, uint8(types.Transact), false, false, txa)
+	assert.NoError(t, err)
+	types.RoleInfoMap.UpsertRole(arbitraryNetworkAdminOrg, arbitraryContractRole, false, false, types.Transact, true)
+
+	_, err = testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, common.Address{}, transfer, txa)
+	assert.Equal(t, err, types.ErrInvalidInput)
+
+	_, err = testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, txa)
+	assert.NoError(t, err)
+
+	numberOfRules, err := caSession.GetNumberOfRules()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), numberOfRules.Int64())
+	rule, err := caSession.GetRuleFromIndex(big.NewInt(0))
+	assert.NoError(t, err)
+	assert.Equal(t, arbitraryNetworkAdminOrg, rule.OrgId)
+	assert.Equal(t, arbitraryContractRole, rule.RoleId)
+	assert.Equal(t, contract, rule.Contract)
+	assert.Equal(t, [4]byte(transfer), rule.FunctionSig)
+	assert.True(t, rule.Active)
+	types.ContractAccessMap.UpsertRule(rule.OrgId, rule.RoleId, rule.Contract, types.FunctionSig(rule.FunctionSig), rule.Active)
+
+	_, err = testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, txa)
+	assert.Equal(t, err, types.ErrContractAccessExists)
+	assert.Equal(t, 1, len(testObject.ContractAccessList()))
+
+	// the contract only accepts rules from the org admins of the role's org
+	otherTransactor := bind.NewKeyedTransactor(guardianKey)
+	_, err = caSession.Contract.AddContractAccess(otherTransactor, arbitraryContractRole, arbitraryOrgToAdd, contract, transfer)
+	assert.Error(t, err)
+
+	_, err = testObject.RemoveContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, invalidTxa)
+	assert.Equal(t, err, errors.New("Invalid account id"))
+
+	_, err = testObject.RemoveContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, txa)
+	assert.NoError(t, err)
+	rule, err = caSession.GetRuleFromIndex(big.NewInt(0))
+	assert.NoError(t, err)
+	assert.False(t, rule.Active)
+	types.ContractAccessMap.UpsertRule(rule.OrgId, rule.RoleId, rule.Contract, types.FunctionSig(rule.FunctionSig), rule.Active)
+
+	_, err = testObject.RemoveContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, txa)
+	assert.Equal(t, err, types.ErrContractAccessNotFound)
+
+	// adding a revoked rule again reactivates it
+	_, err = testObject.AddContractAccess(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer, txa)
+	assert.NoError(t, err)
+	numberOfRules, err = caSession.GetNumberOfRules()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), numberOfRules.Int64())
+	rule, err = caSession.GetRuleFromIndex(big.NewInt(0))
+	assert.NoError(t, err)
+	assert.True(t, rule.Active)
+
+	// the rules are loaded into the cache when the service starts
+	assert.NoError(t, testObject.permCtrl.populateInitPermissions(orgCacheSize, roleCacheSize, nodeCacheSize, accountCacheSize))
+	assert.NotNil(t, types.ContractAccessMap.GetRule(arbitraryNetworkAdminOrg, arbitraryContractRole, contract, transfer))
+}
+
 func getArbitraryAccount() common.Address {
 	acctKey, _ := crypto.GenerateKey()
 	return crypto.PubkeyToAddress(acctKey.PublicKey)
@@ -538,6 +701,9 @@ func typicalPermissionCtrl(t *testing.T) *PermissionCtrl {
 		NwAdminOrg:     arbitraryNetworkAdminOrg,
 		NwAdminRole:    arbitraryNetworkAdminRole,
 		OrgAdminRole:   arbitraryOrgAdminRole,
+
+		ContractAccessAddress: contractAccessManagerAddress,
+
 		Accounts: []common.Address{
 			guardianAddress,
 		},
@@ -569,6 +735,169 @@ func tmpKeyStore(encrypted bool) (string, *keystore.KeyStore, error) {
 	return d, newKs(d), err
 }
 
+func TestPermissionAuditStore_Events(t *testing.T) {
+	store := NewPermissionAuditStore(rawdb.NewMemoryDatabase())
+	admin := guardianAddress
+	account := getArbitraryAccount()
+	events := []PermissionEvent{
+		{Event: "NodeProposed", Actor: admin, BlockNumber: 2, LogIndex: 1, Timestamp: 100, OrgId: arbitraryOrgToAdd, Node: arbitraryNode1, NewStatus: nodeStatusNames[types.NodePendingApproval]},
+		{Event: "OrgPendingApproval", Actor: admin, BlockNumber: 2, LogIndex: 0, Timestamp: 100, OrgId: arbitraryOrgToAdd, NewStatus: orgStatusNames[types.OrgPendingApproval]},
+		{Event: "OrgApproved", Actor: admin, BlockNumber: 3, LogIndex: 0, Timestamp: 110, OrgId: arbitraryOrgToAdd, NewStatus: orgStatusNames[types.OrgApproved]},
+		{Event: "NodeApproved", Actor: admin, BlockNumber: 3, LogIndex: 1, Timestamp: 110, OrgId: arbitraryOrgToAdd, Node: arbitraryNode1, NewStatus: nodeStatusNames[types.NodeApproved]},
+		{Event: "AccountAccessModified", Actor: admin, BlockNumber: 4, LogIndex: 0, Timestamp: 120, OrgId: arbitraryOrgToAdd, RoleId: arbitrartNewRole1, Account: &account, NewStatus: acctStatusNames[types.AcctActive]},
+		{Event: "NodeBlacklisted", Actor: account, BlockNumber: 5, LogIndex: 0, Timestamp: 130, OrgId: arbitraryOrgToAdd, Node: arbitraryNode1, NewStatus: nodeStatusNames[types.NodeBlackListed]},
+	}
+	for i := range events {
+		assert.NoError(t, store.Record(&events[i], false))
+	}
+	// events seen again when the watches restart are only recorded once
+	assert.NoError(t, store.Record(&events[5], false))
+
+	all, err := store.Events(PermissionEventFilter{})
+	assert.NoError(t, err)
+	if assert.Equal(t, 6, len(all)) {
+		assert.Equal(t, "OrgPendingApproval", all[0].Event)
+		assert.Equal(t, "NodeProposed", all[1].Event)
+		assert.Equal(t, "", all[0].OldStatus)
+		assert.Equal(t, "PendingApproval", all[2].OldStatus)
+		assert.Equal(t, "Approved", all[2].NewStatus)
+	}
+
+	// who blacklisted the node and when, whichever form of its enode url is used
+	nodeEvents, err := store.Events(PermissionEventFilter{Node: "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@127.0.0.1:21000"})
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(nodeEvents)) {
+		blacklisted := nodeEvents[2]
+		assert.Equal(t, "NodeBlacklisted", blacklisted.Event)
+		assert.Equal(t, account, blacklisted.Actor)
+		assert.Equal(t, uint64(130), blacklisted.Timestamp)
+		assert.Equal(t, "Approved", blacklisted.OldStatus)
+		assert.Equal(t, "Blacklisted", blacklisted.NewStatus)
+	}
+
+	acctEvents, err := store.Events(PermissionEventFilter{Account: &account})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(acctEvents))
+
+	timeEvents, err := store.Events(PermissionEventFilter{OrgId: arbitraryOrgToAdd, FromTime: 110, ToTime: 120})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(timeEvents))
+
+	sinceEvents, err := store.Events(PermissionEventFilter{FromTime: 120})
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(sinceEvents)) {
+		assert.Equal(t, "AccountAccessModified", sinceEvents[0].Event)
+		assert.Equal(t, "Approved", sinceEvents[1].OldStatus)
+	}
+
+	otherOrgEvents, err := store.Events(PermissionEventFilter{OrgId: arbitraryNetworkAdminOrg})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(otherOrgEvents))
+
+	// events of blocks that are no longer canonical are dropped
+	assert.NoError(t, store.Record(&events[5], true))
+	nodeEvents, err = store.Events(PermissionEventFilter{Node: arbitraryNode1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(nodeEvents))
+}
+
+func TestImportPermissions(t *testing.T) {
+	var (
+		nwAdmin   = common.HexToAddress("0x0000000000000000000000000000000000001001")
+		org1Admin = common.HexToAddress("0x0000000000000000000000000000000000001002")
+		org2Admin = common.HexToAddress("0x0000000000000000000000000000000000001003")
+		member1   = common.HexToAddress("0x0000000000000000000000000000000000001004")
+		member2   = common.HexToAddress("0x0000000000000000000000000000000000001005")
+		contract  = common.HexToAddress("0x0000000000000000000000000000000000002001")
+		subOrg    = arbitraryOrgToAdd + "." + arbitrarySubOrg
+	)
+	snapshot := &PermissionSnapshot{
+		NwAdminOrg:    arbitraryNetworkAdminOrg,
+		NwAdminRole:   arbitraryNetworkAdminRole,
+		OrgAdminRole:  arbitraryOrgAdminRole,
+		SubOrgDepth:   big.NewInt(4),
+		SubOrgBreadth: big.NewInt(4),
+		PermissionTree: PermissionTree{
+			Orgs: []types.OrgInfo{
+				{OrgId: arbitraryNetworkAdminOrg, FullOrgId: arbitraryNetworkAdminOrg, UltimateParent: arbitraryNetworkAdminOrg, Level: big.NewInt(1), Status: types.OrgApproved},
+				{OrgId: arbitraryOrgToAdd, FullOrgId: arbitraryOrgToAdd, UltimateParent: arbitraryOrgToAdd, Level: big.NewInt(1), Status: types.OrgApproved},
+				{OrgId: arbitrarySubOrg, FullOrgId: subOrg, ParentOrgId: arbitraryOrgToAdd, UltimateParent: arbitraryOrgToAdd, Level: big.NewInt(2), Status: types.OrgApproved},
+				{OrgId: "ORG2", FullOrgId: "ORG2", UltimateParent: "ORG2", Level: big.NewInt(1), Status: types.OrgSuspended},
+			},
+			Roles: []types.RoleInfo{
+				{OrgId: arbitraryNetworkAdminOrg, RoleId: arbitraryNetworkAdminRole, IsVoter: true, IsAdmin: true, Access: types.FullAccess, Active: true},
+				{OrgId: arbitraryOrgToAdd, RoleId: arbitraryOrgAdminRole, IsVoter: true, IsAdmin: true, Access: types.FullAccess, Active: true},
+				{OrgId: "ORG2", RoleId: arbitraryOrgAdminRole, IsVoter: true, IsAdmin: true, Access: types.FullAccess, Active: true},
+				{OrgId: arbitraryOrgToAdd, RoleId: arbitrartNewRole1, Access: types.Transact, Active: true},
+				{OrgId: subOrg, RoleId: arbitrartNewRole2, Access: types.ReadOnly, Active: false},
+			},
+			Accounts: []types.AccountInfo{
+				{OrgId: arbitraryNetworkAdminOrg, RoleId: arbitraryNetworkAdminRole, AcctId: nwAdmin, IsOrgAdmin: true, Status: types.AcctActive},
+				{OrgId: arbitraryOrgToAdd, RoleId: arbitraryOrgAdminRole, AcctId: org1Admin, IsOrgAdmin: true, Status: types.AcctActive},
+				{OrgId: "ORG2", RoleId: arbitraryOrgAdminRole, AcctId: org2Admin, IsOrgAdmin: true, Status: types.AcctActive},
+				{OrgId: subOrg, RoleId: arbitrartNewRole1, AcctId: member1, Status: types.AcctSuspended},
+				{OrgId: subOrg, RoleId: arbitrartNewRole2, AcctId: member2, Status: types.AcctActive},
+			},
+			Nodes: []types.NodeInfo{
+				{OrgId: arbitraryNetworkAdminOrg, Url: arbitraryNode1, Status: types.NodeApproved},
+				{OrgId: arbitraryOrgToAdd, Url: arbitraryNode2, Status: types.NodeApproved},
+				{OrgId: subOrg, Url: arbitraryNode3, Status: types.NodeDeactivated},
+				{OrgId: "ORG2", Url: arbitraryNode4, Status: types.NodeApproved},
+			},
+			ContractAccess: []types.ContractAccessInfo{
+				{OrgId: arbitraryOrgToAdd, RoleId: arbitrartNewRole1, Contract: contract, Active: true},
+				{OrgId: arbitraryOrgToAdd, RoleId: arbitrartNewRole1, Contract: contract, FunctionSig: types.FunctionSig{0x60, 0xfe, 0x47, 0xb1}, Active: false},
+			},
+		},
+	}
+	genesis := &core.Genesis{Config: params.AllEthashProtocolChanges}
+
+	alloc, permConfig, err := ImportPermissions(snapshot, genesis, guardianAddress)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	assert.Equal(t, []common.Address{nwAdmin}, permConfig.Accounts)
+	assert.NotEqual(t, common.Address{}, permConfig.ContractAccessAddress)
+	assert.NotZero(t, alloc[guardianAddress].Nonce)
+
+	// the network starting from the genesis exports the same tree
+	db := rawdb.NewMemoryDatabase()
+	block := (&core.Genesis{Config: params.AllEthashProtocolChanges, Alloc: alloc}).ToBlock(db)
+	publicState, err := state.New(block.Root(), state.NewDatabase(db))
+	assert.NoError(t, err)
+	exported, err := ExportPermissions(params.AllEthashProtocolChanges, block.Header(), publicState, permConfig)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	assert.Equal(t, snapshot.NwAdminOrg, exported.NwAdminOrg)
+	assert.Equal(t, snapshot.OrgAdminRole, exported.OrgAdminRole)
+	if assert.Equal(t, len(snapshot.Orgs), len(exported.Orgs)) {
+		for _, org := range exported.Orgs {
+			for _, expected := range snapshot.Orgs {
+				if expected.FullOrgId == org.FullOrgId {
+					assert.Equal(t, expected.Status, org.Status, org.FullOrgId)
+					assert.Equal(t, expected.UltimateParent, org.UltimateParent, org.FullOrgId)
+				}
+			}
+		}
+	}
+	assert.Equal(t, len(snapshot.Roles), len(exported.Roles))
+	assert.Subset(t, exported.Roles, snapshot.Roles)
+	assert.Equal(t, len(snapshot.Accounts), len(exported.Accounts))
+	assert.Subset(t, exported.Accounts, snapshot.Accounts)
+	assert.Equal(t, len(snapshot.Nodes), len(exported.Nodes))
+	assert.Subset(t, exported.Nodes, snapshot.Nodes)
+	assert.Equal(t, len(snapshot.ContractAccess), len(exported.ContractAccess))
+	assert.Subset(t, exported.ContractAccess, snapshot.ContractAccess)
+
+	// operations pending approval can't be replayed
+	snapshot.Orgs[3].Status = types.OrgPendingApproval
+	_, _, err = ImportPermissions(snapshot, genesis, guardianAddress)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), types.ErrPendingApprovals.Error())
+	}
+}
+
 func TestPermissionCtrl_whenUpdateFile(t *testing.T) {
 	testObject := typicalPermissionCtrl(t)
 	assert.NoError(t, testObject.AfterStart())
@@ -0,0 +1,425 @@
+package permission
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	pbind "github.com/ethereum/go-ethereum/permission/bind"
+)
+
+// gas made available to every simulated call
+var simulationGas = uint64(50000000)
+
+// selector of the Error(string) revert reason emitted by require statements
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+var (
+	ErrUnknownSimulationOp = errors.New("unknown permission operation")
+	ErrSimulationReverted  = errors.New("operation reverted by the permission contracts")
+)
+
+// SimulationOp is a permission operation to be simulated. Op is the name of
+// the quorumPermission API performing the operation (e.g. addOrg) and From
+// the account sending it. The remaining fields are the arguments of the API.
+type SimulationOp struct {
+	Op          string            `json:"op"`
+	From        common.Address    `json:"from"`
+	OrgId       string            `json:"orgId"`
+	ParentOrgId string            `json:"parentOrgId"`
+	Url         string            `json:"url"`
+	RoleId      string            `json:"roleId"`
+	Account     common.Address    `json:"account"`
+	Access      uint8             `json:"access"`
+	IsVoter     bool              `json:"isVoter"`
+	IsAdmin     bool              `json:"isAdmin"`
+	Action      uint8             `json:"action"`
+	Contract    common.Address    `json:"contract"`
+	FunctionSig types.FunctionSig `json:"funcSig"`
+}
+
+// SimulationOpResult is the outcome of a single simulated operation. Error
+// holds the rule violated by the operation if it was rejected.
+type SimulationOpResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PermissionTree holds the orgs, roles, accounts, nodes and contract access
+// rules of the permission contracts
+type PermissionTree struct {
+	Orgs           []types.OrgInfo            `json:"orgList"`
+	Roles          []types.RoleInfo           `json:"roleList"`
+	Accounts       []types.AccountInfo        `json:"acctList"`
+	Nodes          []types.NodeInfo           `json:"nodeList"`
+	ContractAccess []types.ContractAccessInfo `json:"contractAccessList"`
+}
+
+// SimulationResult holds the outcome of the simulated operations and the
+// permission tree they result in
+type SimulationResult struct {
+	Ops        []SimulationOpResult `json:"ops"`
+	Violations int                  `json:"violations"`
+	PermissionTree
+}
+
+// permissionSimulator applies permission operations to a copy of the
+// state of the permission contracts. It implements bind.ContractCaller so
+// that the contract bindings can read the simulated state. chain may be nil
+// when the state is not part of a chain, e.g. for a genesis being built.
+type permissionSimulator struct {
+	chain  core.ChainContext
+	config *params.ChainConfig
+	header *types.Header
+	state  *state.StateDB
+}
+
+func newPermissionSimulator(chain core.ChainContext, config *params.ChainConfig, header *types.Header, statedb *state.StateDB) *permissionSimulator {
+	return &permissionSimulator{
+		chain:  chain,
+		config: config,
+		header: header,
+		state:  statedb,
+	}
+}
+
+// apply executes a call, or a contract creation if to is nil, against the
+// simulated state, keeping its effects. the permission contracts are public
+// so the calls are executed as public transactions.
+func (s *permissionSimulator) apply(from common.Address, to *common.Address, data []byte) ([]byte, error) {
+	var author *common.Address
+	if s.chain == nil {
+		author = &s.header.Coinbase
+	}
+	msg := types.NewMessage(from, to, 0, new(big.Int), simulationGas, new(big.Int), data, false)
+	evmContext := core.NewEVMContext(msg, s.header, s.chain, author)
+	vmenv := vm.NewEVM(evmContext, s.state, s.state, s.config, vm.Config{})
+	ret, _, failed, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+	if failed {
+		return nil, revertError(ret)
+	}
+	return ret, nil
+}
+
+// deploy creates a contract from the given code and constructor arguments
+// and returns its address
+func (s *permissionSimulator) deploy(from common.Address, bin string, args []byte) (common.Address, error) {
+	address := crypto.CreateAddress(from, s.state.GetNonce(from))
+	if _, err := s.apply(from, nil, append(common.FromHex(bin), args...)); err != nil {
+		return common.Address{}, err
+	}
+	return address, nil
+}
+
+func (s *permissionSimulator) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return s.state.GetCode(contract), nil
+}
+
+func (s *permissionSimulator) CallContract(ctx context.Context, call goethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	snapshot := s.state.Snapshot()
+	defer s.state.RevertToSnapshot(snapshot)
+	return s.apply(call.From, call.To, call.Data)
+}
+
+// revertError returns the reason given by a reverted contract call
+func revertError(ret []byte) error {
+	if len(ret) < 4 || !bytes.Equal(ret[:4], revertSelector) {
+		return ErrSimulationReverted
+	}
+	typ, _ := abi.NewType("string", "", nil)
+	var reason string
+	if err := (abi.Arguments{{Type: typ}}).Unpack(&reason, ret[4:]); err != nil {
+		return ErrSimulationReverted
+	}
+	return errors.New(reason)
+}
+
+// packPermissionOp returns the contract and the call data performing the
+// given operation on the permission contracts of config
+func packPermissionOp(config *types.PermissionConfig, op SimulationOp) (common.Address, []byte, error) {
+	switch op.Op {
+	case "addContractAccess", "removeContractAccess":
+		if config.ContractAccessAddress == (common.Address{}) {
+			return common.Address{}, nil, types.ErrContractAccessDisabled
+		}
+		caAbi, err := abi.JSON(strings.NewReader(pbind.ContractAccessManagerABI))
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		data, err := caAbi.Pack(op.Op, op.RoleId, op.OrgId, op.Contract, [4]byte(op.FunctionSig))
+		return config.ContractAccessAddress, data, err
+	}
+
+	interfAbi, err := abi.JSON(strings.NewReader(pbind.PermInterfaceABI))
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	var data []byte
+	switch op.Op {
+	case "addOrg":
+		data, err = interfAbi.Pack("addOrg", op.OrgId, op.Url, op.Account)
+	case "approveOrg":
+		data, err = interfAbi.Pack("approveOrg", op.OrgId, op.Url, op.Account)
+	case "addSubOrg":
+		data, err = interfAbi.Pack("addSubOrg", op.ParentOrgId, op.OrgId, op.Url)
+	case "updateOrgStatus":
+		data, err = interfAbi.Pack("updateOrgStatus", op.OrgId, big.NewInt(int64(op.Action)))
+	case "approveOrgStatus":
+		data, err = interfAbi.Pack("approveOrgStatus", op.OrgId, big.NewInt(int64(op.Action)))
+	case "addNode":
+		data, err = interfAbi.Pack("addNode", op.OrgId, op.Url)
+	case "updateNodeStatus":
+		data, err = interfAbi.Pack("updateNodeStatus", op.OrgId, op.Url, big.NewInt(int64(op.Action)))
+	case "assignAdminRole":
+		data, err = interfAbi.Pack("assignAdminRole", op.OrgId, op.Account, op.RoleId)
+	case "approveAdminRole":
+		data, err = interfAbi.Pack("approveAdminRole", op.OrgId, op.Account)
+	case "addNewRole":
+		data, err = interfAbi.Pack("addNewRole", op.RoleId, op.OrgId, big.NewInt(int64(op.Access)), op.IsVoter, op.IsAdmin)
+	case "removeRole":
+		data, err = interfAbi.Pack("removeRole", op.RoleId, op.OrgId)
+	case "addAccountToOrg", "changeAccountRole":
+		data, err = interfAbi.Pack("assignAccountRole", op.Account, op.OrgId, op.RoleId)
+	case "updateAccountStatus":
+		data, err = interfAbi.Pack("updateAccountStatus", op.OrgId, op.Account, big.NewInt(int64(op.Action)))
+	case "recoverBlackListedNode":
+		data, err = interfAbi.Pack("startBlacklistedNodeRecovery", op.OrgId, op.Url)
+	case "approveBlackListedNodeRecovery":
+		data, err = interfAbi.Pack("approveBlacklistedNodeRecovery", op.OrgId, op.Url)
+	case "recoverBlackListedAccount":
+		data, err = interfAbi.Pack("startBlacklistedAccountRecovery", op.OrgId, op.Account)
+	case "approveBlackListedAccountRecovery":
+		data, err = interfAbi.Pack("approveBlacklistedAccountRecovery", op.OrgId, op.Account)
+	default:
+		err = ErrUnknownSimulationOp
+	}
+	return config.InterfAddress, data, err
+}
+
+// validatePermissionOp runs the checks the quorumPermission APIs make
+// before sending the given operation, against the permission data in caches
+func validatePermissionOp(config *types.PermissionConfig, caches *permissionCaches, op SimulationOp) error {
+	switch op.Op {
+	case "addOrg", "addNode":
+		if op.Url == "" {
+			return types.ErrInvalidInput
+		}
+		return valNodeDetails(caches.nodes, op.Url)
+	case "addSubOrg":
+		return valNodeDetails(caches.nodes, op.Url)
+	case "updateOrgStatus":
+		if OrgUpdateAction(op.Action) != SuspendOrg && OrgUpdateAction(op.Action) != ActivateSuspendedOrg {
+			return types.ErrOpNotAllowed
+		}
+		if op.OrgId == config.NwAdminOrg {
+			return types.ErrOpNotAllowed
+		}
+		return checkOrgStatus(caches.orgs, op.OrgId, op.Action)
+	case "updateNodeStatus":
+		return valNodeStatusChange(caches.nodes, op.OrgId, op.Url, NodeUpdateAction(op.Action), UpdateNodeStatus)
+	case "recoverBlackListedNode":
+		return valNodeStatusChange(caches.nodes, op.OrgId, op.Url, RecoverBlacklistedNode, InitiateNodeRecovery)
+	case "approveBlackListedNodeRecovery":
+		return valNodeStatusChange(caches.nodes, op.OrgId, op.Url, ApproveBlacklistedNodeRecovery, ApproveNodeRecovery)
+	case "updateAccountStatus":
+		return valAccountStatusChange(config, caches.accounts, op.OrgId, op.Account, UpdateAccountStatus, AccountUpdateAction(op.Action))
+	case "recoverBlackListedAccount":
+		return valAccountStatusChange(config, caches.accounts, op.OrgId, op.Account, InitiateAccountRecovery, RecoverBlacklistedAccount)
+	case "approveBlackListedAccountRecovery":
+		return valAccountStatusChange(config, caches.accounts, op.OrgId, op.Account, ApproveAccountRecovery, ApproveBlacklistedAccountRecovery)
+	}
+	return nil
+}
+
+// simulate applies the given operations in order to a copy of the current
+// state of the permission contracts. every operation is first checked as
+// the quorumPermission APIs would check it, against the simulated state.
+// an operation failing these checks or rejected by the contracts is
+// reported as a violation and does not stop the simulation. nothing is
+// sent to the network.
+func (p *PermissionCtrl) simulate(ops []SimulationOp) (*SimulationResult, error) {
+	chain := p.eth.BlockChain()
+	publicState, _, err := chain.State()
+	if err != nil {
+		return nil, err
+	}
+	sim := newPermissionSimulator(chain, chain.Config(), chain.CurrentBlock().Header(), publicState)
+	caches, err := readPermissionCaches(p.permConfig, sim)
+	if err != nil {
+		return nil, err
+	}
+	result := &SimulationResult{Ops: make([]SimulationOpResult, len(ops))}
+	for i, op := range ops {
+		opResult := SimulationOpResult{Index: i, Op: op.Op}
+		err := validatePermissionOp(p.permConfig, caches, op)
+		if err == nil {
+			var (
+				to   common.Address
+				data []byte
+			)
+			if to, data, err = packPermissionOp(p.permConfig, op); err == nil {
+				_, err = sim.apply(op.From, &to, data)
+			}
+		}
+		if err != nil {
+			opResult.Error = err.Error()
+			result.Violations++
+		} else {
+			opResult.Success = true
+			// the following operations are checked against the new state
+			if caches, err = readPermissionCaches(p.permConfig, sim); err != nil {
+				return nil, err
+			}
+		}
+		result.Ops[i] = opResult
+	}
+	result.PermissionTree = *caches.tree()
+	return result, nil
+}
+
+// permissionCaches holds the permission data read from the permission
+// contracts
+type permissionCaches struct {
+	orgs           *types.OrgCache
+	roles          *types.RoleCache
+	accounts       *types.AcctCache
+	nodes          *types.NodeCache
+	contractAccess *types.ContractAccessCache
+}
+
+func (c *permissionCaches) tree() *PermissionTree {
+	return &PermissionTree{
+		Orgs:           c.orgs.GetOrgList(),
+		Roles:          c.roles.GetRoleList(),
+		Accounts:       c.accounts.GetAcctList(),
+		Nodes:          c.nodes.GetNodeList(),
+		ContractAccess: c.contractAccess.GetRuleList(),
+	}
+}
+
+// readPermissionTree reads the org, role, account, node and contract access
+// lists from the permission contracts of config
+func readPermissionTree(config *types.PermissionConfig, caller bind.ContractCaller) (*PermissionTree, error) {
+	caches, err := readPermissionCaches(config, caller)
+	if err != nil {
+		return nil, err
+	}
+	return caches.tree(), nil
+}
+
+// readPermissionCaches reads the orgs, roles, accounts, nodes and contract
+// access rules of the permission contracts of config
+func readPermissionCaches(config *types.PermissionConfig, caller bind.ContractCaller) (*permissionCaches, error) {
+	opts := &bind.CallOpts{}
+	caches := &permissionCaches{contractAccess: types.NewContractAccessCache()}
+
+	orgMgr, err := pbind.NewOrgManagerCaller(config.OrgAddress, caller)
+	if err != nil {
+		return nil, err
+	}
+	numberOfOrgs, err := orgMgr.GetNumberOfOrgs(opts)
+	if err != nil {
+		return nil, err
+	}
+	orgs := types.NewOrgCache(int(numberOfOrgs.Int64()) + 1)
+	for k := int64(0); k < numberOfOrgs.Int64(); k++ {
+		orgId, porgId, ultParent, level, status, err := orgMgr.GetOrgInfo(opts, big.NewInt(k))
+		if err != nil {
+			return nil, err
+		}
+		orgs.UpsertOrg(orgId, porgId, ultParent, level, types.OrgStatus(int(status.Int64())))
+	}
+	caches.orgs = orgs
+
+	roleMgr, err := pbind.NewRoleManagerCaller(config.RoleAddress, caller)
+	if err != nil {
+		return nil, err
+	}
+	numberOfRoles, err := roleMgr.GetNumberOfRoles(opts)
+	if err != nil {
+		return nil, err
+	}
+	roles := types.NewRoleCache(int(numberOfRoles.Int64()) + 1)
+	for k := int64(0); k < numberOfRoles.Int64(); k++ {
+		roleStruct, err := roleMgr.GetRoleDetailsFromIndex(opts, big.NewInt(k))
+		if err != nil {
+			return nil, err
+		}
+		roles.UpsertRole(roleStruct.OrgId, roleStruct.RoleId, roleStruct.Voter, roleStruct.Admin, types.AccessType(int(roleStruct.AccessType.Int64())), roleStruct.Active)
+	}
+	caches.roles = roles
+
+	acctMgr, err := pbind.NewAcctManagerCaller(config.AccountAddress, caller)
+	if err != nil {
+		return nil, err
+	}
+	numberOfAccounts, err := acctMgr.GetNumberOfAccounts(opts)
+	if err != nil {
+		return nil, err
+	}
+	accounts := types.NewAcctCache(int(numberOfAccounts.Int64()) + 1)
+	for k := int64(0); k < numberOfAccounts.Int64(); k++ {
+		addr, org, role, status, orgAdmin, err := acctMgr.GetAccountDetailsFromIndex(opts, big.NewInt(k))
+		if err != nil {
+			return nil, err
+		}
+		accounts.UpsertAccount(org, role, addr, orgAdmin, types.AcctStatus(int(status.Int64())))
+	}
+	caches.accounts = accounts
+
+	nodeMgr, err := pbind.NewNodeManagerCaller(config.NodeAddress, caller)
+	if err != nil {
+		return nil, err
+	}
+	numberOfNodes, err := nodeMgr.GetNumberOfNodes(opts)
+	if err != nil {
+		return nil, err
+	}
+	nodes := types.NewNodeCache(int(numberOfNodes.Int64()) + 1)
+	for k := int64(0); k < numberOfNodes.Int64(); k++ {
+		nodeStruct, err := nodeMgr.GetNodeDetailsFromIndex(opts, big.NewInt(k))
+		if err != nil {
+			return nil, err
+		}
+		nodes.UpsertNode(nodeStruct.OrgId, nodeStruct.EnodeId, types.NodeStatus(int(nodeStruct.NodeStatus.Int64())))
+	}
+	caches.nodes = nodes
+
+	if config.ContractAccessAddress == (common.Address{}) {
+		return caches, nil
+	}
+	caMgr, err := pbind.NewContractAccessManagerCaller(config.ContractAccessAddress, caller)
+	if err != nil {
+		return nil, err
+	}
+	numberOfRules, err := caMgr.GetNumberOfRules(opts)
+	if err != nil {
+		return nil, err
+	}
+	for k := int64(0); k < numberOfRules.Int64(); k++ {
+		rule, err := caMgr.GetRuleFromIndex(opts, big.NewInt(k))
+		if err != nil {
+			return nil, err
+		}
+		caches.contractAccess.UpsertRule(rule.OrgId, rule.RoleId, rule.Contract, types.FunctionSig(rule.FunctionSig), rule.Active)
+	}
+	return caches, nil
+}
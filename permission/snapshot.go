@@ -0,0 +1,594 @@
+package permission
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	pbind "github.com/ethereum/go-ethereum/permission/bind"
+)
+
+var (
+	ErrNetworkNotBooted = errors.New("permission network is not booted up")
+	ErrNoNetworkAdmin   = errors.New("no active network admin account in the permission snapshot")
+)
+
+// PermissionSnapshot is the permission tree of a network at a block along
+// with the policy the network was set up with. It is written by geth
+// permission export and loaded into the genesis of a new network by geth
+// permission import.
+type PermissionSnapshot struct {
+	BlockNumber   uint64   `json:"blockNumber"`
+	NwAdminOrg    string   `json:"nwAdminOrg"`
+	NwAdminRole   string   `json:"nwAdminRole"`
+	OrgAdminRole  string   `json:"orgAdminRole"`
+	SubOrgDepth   *big.Int `json:"subOrgDepth"`
+	SubOrgBreadth *big.Int `json:"subOrgBreadth"`
+	PermissionTree
+}
+
+// ExportPermissions reads the permission tree held by the permission
+// contracts of permConfig in the public state of the given block
+func ExportPermissions(config *params.ChainConfig, header *types.Header, publicState *state.StateDB, permConfig *types.PermissionConfig) (*PermissionSnapshot, error) {
+	sim := newPermissionSimulator(nil, config, header, publicState)
+	opts := &bind.CallOpts{}
+
+	upgr, err := pbind.NewPermUpgrCaller(permConfig.UpgrdAddress, sim)
+	if err != nil {
+		return nil, err
+	}
+	implAddress, err := upgr.GetPermImpl(opts)
+	if err != nil {
+		return nil, err
+	}
+	impl, err := pbind.NewPermImplCaller(implAddress, sim)
+	if err != nil {
+		return nil, err
+	}
+	nwAdminOrg, nwAdminRole, orgAdminRole, networkBoot, err := impl.GetPolicyDetails(opts)
+	if err != nil {
+		return nil, err
+	}
+	if !networkBoot {
+		return nil, ErrNetworkNotBooted
+	}
+	tree, err := readPermissionTree(permConfig, sim)
+	if err != nil {
+		return nil, err
+	}
+	return &PermissionSnapshot{
+		BlockNumber:    header.Number.Uint64(),
+		NwAdminOrg:     nwAdminOrg,
+		NwAdminRole:    nwAdminRole,
+		OrgAdminRole:   orgAdminRole,
+		SubOrgDepth:    permConfig.SubOrgDepth,
+		SubOrgBreadth:  permConfig.SubOrgBreadth,
+		PermissionTree: *tree,
+	}, nil
+}
+
+// ImportPermissions deploys the permission contracts on top of the genesis
+// and replays the permission tree of the snapshot into them, the same way the
+// network admins and org admins would. It returns the genesis allocation of
+// the contracts, which has the network booted up, and the permission config
+// pointing at them. The contracts are deployed from guardian, which becomes
+// the guardian of the upgradable contract.
+func ImportPermissions(snapshot *PermissionSnapshot, genesis *core.Genesis, guardian common.Address) (core.GenesisAlloc, *types.PermissionConfig, error) {
+	if err := checkPermissionSnapshot(snapshot); err != nil {
+		return nil, nil, err
+	}
+	db := rawdb.NewMemoryDatabase()
+	block := genesis.ToBlock(db)
+	statedb, err := state.New(block.Root(), state.NewDatabase(db))
+	if err != nil {
+		return nil, nil, err
+	}
+	imp := &permissionImporter{
+		sim:       newPermissionSimulator(nil, genesis.Config, block.Header(), statedb),
+		snapshot:  snapshot,
+		guardian:  guardian,
+		orgs:      make(map[string]types.OrgInfo),
+		orgAdmins: make(map[string]common.Address),
+		nodes:     make(map[string]bool),
+	}
+	if imp.interfAbi, err = abi.JSON(strings.NewReader(pbind.PermInterfaceABI)); err != nil {
+		return nil, nil, err
+	}
+	if imp.caAbi, err = abi.JSON(strings.NewReader(pbind.ContractAccessManagerABI)); err != nil {
+		return nil, nil, err
+	}
+	for _, acct := range snapshot.Accounts {
+		if acct.OrgId == snapshot.NwAdminOrg && acct.RoleId == snapshot.NwAdminRole && acct.Status == types.AcctActive {
+			imp.nwAdmins = append(imp.nwAdmins, acct.AcctId)
+		}
+	}
+	if len(imp.nwAdmins) == 0 {
+		return nil, nil, ErrNoNetworkAdmin
+	}
+	for _, org := range snapshot.Orgs {
+		imp.orgs[org.FullOrgId] = org
+	}
+
+	for _, step := range []func() error{
+		imp.deployContracts,
+		imp.bootupNetwork,
+		imp.importOrgs,
+		imp.importRoles,
+		imp.importAccounts,
+		imp.importNodes,
+		imp.importContractAccess,
+		imp.importStatuses,
+	} {
+		if err := step(); err != nil {
+			return nil, nil, err
+		}
+	}
+	alloc, err := imp.genesisAlloc(genesis)
+	if err != nil {
+		return nil, nil, err
+	}
+	return alloc, imp.config, nil
+}
+
+// checkPermissionSnapshot makes sure that the snapshot can be replayed into
+// new contracts. Operations pending approval, including recoveries of
+// blacklisted nodes and accounts, must be completed or rejected before the
+// export.
+func checkPermissionSnapshot(snapshot *PermissionSnapshot) error {
+	if snapshot.NwAdminOrg == "" || snapshot.NwAdminRole == "" || snapshot.OrgAdminRole == "" {
+		return errors.New("permission snapshot has no network admin org, network admin role or org admin role")
+	}
+	if snapshot.SubOrgDepth == nil || snapshot.SubOrgBreadth == nil {
+		return errors.New("permission snapshot has no sub org depth or breadth")
+	}
+	for _, org := range snapshot.Orgs {
+		if org.Status != types.OrgApproved && org.Status != types.OrgSuspended {
+			return fmt.Errorf("%v: org %s", types.ErrPendingApprovals, org.FullOrgId)
+		}
+	}
+	for _, node := range snapshot.Nodes {
+		if node.Status == types.NodePendingApproval || node.Status == types.NodeRecoveryInitiated {
+			return fmt.Errorf("%v: node %s of org %s", types.ErrPendingApprovals, node.Url, node.OrgId)
+		}
+	}
+	for _, acct := range snapshot.Accounts {
+		switch acct.Status {
+		case types.AcctActive, types.AcctSuspended, types.AcctBlacklisted:
+		case types.AdminRevoked:
+			if acct.RoleId != snapshot.OrgAdminRole {
+				return fmt.Errorf("revoked %s account %s of org %s cannot be imported", acct.RoleId, acct.AcctId.Hex(), acct.OrgId)
+			}
+		case types.AcctPendingApproval, types.AcctRecoveryInitiated:
+			return fmt.Errorf("%v: account %s of org %s", types.ErrPendingApprovals, acct.AcctId.Hex(), acct.OrgId)
+		default:
+			return fmt.Errorf("account %s of org %s has status %d which cannot be imported", acct.AcctId.Hex(), acct.OrgId, acct.Status)
+		}
+	}
+	return nil
+}
+
+// permissionImporter replays a permission snapshot into freshly deployed
+// permission contracts
+type permissionImporter struct {
+	sim       *permissionSimulator
+	snapshot  *PermissionSnapshot
+	guardian  common.Address
+	config    *types.PermissionConfig
+	interfAbi abi.ABI
+	caAbi     abi.ABI
+
+	nwAdmins  []common.Address          // active network admin accounts, the voters
+	orgs      map[string]types.OrgInfo  // orgs of the snapshot by full org id
+	orgAdmins map[string]common.Address // org admin of every master org
+	nodes     map[string]bool           // nodes already added
+}
+
+// transact sends a call to a permission contract and fails if it is
+// rejected
+func (imp *permissionImporter) transact(from, to common.Address, contractAbi abi.ABI, method string, args ...interface{}) error {
+	data, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	if _, err := imp.sim.apply(from, &to, data); err != nil {
+		return fmt.Errorf("permission import failed at %s%v: %v", method, args, err)
+	}
+	return nil
+}
+
+func (imp *permissionImporter) interf(from common.Address, method string, args ...interface{}) error {
+	return imp.transact(from, imp.config.InterfAddress, imp.interfAbi, method, args...)
+}
+
+// vote approves the pending operation by the network admins until the
+// majority is reached
+func (imp *permissionImporter) vote(method string, args ...interface{}) error {
+	interf, err := pbind.NewPermInterfaceCaller(imp.config.InterfAddress, imp.sim)
+	if err != nil {
+		return err
+	}
+	for _, admin := range imp.nwAdmins {
+		if err := imp.interf(admin, method, args...); err != nil {
+			return err
+		}
+		_, _, _, opType, err := interf.GetPendingOp(&bind.CallOpts{}, imp.snapshot.NwAdminOrg)
+		if err != nil {
+			return err
+		}
+		if opType.Sign() == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("permission import failed at %s%v: not approved by the network admins", method, args)
+}
+
+// adminOf returns an account allowed to perform the org admin operations of
+// the given org, i.e. the org admin of its master org
+func (imp *permissionImporter) adminOf(orgId string) (common.Address, error) {
+	ultParent := orgId
+	if org, ok := imp.orgs[orgId]; ok {
+		ultParent = org.UltimateParent
+	}
+	if ultParent == imp.snapshot.NwAdminOrg {
+		return imp.nwAdmins[0], nil
+	}
+	if admin, ok := imp.orgAdmins[ultParent]; ok {
+		return admin, nil
+	}
+	return common.Address{}, fmt.Errorf("org %s has no active org admin", ultParent)
+}
+
+func (imp *permissionImporter) deploy(contractAbi, bin string, params ...interface{}) (common.Address, error) {
+	parsed, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		return common.Address{}, err
+	}
+	args, err := parsed.Pack("", params...)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return imp.sim.deploy(imp.guardian, bin, args)
+}
+
+// deployContracts deploys the permission contracts and links them through
+// the upgradable contract. the contract access manager is only deployed if
+// the snapshot has contract access rules.
+func (imp *permissionImporter) deployContracts() error {
+	var err error
+	config := &types.PermissionConfig{
+		NwAdminOrg:    imp.snapshot.NwAdminOrg,
+		NwAdminRole:   imp.snapshot.NwAdminRole,
+		OrgAdminRole:  imp.snapshot.OrgAdminRole,
+		Accounts:      imp.nwAdmins,
+		SubOrgDepth:   imp.snapshot.SubOrgDepth,
+		SubOrgBreadth: imp.snapshot.SubOrgBreadth,
+	}
+	if config.UpgrdAddress, err = imp.deploy(pbind.PermUpgrABI, pbind.PermUpgrBin, imp.guardian); err != nil {
+		return err
+	}
+	for _, c := range []struct {
+		address  *common.Address
+		abi, bin string
+	}{
+		{&config.InterfAddress, pbind.PermInterfaceABI, pbind.PermInterfaceBin},
+		{&config.NodeAddress, pbind.NodeManagerABI, pbind.NodeManagerBin},
+		{&config.RoleAddress, pbind.RoleManagerABI, pbind.RoleManagerBin},
+		{&config.AccountAddress, pbind.AcctManagerABI, pbind.AcctManagerBin},
+		{&config.OrgAddress, pbind.OrgManagerABI, pbind.OrgManagerBin},
+		{&config.VoterAddress, pbind.VoterManagerABI, pbind.VoterManagerBin},
+	} {
+		if *c.address, err = imp.deploy(c.abi, c.bin, config.UpgrdAddress); err != nil {
+			return err
+		}
+	}
+	if config.ImplAddress, err = imp.deploy(pbind.PermImplABI, pbind.PermImplBin, config.UpgrdAddress, config.OrgAddress,
+		config.RoleAddress, config.AccountAddress, config.VoterAddress, config.NodeAddress); err != nil {
+		return err
+	}
+	if len(imp.snapshot.ContractAccess) > 0 {
+		if config.ContractAccessAddress, err = imp.deploy(pbind.ContractAccessManagerABI, pbind.ContractAccessManagerBin, config.UpgrdAddress); err != nil {
+			return err
+		}
+	}
+	upgrAbi, err := abi.JSON(strings.NewReader(pbind.PermUpgrABI))
+	if err != nil {
+		return err
+	}
+	imp.config = config
+	return imp.transact(imp.guardian, config.UpgrdAddress, upgrAbi, "init", config.InterfAddress, config.ImplAddress)
+}
+
+// bootupNetwork sets up the network admin org with its nodes and network
+// admin accounts, as done by the permission service of the first node
+func (imp *permissionImporter) bootupNetwork() error {
+	if err := imp.interf(imp.guardian, "setPolicy", imp.snapshot.NwAdminOrg, imp.snapshot.NwAdminRole, imp.snapshot.OrgAdminRole); err != nil {
+		return err
+	}
+	if err := imp.interf(imp.guardian, "init", imp.snapshot.SubOrgBreadth, imp.snapshot.SubOrgDepth); err != nil {
+		return err
+	}
+	for _, node := range imp.snapshot.Nodes {
+		if node.OrgId == imp.snapshot.NwAdminOrg {
+			if err := imp.interf(imp.guardian, "addAdminNode", node.Url); err != nil {
+				return err
+			}
+			imp.nodes[node.Url] = true
+		}
+	}
+	for _, admin := range imp.nwAdmins {
+		if err := imp.interf(imp.guardian, "addAdminAccount", admin); err != nil {
+			return err
+		}
+	}
+	return imp.interf(imp.guardian, "updateNetworkBootStatus")
+}
+
+// importOrgs creates the orgs, parents first, along with their org admins.
+// revoked org admins are assigned first so that the current org admin
+// replaces them.
+func (imp *permissionImporter) importOrgs() error {
+	orgs := make([]types.OrgInfo, len(imp.snapshot.Orgs))
+	copy(orgs, imp.snapshot.Orgs)
+	sort.SliceStable(orgs, func(i, j int) bool { return orgs[i].Level.Cmp(orgs[j].Level) < 0 })
+
+	for _, org := range orgs {
+		var admins []types.AccountInfo
+		for _, acct := range imp.snapshot.Accounts {
+			if acct.OrgId == org.FullOrgId && acct.RoleId == imp.snapshot.OrgAdminRole {
+				if acct.Status == types.AdminRevoked {
+					admins = append([]types.AccountInfo{acct}, admins...)
+				} else {
+					admins = append(admins, acct)
+				}
+			}
+		}
+
+		switch {
+		case org.FullOrgId == imp.snapshot.NwAdminOrg:
+		case org.ParentOrgId == "":
+			if len(admins) == 0 {
+				return fmt.Errorf("org %s has no org admin", org.FullOrgId)
+			}
+			url := ""
+			for _, node := range imp.snapshot.Nodes {
+				if node.OrgId == org.FullOrgId {
+					url = node.Url
+					break
+				}
+			}
+			if url == "" {
+				return fmt.Errorf("org %s has no node", org.FullOrgId)
+			}
+			if err := imp.interf(imp.nwAdmins[0], "addOrg", org.FullOrgId, url, admins[0].AcctId); err != nil {
+				return err
+			}
+			if err := imp.vote("approveOrg", org.FullOrgId, url, admins[0].AcctId); err != nil {
+				return err
+			}
+			imp.nodes[url] = true
+			imp.orgAdmins[org.FullOrgId] = admins[0].AcctId
+			admins = admins[1:]
+		default:
+			from, err := imp.adminOf(org.FullOrgId)
+			if err != nil {
+				return err
+			}
+			if err := imp.interf(from, "addSubOrg", org.ParentOrgId, org.OrgId, ""); err != nil {
+				return err
+			}
+		}
+
+		for _, admin := range admins {
+			if err := imp.interf(imp.nwAdmins[0], "assignAdminRole", org.FullOrgId, admin.AcctId, imp.snapshot.OrgAdminRole); err != nil {
+				return err
+			}
+			if err := imp.vote("approveAdminRole", org.FullOrgId, admin.AcctId); err != nil {
+				return err
+			}
+			if org.ParentOrgId == "" {
+				imp.orgAdmins[org.FullOrgId] = admin.AcctId
+			}
+		}
+	}
+	return nil
+}
+
+// importRoles creates the roles. the network admin role and the org admin
+// roles of the master orgs were created along with their orgs. removed
+// roles are removed once the accounts and contract access rules using them
+// are imported.
+func (imp *permissionImporter) importRoles() error {
+	for _, role := range imp.snapshot.Roles {
+		if role.OrgId == imp.snapshot.NwAdminOrg && role.RoleId == imp.snapshot.NwAdminRole {
+			continue
+		}
+		if org, ok := imp.orgs[role.OrgId]; ok && org.ParentOrgId == "" && org.FullOrgId != imp.snapshot.NwAdminOrg && role.RoleId == imp.snapshot.OrgAdminRole {
+			continue
+		}
+		from, err := imp.adminOf(role.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := imp.interf(from, "addNewRole", role.RoleId, role.OrgId, big.NewInt(int64(role.Access)), role.IsVoter, role.IsAdmin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importAccounts assigns their role to the accounts which are not network
+// admins or org admins
+func (imp *permissionImporter) importAccounts() error {
+	for _, acct := range imp.snapshot.Accounts {
+		if acct.RoleId == imp.snapshot.OrgAdminRole || (acct.OrgId == imp.snapshot.NwAdminOrg && acct.RoleId == imp.snapshot.NwAdminRole) {
+			continue
+		}
+		from, err := imp.adminOf(acct.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := imp.interf(from, "assignAccountRole", acct.AcctId, acct.OrgId, acct.RoleId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importNodes adds the nodes not added along with their orgs
+func (imp *permissionImporter) importNodes() error {
+	for _, node := range imp.snapshot.Nodes {
+		if imp.nodes[node.Url] {
+			continue
+		}
+		from, err := imp.adminOf(node.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := imp.interf(from, "addNode", node.OrgId, node.Url); err != nil {
+			return err
+		}
+		imp.nodes[node.Url] = true
+	}
+	return nil
+}
+
+// importContractAccess creates the contract access rules, revoking the ones
+// which are no longer active
+func (imp *permissionImporter) importContractAccess() error {
+	for _, rule := range imp.snapshot.ContractAccess {
+		from, err := imp.adminOf(rule.OrgId)
+		if err != nil {
+			return err
+		}
+		methods := []string{"addContractAccess"}
+		if !rule.Active {
+			methods = append(methods, "removeContractAccess")
+		}
+		for _, method := range methods {
+			if err := imp.transact(from, imp.config.ContractAccessAddress, imp.caAbi, method, rule.RoleId, rule.OrgId, rule.Contract, [4]byte(rule.FunctionSig)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importStatuses removes the inactive roles and suspends, deactivates or
+// blacklists accounts, nodes and orgs. orgs are suspended last as the org
+// admin operations require the org to be approved.
+func (imp *permissionImporter) importStatuses() error {
+	for _, role := range imp.snapshot.Roles {
+		if role.Active {
+			continue
+		}
+		from, err := imp.adminOf(role.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := imp.interf(from, "removeRole", role.RoleId, role.OrgId); err != nil {
+			return err
+		}
+	}
+	for _, acct := range imp.snapshot.Accounts {
+		var action int64
+		switch acct.Status {
+		case types.AcctSuspended:
+			action = 1
+		case types.AcctBlacklisted:
+			action = 3
+		default:
+			continue
+		}
+		from, err := imp.adminOf(acct.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := imp.interf(from, "updateAccountStatus", acct.OrgId, acct.AcctId, big.NewInt(action)); err != nil {
+			return err
+		}
+	}
+	for _, node := range imp.snapshot.Nodes {
+		var action int64
+		switch node.Status {
+		case types.NodeDeactivated:
+			action = 1
+		case types.NodeBlackListed:
+			action = 3
+		default:
+			continue
+		}
+		from, err := imp.adminOf(node.OrgId)
+		if err != nil {
+			return err
+		}
+		if err := imp.interf(from, "updateNodeStatus", node.OrgId, node.Url, big.NewInt(action)); err != nil {
+			return err
+		}
+	}
+	for _, org := range imp.snapshot.Orgs {
+		if org.Status != types.OrgSuspended {
+			continue
+		}
+		if err := imp.interf(imp.nwAdmins[0], "updateOrgStatus", org.FullOrgId, big.NewInt(1)); err != nil {
+			return err
+		}
+		if err := imp.vote("approveOrgStatus", org.FullOrgId, big.NewInt(1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// genesisAlloc returns the code and storage of the permission contracts.
+// the nonce of the guardian is kept so that its own contracts are not
+// deployed at the addresses of the permission contracts.
+func (imp *permissionImporter) genesisAlloc(genesis *core.Genesis) (core.GenesisAlloc, error) {
+	root, err := imp.sim.state.Commit(true)
+	if err != nil {
+		return nil, err
+	}
+	statedb, err := state.New(root, imp.sim.state.Database())
+	if err != nil {
+		return nil, err
+	}
+	contracts := []common.Address{imp.config.UpgrdAddress, imp.config.InterfAddress, imp.config.ImplAddress,
+		imp.config.NodeAddress, imp.config.AccountAddress, imp.config.RoleAddress, imp.config.VoterAddress, imp.config.OrgAddress}
+	if imp.config.ContractAccessAddress != (common.Address{}) {
+		contracts = append(contracts, imp.config.ContractAccessAddress)
+	}
+
+	alloc := make(core.GenesisAlloc)
+	for _, addr := range contracts {
+		account := core.GenesisAccount{
+			Code:    statedb.GetCode(addr),
+			Storage: make(map[common.Hash]common.Hash),
+			Balance: statedb.GetBalance(addr),
+			Nonce:   statedb.GetNonce(addr),
+		}
+		err := statedb.ForEachStorage(addr, func(key, value common.Hash) bool {
+			account.Storage[key] = value
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		alloc[addr] = account
+	}
+	guardian, ok := genesis.Alloc[imp.guardian]
+	if !ok {
+		guardian.Balance = new(big.Int)
+	}
+	guardian.Nonce = statedb.GetNonce(imp.guardian)
+	alloc[imp.guardian] = guardian
+	return alloc, nil
+}
@@ -186,6 +186,9 @@ func (bp *basePlugin) init() error {
 }
 
 func (bp *basePlugin) dispense(name string) (interface{}, error) {
+	if bp.client == nil {
+		return nil, fmt.Errorf("plugin [%s] is not started", bp.pluginInterface)
+	}
 	rpcClient, err := bp.client.Client()
 	if err != nil {
 		return nil, err
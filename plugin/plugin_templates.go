@@ -2,10 +2,12 @@ package plugin
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/plugin/account"
 	"github.com/ethereum/go-ethereum/plugin/helloworld"
+	"github.com/ethereum/go-ethereum/plugin/privacy"
 	"github.com/ethereum/go-ethereum/plugin/security"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -96,3 +98,22 @@ func (f *ReloadableAccountServiceFactory) Create() (account.Service, error) {
 
 	return am, nil
 }
+
+// a template that returns the private transaction manager implemented by the plugin
+type PrivacyPluginTemplate struct {
+	*basePlugin
+}
+
+// Get returns the private transaction manager, its calls to the plugin being
+// cancelled after timeout. Zero means privacy.DefaultTimeout is used.
+func (p *PrivacyPluginTemplate) Get(timeout time.Duration) (privacy.PrivateTransactionManager, error) {
+	return &privacy.ReloadablePrivateTransactionManager{
+		DeferFunc: func() (privacy.PrivateTransactionManager, error) {
+			raw, err := p.dispense(privacy.ConnectorName)
+			if err != nil {
+				return nil, err
+			}
+			return raw.(*privacy.PluginGateway).WithTimeout(timeout), nil
+		},
+	}, nil
+}
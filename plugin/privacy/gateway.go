@@ -0,0 +1,161 @@
+package privacy
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/plugin/privacy/proto"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTimeout is the time the plugin is given to answer a call unless
+// another timeout is configured
+const DefaultTimeout = 5 * time.Second
+
+type PluginGateway struct {
+	client  proto.PrivateTransactionManagerClient
+	timeout time.Duration
+}
+
+// WithTimeout returns a gateway to the same plugin whose calls are cancelled
+// after timeout. Zero means DefaultTimeout is used.
+func (p *PluginGateway) WithTimeout(timeout time.Duration) *PluginGateway {
+	return &PluginGateway{client: p.client, timeout: timeout}
+}
+
+func (p *PluginGateway) newContext() (context.Context, context.CancelFunc) {
+	timeout := p.timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func (p *PluginGateway) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
+	ctx, cancel := p.newContext()
+	defer cancel()
+	resp, err := p.client.Send(ctx, &proto.SendRequest{
+		Payload: data,
+		From:    from,
+		To:      to,
+		Extra:   toProtoExtra(extra),
+	})
+	if err != nil {
+		return common.EncryptedPayloadHash{}, err
+	}
+	return common.BytesToEncryptedPayloadHash(resp.GetTxHash()), nil
+}
+
+func (p *PluginGateway) StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error) {
+	ctx, cancel := p.newContext()
+	defer cancel()
+	resp, err := p.client.StoreRaw(ctx, &proto.StoreRawRequest{
+		Payload: data,
+		From:    from,
+	})
+	if err != nil {
+		return common.EncryptedPayloadHash{}, err
+	}
+	return common.BytesToEncryptedPayloadHash(resp.GetTxHash()), nil
+}
+
+func (p *PluginGateway) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	ctx, cancel := p.newContext()
+	defer cancel()
+	resp, err := p.client.SendSignedTx(ctx, &proto.SendSignedTxRequest{
+		TxHash: txHash.Bytes(),
+		To:     to,
+		Extra:  toProtoExtra(extra),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetData(), nil
+}
+
+// Receive returns a nil payload if the plugin responds with an empty payload
+// or a NotFound status. Any other error is returned as *engine.TransportError.
+func (p *PluginGateway) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	ctx, cancel := p.newContext()
+	defer cancel()
+	resp, err := p.client.Receive(ctx, &proto.ReceiveRequest{
+		TxHash: txHash.Bytes(),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, &engine.TransportError{Op: "Receive", Err: err}
+	}
+	if len(resp.GetPayload()) == 0 {
+		return nil, nil, nil
+	}
+	return resp.GetPayload(), fromProtoExtra(resp.GetExtra()), nil
+}
+
+// IsSender returns any error as *engine.TransportError
+func (p *PluginGateway) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
+	ctx, cancel := p.newContext()
+	defer cancel()
+	resp, err := p.client.IsSender(ctx, &proto.IsSenderRequest{
+		TxHash: txHash.Bytes(),
+	})
+	if err != nil {
+		return false, &engine.TransportError{Op: "IsSender", Err: err}
+	}
+	return resp.GetSender(), nil
+}
+
+// ReceiveBatch receives the payloads concurrently as the plugin API has no
+// batch operation
+func (p *PluginGateway) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return engine.ReceiveConcurrently(txHashes, engine.DefaultBatchConcurrency, p.Receive)
+}
+
+// GetParticipants returns any error as *engine.TransportError
+func (p *PluginGateway) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
+	ctx, cancel := p.newContext()
+	defer cancel()
+	resp, err := p.client.GetParticipants(ctx, &proto.GetParticipantsRequest{
+		TxHash: txHash.Bytes(),
+	})
+	if err != nil {
+		return nil, &engine.TransportError{Op: "GetParticipants", Err: err}
+	}
+	return resp.GetParticipants(), nil
+}
+
+func toProtoExtra(extra *engine.ExtraMetadata) *proto.ExtraMetadata {
+	if extra == nil {
+		return nil
+	}
+	acHashes := make([][]byte, 0, len(extra.ACHashes))
+	for h := range extra.ACHashes {
+		acHashes = append(acHashes, h.Bytes())
+	}
+	return &proto.ExtraMetadata{
+		AcHashes:            acHashes,
+		PrivacyFlag:         uint64(extra.PrivacyFlag),
+		MandatoryRecipients: extra.MandatoryRecipients,
+		Participants:        extra.Participants,
+	}
+}
+
+func fromProtoExtra(extra *proto.ExtraMetadata) *engine.ExtraMetadata {
+	if extra == nil {
+		return nil
+	}
+	acHashes := make(common.EncryptedPayloadHashes, len(extra.GetAcHashes()))
+	for _, h := range extra.GetAcHashes() {
+		acHashes.Add(common.BytesToEncryptedPayloadHash(h))
+	}
+	return &engine.ExtraMetadata{
+		ACHashes:            acHashes,
+		PrivacyFlag:         engine.PrivacyFlagType(extra.GetPrivacyFlag()),
+		MandatoryRecipients: extra.GetMandatoryRecipients(),
+		Participants:        extra.GetParticipants(),
+	}
+}
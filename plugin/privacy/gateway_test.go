@@ -0,0 +1,177 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/plugin/privacy/proto"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	arbitraryHash     = common.BytesToEncryptedPayloadHash([]byte("arbitrary hash"))
+	arbitraryACHash   = common.BytesToEncryptedPayloadHash([]byte("arbitrary creation hash"))
+	notFoundHash      = common.BytesToEncryptedPayloadHash([]byte("not found"))
+	failingHash       = common.BytesToEncryptedPayloadHash([]byte("failing"))
+	slowHash          = common.BytesToEncryptedPayloadHash([]byte("slow"))
+	arbitraryPayload  = []byte("arbitrary payload")
+	arbitraryReceiver = "arbitrary receiver"
+)
+
+// fakeServer is an in-process private transaction manager storing payloads in memory
+type fakeServer struct {
+	payloads map[common.EncryptedPayloadHash]*proto.ReceiveResponse
+}
+
+func (s *fakeServer) Send(_ context.Context, req *proto.SendRequest) (*proto.SendResponse, error) {
+	s.payloads[arbitraryHash] = &proto.ReceiveResponse{Payload: req.Payload, Extra: req.Extra}
+	return &proto.SendResponse{TxHash: arbitraryHash.Bytes()}, nil
+}
+
+func (s *fakeServer) StoreRaw(_ context.Context, req *proto.StoreRawRequest) (*proto.StoreRawResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (s *fakeServer) SendSignedTx(_ context.Context, req *proto.SendSignedTxRequest) (*proto.SendSignedTxResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (s *fakeServer) Receive(ctx context.Context, req *proto.ReceiveRequest) (*proto.ReceiveResponse, error) {
+	hash := common.BytesToEncryptedPayloadHash(req.TxHash)
+	switch hash {
+	case notFoundHash:
+		return nil, status.Error(codes.NotFound, "not found")
+	case failingHash:
+		return nil, status.Error(codes.Unavailable, "enclave unavailable")
+	case slowHash:
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if resp, ok := s.payloads[hash]; ok {
+		return resp, nil
+	}
+	return &proto.ReceiveResponse{}, nil
+}
+
+func (s *fakeServer) IsSender(_ context.Context, req *proto.IsSenderRequest) (*proto.IsSenderResponse, error) {
+	if common.BytesToEncryptedPayloadHash(req.TxHash) == failingHash {
+		return nil, status.Error(codes.Unavailable, "enclave unavailable")
+	}
+	_, ok := s.payloads[common.BytesToEncryptedPayloadHash(req.TxHash)]
+	return &proto.IsSenderResponse{Sender: ok}, nil
+}
+
+func (s *fakeServer) GetParticipants(_ context.Context, req *proto.GetParticipantsRequest) (*proto.GetParticipantsResponse, error) {
+	if common.BytesToEncryptedPayloadHash(req.TxHash) == failingHash {
+		return nil, status.Error(codes.Unavailable, "enclave unavailable")
+	}
+	return &proto.GetParticipantsResponse{Participants: []string{arbitraryReceiver}}, nil
+}
+
+func newTestGateway(t *testing.T) (*PluginGateway, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	proto.RegisterPrivateTransactionManagerServer(server, &fakeServer{
+		payloads: make(map[common.EncryptedPayloadHash]*proto.ReceiveResponse),
+	})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &PluginGateway{client: proto.NewPrivateTransactionManagerClient(conn)}, func() {
+		_ = conn.Close()
+		server.Stop()
+	}
+}
+
+func TestPluginGateway_SendAndReceive(t *testing.T) {
+	testObject, cleanup := newTestGateway(t)
+	defer cleanup()
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(arbitraryACHash)
+	extra := &engine.ExtraMetadata{
+		ACHashes:            acHashes,
+		PrivacyFlag:         engine.PrivacyFlagMandatoryRecipients,
+		MandatoryRecipients: []string{arbitraryReceiver},
+	}
+
+	hash, err := testObject.Send(arbitraryPayload, "", []string{arbitraryReceiver}, extra)
+
+	assert.NoError(t, err)
+	assert.Equal(t, arbitraryHash, hash)
+
+	data, actualExtra, err := testObject.Receive(hash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, arbitraryPayload, data)
+	assert.Equal(t, extra, actualExtra)
+
+	isSender, err := testObject.IsSender(hash)
+
+	assert.NoError(t, err)
+	assert.True(t, isSender)
+
+	participants, err := testObject.GetParticipants(hash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{arbitraryReceiver}, participants)
+}
+
+func TestPluginGateway_Receive_whenNotParty(t *testing.T) {
+	testObject, cleanup := newTestGateway(t)
+	defer cleanup()
+
+	for _, hash := range []common.EncryptedPayloadHash{notFoundHash, arbitraryACHash} {
+		data, extra, err := testObject.Receive(hash)
+
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+		assert.Nil(t, extra)
+	}
+}
+
+func TestPluginGateway_Receive_whenPluginFails(t *testing.T) {
+	testObject, cleanup := newTestGateway(t)
+	defer cleanup()
+
+	_, _, err := testObject.Receive(failingHash)
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+}
+
+func TestPluginGateway_whenPluginFails(t *testing.T) {
+	testObject, cleanup := newTestGateway(t)
+	defer cleanup()
+
+	_, err := testObject.IsSender(failingHash)
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+
+	_, err = testObject.GetParticipants(failingHash)
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+}
+
+func TestPluginGateway_Receive_whenPluginTimesOut(t *testing.T) {
+	testObject, cleanup := newTestGateway(t)
+	defer cleanup()
+
+	_, _, err := testObject.WithTimeout(100 * time.Millisecond).Receive(slowHash)
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(errors.Unwrap(err)))
+}
@@ -0,0 +1,913 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: privacy.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Additional data stored with a private payload
+type ExtraMetadata struct {
+	// hashes of the transactions which created the contracts affected by the transaction
+	AcHashes [][]byte `protobuf:"bytes,1,rep,name=acHashes,proto3" json:"acHashes,omitempty"`
+	// privacy flag of the transaction
+	PrivacyFlag uint64 `protobuf:"varint,2,opt,name=privacyFlag,proto3" json:"privacyFlag,omitempty"`
+	// public keys of the recipients which must be party to every transaction affecting the contract
+	MandatoryRecipients []string `protobuf:"bytes,3,rep,name=mandatoryRecipients,proto3" json:"mandatoryRecipients,omitempty"`
+	// public keys of the sender and the recipients of the transaction, which every party checks the transaction against
+	Participants         []string `protobuf:"bytes,4,rep,name=participants,proto3" json:"participants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExtraMetadata) Reset()         { *m = ExtraMetadata{} }
+func (m *ExtraMetadata) String() string { return proto.CompactTextString(m) }
+func (*ExtraMetadata) ProtoMessage()    {}
+func (*ExtraMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{0}
+}
+
+func (m *ExtraMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtraMetadata.Unmarshal(m, b)
+}
+func (m *ExtraMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtraMetadata.Marshal(b, m, deterministic)
+}
+func (m *ExtraMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtraMetadata.Merge(m, src)
+}
+func (m *ExtraMetadata) XXX_Size() int {
+	return xxx_messageInfo_ExtraMetadata.Size(m)
+}
+func (m *ExtraMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtraMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtraMetadata proto.InternalMessageInfo
+
+func (m *ExtraMetadata) GetAcHashes() [][]byte {
+	if m != nil {
+		return m.AcHashes
+	}
+	return nil
+}
+
+func (m *ExtraMetadata) GetPrivacyFlag() uint64 {
+	if m != nil {
+		return m.PrivacyFlag
+	}
+	return 0
+}
+
+func (m *ExtraMetadata) GetMandatoryRecipients() []string {
+	if m != nil {
+		return m.MandatoryRecipients
+	}
+	return nil
+}
+
+func (m *ExtraMetadata) GetParticipants() []string {
+	if m != nil {
+		return m.Participants
+	}
+	return nil
+}
+
+type SendRequest struct {
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// base64 encoded public key of the sender
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// base64 encoded public keys of the recipients
+	To                   []string       `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
+	Extra                *ExtraMetadata `protobuf:"bytes,4,opt,name=extra,proto3" json:"extra,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SendRequest) Reset()         { *m = SendRequest{} }
+func (m *SendRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRequest) ProtoMessage()    {}
+func (*SendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{1}
+}
+
+func (m *SendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendRequest.Unmarshal(m, b)
+}
+func (m *SendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendRequest.Marshal(b, m, deterministic)
+}
+func (m *SendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRequest.Merge(m, src)
+}
+func (m *SendRequest) XXX_Size() int {
+	return xxx_messageInfo_SendRequest.Size(m)
+}
+func (m *SendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendRequest proto.InternalMessageInfo
+
+func (m *SendRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SendRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *SendRequest) GetTo() []string {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *SendRequest) GetExtra() *ExtraMetadata {
+	if m != nil {
+		return m.Extra
+	}
+	return nil
+}
+
+type SendResponse struct {
+	// hash of the encrypted payload
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendResponse) Reset()         { *m = SendResponse{} }
+func (m *SendResponse) String() string { return proto.CompactTextString(m) }
+func (*SendResponse) ProtoMessage()    {}
+func (*SendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{2}
+}
+
+func (m *SendResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendResponse.Unmarshal(m, b)
+}
+func (m *SendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendResponse.Marshal(b, m, deterministic)
+}
+func (m *SendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendResponse.Merge(m, src)
+}
+func (m *SendResponse) XXX_Size() int {
+	return xxx_messageInfo_SendResponse.Size(m)
+}
+func (m *SendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendResponse proto.InternalMessageInfo
+
+func (m *SendResponse) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+type StoreRawRequest struct {
+	Payload              []byte   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	From                 string   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreRawRequest) Reset()         { *m = StoreRawRequest{} }
+func (m *StoreRawRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreRawRequest) ProtoMessage()    {}
+func (*StoreRawRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{3}
+}
+
+func (m *StoreRawRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreRawRequest.Unmarshal(m, b)
+}
+func (m *StoreRawRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreRawRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreRawRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreRawRequest.Merge(m, src)
+}
+func (m *StoreRawRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreRawRequest.Size(m)
+}
+func (m *StoreRawRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreRawRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreRawRequest proto.InternalMessageInfo
+
+func (m *StoreRawRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *StoreRawRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+type StoreRawResponse struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreRawResponse) Reset()         { *m = StoreRawResponse{} }
+func (m *StoreRawResponse) String() string { return proto.CompactTextString(m) }
+func (*StoreRawResponse) ProtoMessage()    {}
+func (*StoreRawResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{4}
+}
+
+func (m *StoreRawResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreRawResponse.Unmarshal(m, b)
+}
+func (m *StoreRawResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreRawResponse.Marshal(b, m, deterministic)
+}
+func (m *StoreRawResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreRawResponse.Merge(m, src)
+}
+func (m *StoreRawResponse) XXX_Size() int {
+	return xxx_messageInfo_StoreRawResponse.Size(m)
+}
+func (m *StoreRawResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreRawResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreRawResponse proto.InternalMessageInfo
+
+func (m *StoreRawResponse) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+type SendSignedTxRequest struct {
+	// hash of the payload previously stored with StoreRaw
+	TxHash               []byte         `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	To                   []string       `protobuf:"bytes,2,rep,name=to,proto3" json:"to,omitempty"`
+	Extra                *ExtraMetadata `protobuf:"bytes,3,opt,name=extra,proto3" json:"extra,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SendSignedTxRequest) Reset()         { *m = SendSignedTxRequest{} }
+func (m *SendSignedTxRequest) String() string { return proto.CompactTextString(m) }
+func (*SendSignedTxRequest) ProtoMessage()    {}
+func (*SendSignedTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{5}
+}
+
+func (m *SendSignedTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendSignedTxRequest.Unmarshal(m, b)
+}
+func (m *SendSignedTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendSignedTxRequest.Marshal(b, m, deterministic)
+}
+func (m *SendSignedTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSignedTxRequest.Merge(m, src)
+}
+func (m *SendSignedTxRequest) XXX_Size() int {
+	return xxx_messageInfo_SendSignedTxRequest.Size(m)
+}
+func (m *SendSignedTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSignedTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendSignedTxRequest proto.InternalMessageInfo
+
+func (m *SendSignedTxRequest) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *SendSignedTxRequest) GetTo() []string {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *SendSignedTxRequest) GetExtra() *ExtraMetadata {
+	if m != nil {
+		return m.Extra
+	}
+	return nil
+}
+
+type SendSignedTxResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendSignedTxResponse) Reset()         { *m = SendSignedTxResponse{} }
+func (m *SendSignedTxResponse) String() string { return proto.CompactTextString(m) }
+func (*SendSignedTxResponse) ProtoMessage()    {}
+func (*SendSignedTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{6}
+}
+
+func (m *SendSignedTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendSignedTxResponse.Unmarshal(m, b)
+}
+func (m *SendSignedTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendSignedTxResponse.Marshal(b, m, deterministic)
+}
+func (m *SendSignedTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSignedTxResponse.Merge(m, src)
+}
+func (m *SendSignedTxResponse) XXX_Size() int {
+	return xxx_messageInfo_SendSignedTxResponse.Size(m)
+}
+func (m *SendSignedTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSignedTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendSignedTxResponse proto.InternalMessageInfo
+
+func (m *SendSignedTxResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ReceiveRequest struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveRequest) Reset()         { *m = ReceiveRequest{} }
+func (m *ReceiveRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiveRequest) ProtoMessage()    {}
+func (*ReceiveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{7}
+}
+
+func (m *ReceiveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveRequest.Unmarshal(m, b)
+}
+func (m *ReceiveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveRequest.Marshal(b, m, deterministic)
+}
+func (m *ReceiveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveRequest.Merge(m, src)
+}
+func (m *ReceiveRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiveRequest.Size(m)
+}
+func (m *ReceiveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveRequest proto.InternalMessageInfo
+
+func (m *ReceiveRequest) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+// An empty payload means this node is not a party to the transaction
+type ReceiveResponse struct {
+	Payload              []byte         `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Extra                *ExtraMetadata `protobuf:"bytes,2,opt,name=extra,proto3" json:"extra,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ReceiveResponse) Reset()         { *m = ReceiveResponse{} }
+func (m *ReceiveResponse) String() string { return proto.CompactTextString(m) }
+func (*ReceiveResponse) ProtoMessage()    {}
+func (*ReceiveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{8}
+}
+
+func (m *ReceiveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveResponse.Unmarshal(m, b)
+}
+func (m *ReceiveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveResponse.Marshal(b, m, deterministic)
+}
+func (m *ReceiveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveResponse.Merge(m, src)
+}
+func (m *ReceiveResponse) XXX_Size() int {
+	return xxx_messageInfo_ReceiveResponse.Size(m)
+}
+func (m *ReceiveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveResponse proto.InternalMessageInfo
+
+func (m *ReceiveResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ReceiveResponse) GetExtra() *ExtraMetadata {
+	if m != nil {
+		return m.Extra
+	}
+	return nil
+}
+
+type IsSenderRequest struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsSenderRequest) Reset()         { *m = IsSenderRequest{} }
+func (m *IsSenderRequest) String() string { return proto.CompactTextString(m) }
+func (*IsSenderRequest) ProtoMessage()    {}
+func (*IsSenderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{9}
+}
+
+func (m *IsSenderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsSenderRequest.Unmarshal(m, b)
+}
+func (m *IsSenderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsSenderRequest.Marshal(b, m, deterministic)
+}
+func (m *IsSenderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsSenderRequest.Merge(m, src)
+}
+func (m *IsSenderRequest) XXX_Size() int {
+	return xxx_messageInfo_IsSenderRequest.Size(m)
+}
+func (m *IsSenderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsSenderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsSenderRequest proto.InternalMessageInfo
+
+func (m *IsSenderRequest) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+type IsSenderResponse struct {
+	Sender               bool     `protobuf:"varint,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsSenderResponse) Reset()         { *m = IsSenderResponse{} }
+func (m *IsSenderResponse) String() string { return proto.CompactTextString(m) }
+func (*IsSenderResponse) ProtoMessage()    {}
+func (*IsSenderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{10}
+}
+
+func (m *IsSenderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsSenderResponse.Unmarshal(m, b)
+}
+func (m *IsSenderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsSenderResponse.Marshal(b, m, deterministic)
+}
+func (m *IsSenderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsSenderResponse.Merge(m, src)
+}
+func (m *IsSenderResponse) XXX_Size() int {
+	return xxx_messageInfo_IsSenderResponse.Size(m)
+}
+func (m *IsSenderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsSenderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsSenderResponse proto.InternalMessageInfo
+
+func (m *IsSenderResponse) GetSender() bool {
+	if m != nil {
+		return m.Sender
+	}
+	return false
+}
+
+type GetParticipantsRequest struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetParticipantsRequest) Reset()         { *m = GetParticipantsRequest{} }
+func (m *GetParticipantsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetParticipantsRequest) ProtoMessage()    {}
+func (*GetParticipantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{11}
+}
+
+func (m *GetParticipantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetParticipantsRequest.Unmarshal(m, b)
+}
+func (m *GetParticipantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetParticipantsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetParticipantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetParticipantsRequest.Merge(m, src)
+}
+func (m *GetParticipantsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetParticipantsRequest.Size(m)
+}
+func (m *GetParticipantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetParticipantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetParticipantsRequest proto.InternalMessageInfo
+
+func (m *GetParticipantsRequest) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+type GetParticipantsResponse struct {
+	Participants         []string `protobuf:"bytes,1,rep,name=participants,proto3" json:"participants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetParticipantsResponse) Reset()         { *m = GetParticipantsResponse{} }
+func (m *GetParticipantsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetParticipantsResponse) ProtoMessage()    {}
+func (*GetParticipantsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dde03d4df7a6e99a, []int{12}
+}
+
+func (m *GetParticipantsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetParticipantsResponse.Unmarshal(m, b)
+}
+func (m *GetParticipantsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetParticipantsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetParticipantsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetParticipantsResponse.Merge(m, src)
+}
+func (m *GetParticipantsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetParticipantsResponse.Size(m)
+}
+func (m *GetParticipantsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetParticipantsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetParticipantsResponse proto.InternalMessageInfo
+
+func (m *GetParticipantsResponse) GetParticipants() []string {
+	if m != nil {
+		return m.Participants
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ExtraMetadata)(nil), "proto.ExtraMetadata")
+	proto.RegisterType((*SendRequest)(nil), "proto.SendRequest")
+	proto.RegisterType((*SendResponse)(nil), "proto.SendResponse")
+	proto.RegisterType((*StoreRawRequest)(nil), "proto.StoreRawRequest")
+	proto.RegisterType((*StoreRawResponse)(nil), "proto.StoreRawResponse")
+	proto.RegisterType((*SendSignedTxRequest)(nil), "proto.SendSignedTxRequest")
+	proto.RegisterType((*SendSignedTxResponse)(nil), "proto.SendSignedTxResponse")
+	proto.RegisterType((*ReceiveRequest)(nil), "proto.ReceiveRequest")
+	proto.RegisterType((*ReceiveResponse)(nil), "proto.ReceiveResponse")
+	proto.RegisterType((*IsSenderRequest)(nil), "proto.IsSenderRequest")
+	proto.RegisterType((*IsSenderResponse)(nil), "proto.IsSenderResponse")
+	proto.RegisterType((*GetParticipantsRequest)(nil), "proto.GetParticipantsRequest")
+	proto.RegisterType((*GetParticipantsResponse)(nil), "proto.GetParticipantsResponse")
+}
+
+func init() { proto.RegisterFile("privacy.proto", fileDescriptor_dde03d4df7a6e99a) }
+
+var fileDescriptor_dde03d4df7a6e99a = []byte{
+	// 536 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4d, 0x6b, 0xdb, 0x40,
+	0x10, 0x45, 0x1f, 0x89, 0x9d, 0xb1, 0x12, 0x87, 0x75, 0x6a, 0xab, 0x2a, 0x2d, 0x42, 0x87, 0xa2,
+	0xf8, 0xe0, 0x86, 0xf4, 0x52, 0x28, 0xa5, 0x10, 0x68, 0xd3, 0x1e, 0x02, 0x66, 0x1d, 0x28, 0xf4,
+	0xb6, 0x95, 0xb6, 0xee, 0x82, 0xad, 0x55, 0x56, 0xeb, 0xc4, 0xfe, 0x2d, 0x3d, 0xf6, 0x8f, 0x16,
+	0x49, 0x2b, 0x5b, 0x1f, 0x36, 0x82, 0x9c, 0xa4, 0x9d, 0x7d, 0x33, 0xef, 0xcd, 0xcc, 0x93, 0xe0,
+	0x34, 0x16, 0xec, 0x91, 0x04, 0x9b, 0x49, 0x2c, 0xb8, 0xe4, 0xe8, 0x28, 0x7b, 0x78, 0xff, 0x34,
+	0x38, 0xfd, 0xb2, 0x96, 0x82, 0xdc, 0x51, 0x49, 0x42, 0x22, 0x09, 0x72, 0xa0, 0x4b, 0x82, 0x6f,
+	0x24, 0xf9, 0x43, 0x13, 0x5b, 0x73, 0x0d, 0xdf, 0xc2, 0xdb, 0x33, 0x72, 0xa1, 0xa7, 0xaa, 0x7c,
+	0x5d, 0x90, 0xb9, 0xad, 0xbb, 0x9a, 0x6f, 0xe2, 0x72, 0x08, 0x5d, 0xc1, 0x60, 0x49, 0xa2, 0x90,
+	0x48, 0x2e, 0x36, 0x98, 0x06, 0x2c, 0x66, 0x34, 0x92, 0x89, 0x6d, 0xb8, 0x86, 0x7f, 0x82, 0xf7,
+	0x5d, 0x21, 0x0f, 0xac, 0x98, 0x08, 0xc9, 0x02, 0x16, 0x93, 0x14, 0x6a, 0x66, 0xd0, 0x4a, 0xcc,
+	0x7b, 0x82, 0xde, 0x8c, 0x46, 0x21, 0xa6, 0x0f, 0x2b, 0x9a, 0x48, 0x64, 0x43, 0x27, 0x26, 0x9b,
+	0x05, 0x27, 0xa1, 0xad, 0xb9, 0x9a, 0x6f, 0xe1, 0xe2, 0x88, 0x10, 0x98, 0xbf, 0x05, 0x5f, 0x66,
+	0xca, 0x4e, 0x70, 0xf6, 0x8e, 0xce, 0x40, 0x97, 0x5c, 0x29, 0xd0, 0x25, 0x47, 0x63, 0x38, 0xa2,
+	0x69, 0xc7, 0xb6, 0xe9, 0x6a, 0x7e, 0xef, 0xfa, 0x22, 0x1f, 0xc8, 0xa4, 0x32, 0x05, 0x9c, 0x43,
+	0xbc, 0xb7, 0x60, 0xe5, 0xc4, 0x49, 0xcc, 0xa3, 0x84, 0xa2, 0x21, 0x1c, 0xcb, 0x75, 0x3a, 0x0c,
+	0x45, 0xac, 0x4e, 0xde, 0x67, 0xe8, 0xcf, 0x24, 0x17, 0x14, 0x93, 0xa7, 0x67, 0x89, 0xf4, 0xc6,
+	0x70, 0xbe, 0x2b, 0xd0, 0x42, 0xc6, 0x60, 0x90, 0x8a, 0x9a, 0xb1, 0x79, 0x44, 0xc3, 0xfb, 0x75,
+	0x41, 0x78, 0x00, 0xae, 0xfa, 0xd7, 0x9b, 0xfd, 0x1b, 0xed, 0xfd, 0x8f, 0xe1, 0xa2, 0x4a, 0xa5,
+	0xa4, 0x21, 0x30, 0x53, 0x98, 0x62, 0xca, 0xde, 0x3d, 0x1f, 0xce, 0x30, 0x0d, 0x28, 0x7b, 0xa4,
+	0x2d, 0x8a, 0xbc, 0x1f, 0xd0, 0xdf, 0x22, 0x55, 0xc1, 0xc3, 0xd3, 0xda, 0xca, 0xd5, 0xdb, 0xe5,
+	0x5e, 0x42, 0xff, 0x7b, 0x92, 0x0a, 0xa6, 0xa2, 0x4d, 0xc3, 0x18, 0xce, 0x77, 0xd0, 0xdd, 0xc0,
+	0x93, 0x2c, 0x92, 0x61, 0xbb, 0x58, 0x9d, 0xbc, 0x2b, 0x18, 0xde, 0x52, 0x39, 0x2d, 0x39, 0xb2,
+	0xad, 0xfa, 0x27, 0x18, 0x35, 0x32, 0x14, 0x49, 0xdd, 0xef, 0x5a, 0xd3, 0xef, 0xd7, 0x7f, 0x0d,
+	0x78, 0x39, 0x4d, 0xbf, 0x2a, 0x49, 0xef, 0x05, 0x89, 0x12, 0x12, 0x48, 0xc6, 0xa3, 0x3b, 0x12,
+	0x91, 0x39, 0x15, 0xe8, 0x1d, 0x98, 0xa9, 0x70, 0x84, 0xd4, 0x28, 0x4a, 0x9f, 0x86, 0x33, 0xa8,
+	0xc4, 0x14, 0xe5, 0x47, 0xe8, 0x16, 0xe6, 0x42, 0xc3, 0x02, 0x50, 0xb5, 0xab, 0x33, 0x6a, 0xc4,
+	0x55, 0xf2, 0x2d, 0x58, 0x65, 0x0b, 0x20, 0xa7, 0xc4, 0x50, 0xb3, 0xa0, 0xf3, 0x6a, 0xef, 0x9d,
+	0x2a, 0xf4, 0x01, 0x3a, 0x6a, 0xeb, 0xe8, 0x85, 0xc2, 0x55, 0xfd, 0xe2, 0x0c, 0xeb, 0xe1, 0x9d,
+	0xfe, 0x62, 0x57, 0x5b, 0xfd, 0xb5, 0x3d, 0x3b, 0xa3, 0x46, 0x5c, 0x25, 0x4f, 0xa1, 0x5f, 0x5b,
+	0x05, 0x7a, 0xad, 0xb0, 0xfb, 0x97, 0xea, 0xbc, 0x39, 0x74, 0x9d, 0x57, 0xbc, 0xb9, 0x84, 0x51,
+	0xc0, 0x97, 0x93, 0x87, 0x15, 0x17, 0xab, 0xe5, 0x24, 0x5e, 0xac, 0xe6, 0x2c, 0xca, 0x53, 0x6e,
+	0x3a, 0xd3, 0xfc, 0x5f, 0xf8, 0x33, 0xff, 0xbd, 0xfe, 0x3a, 0xce, 0x1e, 0xef, 0xff, 0x0f, 0x00,
+	0xea, 0xc3, 0x15, 0xa5, 0x7d, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PrivateTransactionManagerClient is the client API for PrivateTransactionManager service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PrivateTransactionManagerClient interface {
+	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	StoreRaw(ctx context.Context, in *StoreRawRequest, opts ...grpc.CallOption) (*StoreRawResponse, error)
+	SendSignedTx(ctx context.Context, in *SendSignedTxRequest, opts ...grpc.CallOption) (*SendSignedTxResponse, error)
+	Receive(ctx context.Context, in *ReceiveRequest, opts ...grpc.CallOption) (*ReceiveResponse, error)
+	IsSender(ctx context.Context, in *IsSenderRequest, opts ...grpc.CallOption) (*IsSenderResponse, error)
+	GetParticipants(ctx context.Context, in *GetParticipantsRequest, opts ...grpc.CallOption) (*GetParticipantsResponse, error)
+}
+
+type privateTransactionManagerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPrivateTransactionManagerClient(cc *grpc.ClientConn) PrivateTransactionManagerClient {
+	return &privateTransactionManagerClient{cc}
+}
+
+func (c *privateTransactionManagerClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := c.cc.Invoke(ctx, "/proto.PrivateTransactionManager/Send", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privateTransactionManagerClient) StoreRaw(ctx context.Context, in *StoreRawRequest, opts ...grpc.CallOption) (*StoreRawResponse, error) {
+	out := new(StoreRawResponse)
+	err := c.cc.Invoke(ctx, "/proto.PrivateTransactionManager/StoreRaw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privateTransactionManagerClient) SendSignedTx(ctx context.Context, in *SendSignedTxRequest, opts ...grpc.CallOption) (*SendSignedTxResponse, error) {
+	out := new(SendSignedTxResponse)
+	err := c.cc.Invoke(ctx, "/proto.PrivateTransactionManager/SendSignedTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privateTransactionManagerClient) Receive(ctx context.Context, in *ReceiveRequest, opts ...grpc.CallOption) (*ReceiveResponse, error) {
+	out := new(ReceiveResponse)
+	err := c.cc.Invoke(ctx, "/proto.PrivateTransactionManager/Receive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privateTransactionManagerClient) IsSender(ctx context.Context, in *IsSenderRequest, opts ...grpc.CallOption) (*IsSenderResponse, error) {
+	out := new(IsSenderResponse)
+	err := c.cc.Invoke(ctx, "/proto.PrivateTransactionManager/IsSender", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privateTransactionManagerClient) GetParticipants(ctx context.Context, in *GetParticipantsRequest, opts ...grpc.CallOption) (*GetParticipantsResponse, error) {
+	out := new(GetParticipantsResponse)
+	err := c.cc.Invoke(ctx, "/proto.PrivateTransactionManager/GetParticipants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivateTransactionManagerServer is the server API for PrivateTransactionManager service.
+type PrivateTransactionManagerServer interface {
+	Send(context.Context, *SendRequest) (*SendResponse, error)
+	StoreRaw(context.Context, *StoreRawRequest) (*StoreRawResponse, error)
+	SendSignedTx(context.Context, *SendSignedTxRequest) (*SendSignedTxResponse, error)
+	Receive(context.Context, *ReceiveRequest) (*ReceiveResponse, error)
+	IsSender(context.Context, *IsSenderRequest) (*IsSenderResponse, error)
+	GetParticipants(context.Context, *GetParticipantsRequest) (*GetParticipantsResponse, error)
+}
+
+func RegisterPrivateTransactionManagerServer(s *grpc.Server, srv PrivateTransactionManagerServer) {
+	s.RegisterService(&_PrivateTransactionManager_serviceDesc, srv)
+}
+
+func _PrivateTransactionManager_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTransactionManagerServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PrivateTransactionManager/Send",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTransactionManagerServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivateTransactionManager_StoreRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTransactionManagerServer).StoreRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PrivateTransactionManager/StoreRaw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTransactionManagerServer).StoreRaw(ctx, req.(*StoreRawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivateTransactionManager_SendSignedTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendSignedTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTransactionManagerServer).SendSignedTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PrivateTransactionManager/SendSignedTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTransactionManagerServer).SendSignedTx(ctx, req.(*SendSignedTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivateTransactionManager_Receive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTransactionManagerServer).Receive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PrivateTransactionManager/Receive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTransactionManagerServer).Receive(ctx, req.(*ReceiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivateTransactionManager_IsSender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsSenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTransactionManagerServer).IsSender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PrivateTransactionManager/IsSender",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTransactionManagerServer).IsSender(ctx, req.(*IsSenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivateTransactionManager_GetParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTransactionManagerServer).GetParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PrivateTransactionManager/GetParticipants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTransactionManagerServer).GetParticipants(ctx, req.(*GetParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PrivateTransactionManager_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.PrivateTransactionManager",
+	HandlerType: (*PrivateTransactionManagerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Send",
+			Handler:    _PrivateTransactionManager_Send_Handler,
+		},
+		{
+			MethodName: "StoreRaw",
+			Handler:    _PrivateTransactionManager_StoreRaw_Handler,
+		},
+		{
+			MethodName: "SendSignedTx",
+			Handler:    _PrivateTransactionManager_SendSignedTx_Handler,
+		},
+		{
+			MethodName: "Receive",
+			Handler:    _PrivateTransactionManager_Receive_Handler,
+		},
+		{
+			MethodName: "IsSender",
+			Handler:    _PrivateTransactionManager_IsSender_Handler,
+		},
+		{
+			MethodName: "GetParticipants",
+			Handler:    _PrivateTransactionManager_GetParticipants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "privacy.proto",
+}
@@ -0,0 +1,87 @@
+package privacy
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/private/engine"
+)
+
+// PrivateTransactionManager is implemented by the privacy plugin. It has the
+// same methods as private.PrivateTransactionManager so the plugin can be used
+// wherever a private transaction manager is expected.
+type PrivateTransactionManager interface {
+	Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error)
+	StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error)
+	SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error)
+	Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error)
+	// ReceiveBatch is Receive for several transactions at once, the results
+	// being in the same order as txHashes
+	ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error)
+	IsSender(txHash common.EncryptedPayloadHash) (bool, error)
+	GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error)
+}
+
+type PrivateTransactionManagerDeferFunc func() (PrivateTransactionManager, error)
+
+// ReloadablePrivateTransactionManager dispenses the plugin on every call so
+// it can be created before the plugin is started and survives plugin reloads
+type ReloadablePrivateTransactionManager struct {
+	DeferFunc PrivateTransactionManagerDeferFunc
+}
+
+func (d *ReloadablePrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return common.EncryptedPayloadHash{}, err
+	}
+	return p.Send(data, from, to, extra)
+}
+
+func (d *ReloadablePrivateTransactionManager) StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return common.EncryptedPayloadHash{}, err
+	}
+	return p.StoreRaw(data, from)
+}
+
+func (d *ReloadablePrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return nil, err
+	}
+	return p.SendSignedTx(txHash, to, extra)
+}
+
+func (d *ReloadablePrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		// not being able to reach the plugin says nothing about whether
+		// we are a party to the transaction
+		return nil, nil, &engine.TransportError{Op: "Receive", Err: err}
+	}
+	return p.Receive(txHash)
+}
+
+func (d *ReloadablePrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return nil, nil, &engine.TransportError{Op: "ReceiveBatch", Err: err}
+	}
+	return p.ReceiveBatch(txHashes)
+}
+
+func (d *ReloadablePrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return false, &engine.TransportError{Op: "IsSender", Err: err}
+	}
+	return p.IsSender(txHash)
+}
+
+func (d *ReloadablePrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return nil, &engine.TransportError{Op: "GetParticipants", Err: err}
+	}
+	return p.GetParticipants(txHash)
+}
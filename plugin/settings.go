@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/plugin/account"
 	"github.com/ethereum/go-ethereum/plugin/helloworld"
+	"github.com/ethereum/go-ethereum/plugin/privacy"
 	"github.com/ethereum/go-ethereum/plugin/security"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-plugin"
@@ -23,6 +24,7 @@ const (
 	HelloWorldPluginInterfaceName = PluginInterfaceName("helloworld") // lower-case always
 	SecurityPluginInterfaceName   = PluginInterfaceName("security")
 	AccountPluginInterfaceName    = PluginInterfaceName("account")
+	PrivacyPluginInterfaceName    = PluginInterfaceName("privacy")
 )
 
 var (
@@ -76,6 +78,11 @@ var (
 				account.ConnectorName: &account.PluginConnector{},
 			},
 		},
+		PrivacyPluginInterfaceName: {
+			pluginSet: plugin.PluginSet{
+				privacy.ConnectorName: &privacy.PluginConnector{},
+			},
+		},
 	}
 
 	// this is the place holder for future solution of the plugin central
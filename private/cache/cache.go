@@ -1,16 +1,315 @@
 package cache
 
 import (
+	"math"
+	"sync"
 	"time"
 
-	gocache "github.com/patrickmn/go-cache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/hashicorp/golang-lru/simplelru"
 )
 
 const (
 	DefaultExpiration = 5 * time.Minute
-	CleanupInterval   = 5 * time.Minute
+	DefaultMaxSize    = 32 * 1024 * 1024
+
+	// itemOverhead approximates the memory used by an entry besides its payload
+	itemOverhead = 256
+)
+
+var (
+	hitMeter     = metrics.NewRegisteredMeter("ptm/cache/hit", nil)
+	missMeter    = metrics.NewRegisteredMeter("ptm/cache/miss", nil)
+	diskHitMeter = metrics.NewRegisteredMeter("ptm/cache/disk/hit", nil)
+	sizeGauge    = metrics.NewRegisteredGauge("ptm/cache/size", nil)
 )
 
-func NewDefaultCache() *gocache.Cache {
-	return gocache.New(DefaultExpiration, CleanupInterval)
+// Item is a payload received from or sent to the private transaction manager
+// together with its extra metadata. A nil Data means this node is not a party
+// to the transaction.
+type Item struct {
+	Data  []byte
+	Extra *engine.ExtraMetadata
+}
+
+// size returns the approximate memory used by the item
+func (i Item) size() int {
+	size := itemOverhead + len(i.Data)
+	if i.Extra != nil {
+		size += len(i.Extra.ACHashes) * common.EncryptedPayloadHashLength
+	}
+	return size
+}
+
+type entry struct {
+	item         Item
+	participants []string
+	expires      time.Time
+}
+
+// size returns the approximate memory used by the entry
+func (e *entry) size() int {
+	size := e.item.size()
+	for _, p := range e.participants {
+		size += len(p)
+	}
+	return size
+}
+
+// participantsKey keys the participants of a transaction apart from its
+// payload
+type participantsKey common.EncryptedPayloadHash
+
+// Cache is a memory bounded LRU cache of private payloads, with an optional
+// persistent layer in a database. Entries expire from memory after the
+// configured TTL, but payloads in the database never do as they are needed
+// again whenever the chain is imported.
+type Cache struct {
+	mu      sync.Mutex
+	lru     *simplelru.LRU
+	size    int
+	maxSize int
+	ttl     time.Duration
+	db      ethdb.KeyValueStore
+}
+
+// New creates a cache holding up to maxSize bytes of payloads for ttl,
+// the default values being used for zero
+func New(maxSize int, ttl time.Duration) *Cache {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultExpiration
+	}
+	c := &Cache{
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+	// the number of entries is only limited by their size
+	c.lru, _ = simplelru.NewLRU(math.MaxInt32, c.onEvict)
+	return c
+}
+
+func NewDefaultCache() *Cache {
+	return New(DefaultMaxSize, DefaultExpiration)
+}
+
+func (c *Cache) onEvict(_ interface{}, value interface{}) {
+	c.size -= value.(*entry).size()
+}
+
+// SetDatabase enables the persistent layer of the cache
+func (c *Cache) SetDatabase(db ethdb.KeyValueStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.db = db
+}
+
+// Get returns the item cached for the given hash, looking it up in the
+// database if it isn't in memory
+func (c *Cache) Get(hash common.EncryptedPayloadHash) (Item, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.lru.Get(hash); ok {
+		e := v.(*entry)
+		if time.Now().Before(e.expires) {
+			hitMeter.Mark(1)
+			return e.item, true
+		}
+		c.lru.Remove(hash)
+	}
+	if item, ok := c.readItem(hash); ok {
+		diskHitMeter.Mark(1)
+		c.add(hash, &entry{item: item})
+		return item, true
+	}
+	missMeter.Mark(1)
+	return Item{}, false
+}
+
+// GetParticipants returns the participants cached for the transaction with
+// the given hash
+func (c *Cache) GetParticipants(hash common.EncryptedPayloadHash) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.lru.Get(participantsKey(hash)); ok {
+		e := v.(*entry)
+		if time.Now().Before(e.expires) {
+			hitMeter.Mark(1)
+			return e.participants, true
+		}
+		c.lru.Remove(participantsKey(hash))
+	}
+	missMeter.Mark(1)
+	return nil, false
+}
+
+// Set caches the item for the given hash. Payloads are also written to the
+// database if the persistent layer is enabled, while knowing this node isn't
+// a party to a transaction is only kept in memory.
+func (c *Cache) Set(hash common.EncryptedPayloadHash, item Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(hash, &entry{item: item})
+	if c.db != nil && item.Data != nil {
+		c.writeItem(hash, item)
+	}
+}
+
+// SetParticipants caches the participants of the transaction with the given
+// hash. They are only kept in memory.
+func (c *Cache) SetParticipants(hash common.EncryptedPayloadHash, participants []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(participantsKey(hash), &entry{participants: participants})
+}
+
+// Flush removes all the items from memory
+func (c *Cache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Purge()
+	sizeGauge.Update(int64(c.size))
+}
+
+func (c *Cache) add(key interface{}, e *entry) {
+	size := e.size()
+	if size > c.maxSize {
+		c.lru.Remove(key)
+		return
+	}
+	// replacing an entry doesn't evict it, account for it explicitly
+	if v, ok := c.lru.Peek(key); ok {
+		c.size -= v.(*entry).size()
+	}
+	e.expires = time.Now().Add(c.ttl)
+	c.lru.Add(key, e)
+	c.size += size
+	for c.size > c.maxSize {
+		c.lru.RemoveOldest()
+	}
+	sizeGauge.Update(int64(c.size))
+}
+
+// storedItem is the database representation of an item
+type storedItem struct {
+	Data        []byte
+	HasExtra    bool
+	ACHashes    []common.EncryptedPayloadHash
+	PrivacyFlag uint64
+
+	// Recipients holds the encoded mandatory recipients followed by the
+	// encoded participants. The items stored before the participants were
+	// added have the mandatory recipients inlined instead.
+	Recipients []rlp.RawValue `rlp:"tail"`
+}
+
+// decodeRecipients returns the mandatory recipients and participants of a
+// stored item
+func decodeRecipients(recipients []rlp.RawValue) (mandatory, participants []string, err error) {
+	if len(recipients) == 0 {
+		return nil, nil, nil
+	}
+	if kind, _, _, err := rlp.Split(recipients[0]); err != nil {
+		return nil, nil, err
+	} else if kind != rlp.List {
+		// mandatory recipients inlined by the older items
+		for _, raw := range recipients {
+			var recipient string
+			if err := rlp.DecodeBytes(raw, &recipient); err != nil {
+				return nil, nil, err
+			}
+			mandatory = append(mandatory, recipient)
+		}
+		return mandatory, nil, nil
+	}
+	if err := rlp.DecodeBytes(recipients[0], &mandatory); err != nil {
+		return nil, nil, err
+	}
+	if len(recipients) > 1 {
+		if err := rlp.DecodeBytes(recipients[1], &participants); err != nil {
+			return nil, nil, err
+		}
+	}
+	return mandatory, participants, nil
+}
+
+// encodeRecipients is the counterpart of decodeRecipients
+func encodeRecipients(mandatory, participants []string) ([]rlp.RawValue, error) {
+	if len(mandatory) == 0 && len(participants) == 0 {
+		return nil, nil
+	}
+	encodedMandatory, err := rlp.EncodeToBytes(mandatory)
+	if err != nil {
+		return nil, err
+	}
+	encodedParticipants, err := rlp.EncodeToBytes(participants)
+	if err != nil {
+		return nil, err
+	}
+	return []rlp.RawValue{encodedMandatory, encodedParticipants}, nil
+}
+
+func (c *Cache) readItem(hash common.EncryptedPayloadHash) (Item, bool) {
+	if c.db == nil {
+		return Item{}, false
+	}
+	blob := rawdb.ReadPrivatePayload(c.db, hash)
+	if len(blob) == 0 {
+		return Item{}, false
+	}
+	var stored storedItem
+	if err := rlp.DecodeBytes(blob, &stored); err != nil {
+		log.Error("Invalid private payload in database", "hash", hash, "err", err)
+		return Item{}, false
+	}
+	item := Item{Data: stored.Data}
+	if stored.HasExtra {
+		mandatory, participants, err := decodeRecipients(stored.Recipients)
+		if err != nil {
+			log.Error("Invalid private payload in database", "hash", hash, "err", err)
+			return Item{}, false
+		}
+		item.Extra = &engine.ExtraMetadata{
+			ACHashes:    make(common.EncryptedPayloadHashes),
+			PrivacyFlag: engine.PrivacyFlagType(stored.PrivacyFlag),
+
+			MandatoryRecipients: mandatory,
+			Participants:        participants,
+		}
+		for _, h := range stored.ACHashes {
+			item.Extra.ACHashes.Add(h)
+		}
+	}
+	return item, true
+}
+
+func (c *Cache) writeItem(hash common.EncryptedPayloadHash, item Item) {
+	stored := storedItem{Data: item.Data}
+	if item.Extra != nil {
+		stored.HasExtra = true
+		stored.PrivacyFlag = uint64(item.Extra.PrivacyFlag)
+		for h := range item.Extra.ACHashes {
+			stored.ACHashes = append(stored.ACHashes, h)
+		}
+		recipients, err := encodeRecipients(item.Extra.MandatoryRecipients, item.Extra.Participants)
+		if err != nil {
+			log.Error("Failed to encode private payload", "hash", hash, "err", err)
+			return
+		}
+		stored.Recipients = recipients
+	}
+	blob, err := rlp.EncodeToBytes(&stored)
+	if err != nil {
+		log.Error("Failed to encode private payload", "hash", hash, "err", err)
+		return
+	}
+	rawdb.WritePrivatePayload(c.db, hash, blob)
 }
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func hash(s string) common.EncryptedPayloadHash {
+	return common.BytesToEncryptedPayloadHash([]byte(s))
+}
+
+func TestCache_whenFull_evictsLeastRecentlyUsed(t *testing.T) {
+	payload := make([]byte, 1000)
+	c := New(3*(itemOverhead+len(payload)), time.Minute)
+
+	c.Set(hash("a"), Item{Data: payload})
+	c.Set(hash("b"), Item{Data: payload})
+	c.Set(hash("c"), Item{Data: payload})
+	_, _ = c.Get(hash("a"))
+	c.Set(hash("d"), Item{Data: payload})
+
+	_, found := c.Get(hash("b"))
+	assert.False(t, found, "least recently used item must be evicted")
+	for _, s := range []string{"a", "c", "d"} {
+		_, found := c.Get(hash(s))
+		assert.True(t, found, "%s must still be cached", s)
+	}
+	assert.Equal(t, 3*(itemOverhead+len(payload)), c.size)
+}
+
+func TestCache_whenItemTooLarge(t *testing.T) {
+	c := New(100, time.Minute)
+
+	c.Set(hash("a"), Item{Data: make([]byte, 1000)})
+
+	_, found := c.Get(hash("a"))
+	assert.False(t, found)
+	assert.Equal(t, 0, c.size)
+}
+
+func TestCache_whenExpired(t *testing.T) {
+	c := New(DefaultMaxSize, time.Millisecond)
+
+	c.Set(hash("a"), Item{Data: []byte("payload")})
+	time.Sleep(5 * time.Millisecond)
+
+	_, found := c.Get(hash("a"))
+	assert.False(t, found)
+	assert.Equal(t, 0, c.size)
+}
+
+func TestCache_whenPersisted(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	c := NewDefaultCache()
+	c.SetDatabase(db)
+	acHashes := common.EncryptedPayloadHashes{hash("contract"): struct{}{}}
+
+	c.Set(hash("a"), Item{Data: []byte("payload"), Extra: &engine.ExtraMetadata{ACHashes: acHashes, PrivacyFlag: engine.PrivacyFlagMandatoryRecipients, MandatoryRecipients: []string{"regulator"}, Participants: []string{"sender", "regulator"}}})
+	c.Set(hash("not a party"), Item{})
+
+	restarted := NewDefaultCache()
+	restarted.SetDatabase(db)
+	item, found := restarted.Get(hash("a"))
+
+	assert.True(t, found)
+	assert.Equal(t, "payload", string(item.Data))
+	assert.Equal(t, engine.PrivacyFlagMandatoryRecipients, item.Extra.PrivacyFlag)
+	assert.Equal(t, []string{"regulator"}, item.Extra.MandatoryRecipients)
+	assert.Equal(t, []string{"sender", "regulator"}, item.Extra.Participants)
+	assert.Equal(t, acHashes, item.Extra.ACHashes)
+
+	_, found = restarted.Get(hash("not a party"))
+	assert.False(t, found, "not being a party must not be persisted")
+}
+
+func TestCache_whenPersistedWithInlinedMandatoryRecipients(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	// the layout of the items stored before the participants were added
+	blob, err := rlp.EncodeToBytes(&struct {
+		Data                []byte
+		HasExtra            bool
+		ACHashes            []common.EncryptedPayloadHash
+		PrivacyFlag         uint64
+		MandatoryRecipients []string `rlp:"tail"`
+	}{[]byte("payload"), true, nil, uint64(engine.PrivacyFlagMandatoryRecipients), []string{"regulator", "auditor"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawdb.WritePrivatePayload(db, hash("a"), blob)
+	c := NewDefaultCache()
+	c.SetDatabase(db)
+
+	item, found := c.Get(hash("a"))
+
+	assert.True(t, found)
+	assert.Equal(t, []string{"regulator", "auditor"}, item.Extra.MandatoryRecipients)
+	assert.Empty(t, item.Extra.Participants)
+}
+
+func TestCache_participantsApartFromPayload(t *testing.T) {
+	c := NewDefaultCache()
+
+	c.SetParticipants(hash("a"), []string{"A", "B"})
+
+	_, found := c.Get(hash("a"))
+	assert.False(t, found, "participants must not be taken for the payload")
+	participants, found := c.GetParticipants(hash("a"))
+	assert.True(t, found)
+	assert.Equal(t, []string{"A", "B"}, participants)
+	assert.Equal(t, itemOverhead+2, c.size)
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultBatchConcurrency is the number of payloads a batch receives at once
+// from a transaction manager which has no batch API
+const DefaultBatchConcurrency = 16
+
+// ReceiveFunc retrieves a single payload, see PrivateTransactionManager.Receive
+type ReceiveFunc func(txHash common.EncryptedPayloadHash) ([]byte, *ExtraMetadata, error)
+
+// ReceiveConcurrently receives the payloads of txHashes with at most
+// concurrency requests in flight, returning them in the same order as
+// txHashes. The first error encountered is returned.
+func ReceiveConcurrently(txHashes []common.EncryptedPayloadHash, concurrency int, receive ReceiveFunc) ([][]byte, []*ExtraMetadata, error) {
+	var (
+		payloads = make([][]byte, len(txHashes))
+		extras   = make([]*ExtraMetadata, len(txHashes))
+		errs     = make([]error, len(txHashes))
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+	)
+	for i, txHash := range txHashes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, txHash common.EncryptedPayloadHash) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			payloads[i], extras[i], errs[i] = receive(txHash)
+		}(i, txHash)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return payloads, extras, nil
+}
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveConcurrently(t *testing.T) {
+	var (
+		inFlight, maxInFlight int32
+		txHashes              []common.EncryptedPayloadHash
+	)
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		txHashes = append(txHashes, common.BytesToEncryptedPayloadHash([]byte(s)))
+	}
+
+	payloads, extras, err := ReceiveConcurrently(txHashes, 2, func(txHash common.EncryptedPayloadHash) ([]byte, *ExtraMetadata, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		return txHash.Bytes(), &ExtraMetadata{}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, extras, len(txHashes))
+	for i, txHash := range txHashes {
+		assert.Equal(t, txHash.Bytes(), payloads[i], "results must be in the order of the hashes")
+	}
+	assert.True(t, maxInFlight <= 2, "at most 2 requests must be in flight, got %d", maxInFlight)
+}
+
+func TestReceiveConcurrently_whenOneFails(t *testing.T) {
+	failure := errors.New("arbitrary failure")
+	failing := common.BytesToEncryptedPayloadHash([]byte("failing"))
+
+	_, _, err := ReceiveConcurrently([]common.EncryptedPayloadHash{{}, failing}, 2, func(txHash common.EncryptedPayloadHash) ([]byte, *ExtraMetadata, error) {
+		if txHash == failing {
+			return nil, nil, failure
+		}
+		return []byte{}, nil, nil
+	})
+
+	assert.Equal(t, failure, err)
+}
@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrPayloadNotFound is returned by a transaction manager client when the
+// requested payload is unknown, which means this node is not a party to it
+var ErrPayloadNotFound = errors.New("payload not found in private transaction manager")
+
+// TransportError is returned when a request to the private transaction
+// manager could not be completed or got an unexpected response. Unlike
+// ErrPayloadNotFound it says nothing about whether this node is a party to a
+// transaction, so callers must not treat it as such.
+type TransportError struct {
+	Op  string // the API which was called
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("private transaction manager %s failed: %v", e.Op, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransportError returns true if err is or wraps a *TransportError
+func IsTransportError(err error) bool {
+	var transportErr *TransportError
+	return errors.As(err, &transportErr)
+}
+
+// PrivacyFlagType describes the level of privacy enforcement requested for
+// a private transaction and recorded against the contracts it creates
+type PrivacyFlagType uint64
+
+const (
+	// Standard private transaction, no additional enforcement
+	PrivacyFlagStandardPrivate PrivacyFlagType = 0
+	// Only parties to a contract can send transactions that affect it
+	PrivacyFlagPartyProtection PrivacyFlagType = 1
+	// Party protection, and the mandatory recipients of a contract must be
+	// party to every transaction affecting it
+	PrivacyFlagMandatoryRecipients PrivacyFlagType = 2
+	// Party protection, and the participants of every transaction affecting
+	// a contract must be exactly the participants of the contract
+	PrivacyFlagStateValidation PrivacyFlagType = 3
+)
+
+func (f PrivacyFlagType) IsStandardPrivate() bool {
+	return f == PrivacyFlagStandardPrivate
+}
+
+func (f PrivacyFlagType) IsNotStandardPrivate() bool {
+	return !f.IsStandardPrivate()
+}
+
+func (f PrivacyFlagType) Validate() error {
+	switch f {
+	case PrivacyFlagStandardPrivate, PrivacyFlagPartyProtection, PrivacyFlagMandatoryRecipients, PrivacyFlagStateValidation:
+		return nil
+	}
+	return fmt.Errorf("invalid privacy flag %d", f)
+}
+
+// ExtraMetadata is the additional data stored with a private payload in the
+// private transaction manager
+type ExtraMetadata struct {
+	// Hashes of the transactions which created the contracts affected by
+	// this transaction
+	ACHashes common.EncryptedPayloadHashes
+	// Privacy flag of the transaction
+	PrivacyFlag PrivacyFlagType
+	// Public keys of the recipients which must be party to every transaction
+	// affecting the contracts, only set with PrivacyFlagMandatoryRecipients
+	MandatoryRecipients []string
+	// Public keys of the sender and the recipients of the transaction, only
+	// set with the privacy flags checking them, so that every party checks
+	// the transaction against the same participants
+	Participants []string
+}
+
+// HealthStatus is the availability of the private transaction manager as last
+// observed by its client, either through a request or an upcheck probe
+type HealthStatus struct {
+	Up                  bool      `json:"up"`
+	LastChecked         time.Time `json:"lastChecked"`
+	LastError           string    `json:"lastError,omitempty"`
+	ConsecutiveFailures uint64    `json:"consecutiveFailures"`
+	// CircuitOpen is true while requests fail fast without being sent
+	CircuitOpen bool `json:"circuitOpen"`
+}
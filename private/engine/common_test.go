@@ -0,0 +1,18 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransportError(t *testing.T) {
+	transportErr := &TransportError{Op: "receive", Err: errors.New("connection refused")}
+
+	assert.True(t, IsTransportError(transportErr))
+	assert.True(t, IsTransportError(fmt.Errorf("unable to retrieve payload: %w", transportErr)), "wrapped errors must be recognized")
+	assert.False(t, IsTransportError(ErrPayloadNotFound))
+	assert.False(t, IsTransportError(nil))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/private/engine"
 )
 
 var ErrPrivateTxManagerNotInUse = errors.New("private transaction manager is not in use")
@@ -13,14 +14,14 @@ var ErrPrivateTxManagerNotInUse = errors.New("private transaction manager is not
 type PrivateTransactionManager struct{}
 
 func (ptm *PrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
-	panic("implement me")
+	return false, ErrPrivateTxManagerNotInUse
 }
 
 func (ptm *PrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
-	panic("implement me")
+	return nil, ErrPrivateTxManagerNotInUse
 }
 
-func (ptm *PrivateTransactionManager) Send(data []byte, from string, to []string) (common.EncryptedPayloadHash, error) {
+func (ptm *PrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error) {
 	return common.EncryptedPayloadHash{}, ErrPrivateTxManagerNotInUse
 }
 
@@ -28,13 +29,17 @@ func (ptm *PrivateTransactionManager) StoreRaw(data []byte, from string) (common
 	return common.EncryptedPayloadHash{}, ErrPrivateTxManagerNotInUse
 }
 
-func (ptm *PrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string) ([]byte, error) {
+func (ptm *PrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error) {
 	return nil, ErrPrivateTxManagerNotInUse
 }
 
-func (ptm *PrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, error) {
+func (ptm *PrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
 	//error not thrown here, acts as though no private data to fetch
-	return nil, nil
+	return nil, nil, nil
+}
+
+func (ptm *PrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return make([][]byte, len(txHashes)), make([]*engine.ExtraMetadata, len(txHashes)), nil
 }
 
 func (ptm *PrivateTransactionManager) Name() string {
@@ -17,7 +17,7 @@ func TestName(t *testing.T) {
 func TestSendReturnsError(t *testing.T) {
 	ptm := &PrivateTransactionManager{}
 
-	_, err := ptm.Send([]byte{}, "", []string{})
+	_, err := ptm.Send([]byte{}, "", []string{}, nil)
 
 	assert.Equal(t, err, ErrPrivateTxManagerNotInUse, "got wrong error in 'send'")
 }
@@ -33,7 +33,7 @@ func TestStoreRawReturnsError(t *testing.T) {
 func TestReceiveReturnsError(t *testing.T) {
 	ptm := &PrivateTransactionManager{}
 
-	_, err := ptm.Receive(common.EncryptedPayloadHash{})
+	_, _, err := ptm.Receive(common.EncryptedPayloadHash{})
 
 	assert.Nil(t, err, "got unexpected error in 'receive'")
 }
@@ -41,7 +41,7 @@ func TestReceiveReturnsError(t *testing.T) {
 func TestSendSignedTxReturnsError(t *testing.T) {
 	ptm := &PrivateTransactionManager{}
 
-	_, err := ptm.SendSignedTx(common.EncryptedPayloadHash{}, []string{})
+	_, err := ptm.SendSignedTx(common.EncryptedPayloadHash{}, []string{}, nil)
 
 	assert.Equal(t, err, ErrPrivateTxManagerNotInUse, "got wrong error in 'SendSignedTx'")
 }
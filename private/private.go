@@ -4,23 +4,44 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/private/engine"
 	"github.com/ethereum/go-ethereum/private/engine/notinuse"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/private/privatetransactionmanager"
 )
 
 // Interacting with Private Transaction Manager APIs
 type PrivateTransactionManager interface {
-	Send(data []byte, from string, to []string) (common.EncryptedPayloadHash, error)
+	Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (common.EncryptedPayloadHash, error)
 	StoreRaw(data []byte, from string) (common.EncryptedPayloadHash, error)
-	SendSignedTx(txHash common.EncryptedPayloadHash, to []string) ([]byte, error)
-	Receive(txHash common.EncryptedPayloadHash) ([]byte, error)
+	SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) ([]byte, error)
+	// Receive returns the payload and the extra metadata stored with it.
+	// A nil payload means this node is not a party to the transaction.
+	Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error)
+	// ReceiveBatch is Receive for several transactions at once, the results
+	// being in the same order as txHashes
+	ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error)
 
 	IsSender(txHash common.EncryptedPayloadHash) (bool, error)
 	GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error)
 }
 
+// HealthChecker is implemented by private transaction managers which monitor
+// the availability of the transaction manager they connect to
+type HealthChecker interface {
+	Health() engine.HealthStatus
+}
+
+// PersistentCache is implemented by private transaction managers which can keep
+// the payloads they receive in the chain database
+type PersistentCache interface {
+	SetCacheDatabase(db ethdb.KeyValueStore)
+}
+
+// FromEnvironmentOrNil creates the private transaction manager configured by
+// the environment variable name, nil if it is not set
 func FromEnvironmentOrNil(name string) PrivateTransactionManager {
 	cfgPath := os.Getenv(name)
 	if cfgPath == "" {
@@ -31,5 +52,3 @@ func FromEnvironmentOrNil(name string) PrivateTransactionManager {
 	}
 	return privatetransactionmanager.MustNew(cfgPath)
 }
-
-var P = FromEnvironmentOrNil("PRIVATE_CONFIG")
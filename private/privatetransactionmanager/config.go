@@ -1,13 +1,70 @@
 package privatetransactionmanager
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/BurntSushi/toml"
 )
 
+const (
+	DefaultDialTimeout           = 1 * time.Second
+	DefaultRequestTimeout        = 5 * time.Second
+	DefaultResponseHeaderTimeout = 5 * time.Second
+
+	DefaultRetryBackoff           = 200 * time.Millisecond
+	DefaultCircuitBreakerCooldown = 10 * time.Second
+)
+
 type Config struct {
 	Socket  string `toml:"socket"`
 	WorkDir string `toml:"workdir"`
 
+	// HttpUrl is the base URL of the transaction manager when it is reached
+	// over the network (http:// or https://) rather than a local socket.
+	// It takes precedence over Socket when both are set.
+	HttpUrl string `toml:"httpUrl"`
+
+	// TLS settings, only used when HttpUrl is an https:// URL.
+	// TLSClientCert and TLSClientKey must be provided together to
+	// enable mutual TLS.
+	TLSRootCA             string `toml:"tlsRootCA"`
+	TLSClientCert         string `toml:"tlsClientCert"`
+	TLSClientKey          string `toml:"tlsClientKey"`
+	TLSInsecureSkipVerify bool   `toml:"tlsInsecureSkipVerify"`
+
+	// Timeouts, in seconds. Zero means the default value is used.
+	DialTimeout           uint `toml:"dialTimeout"`
+	RequestTimeout        uint `toml:"requestTimeout"`
+	ResponseHeaderTimeout uint `toml:"responseHeaderTimeout"`
+
+	// Requests failing with a transport error or a 5xx response are retried
+	// up to MaxRetries times. The first retry waits RetryBackoff milliseconds
+	// (zero means the default value), doubled for every following retry.
+	MaxRetries   uint `toml:"maxRetries"`
+	RetryBackoff uint `toml:"retryBackoff"`
+
+	// After CircuitBreakerThreshold consecutive failed requests, requests
+	// fail fast for CircuitBreakerCooldown seconds (zero means the default
+	// value). A zero threshold disables the circuit breaker.
+	CircuitBreakerThreshold uint `toml:"circuitBreakerThreshold"`
+	CircuitBreakerCooldown  uint `toml:"circuitBreakerCooldown"`
+
+	// UpcheckInterval is the interval in seconds at which the transaction
+	// manager is probed, zero disables the probe.
+	UpcheckInterval uint `toml:"upcheckInterval"`
+
+	// Payload cache. CacheSize is its memory limit in megabytes and CacheTTL
+	// the time in seconds a payload is kept in memory, zero meaning the
+	// default values. If CachePersist is set, received payloads are also
+	// kept in the chain database so that they aren't fetched again when the
+	// chain is imported.
+	CacheSize    uint `toml:"cacheSize"`
+	CacheTTL     uint `toml:"cacheTTL"`
+	CachePersist bool `toml:"cachePersist"`
+
 	// Deprecated
 	SocketPath string `toml:"socketPath"`
 }
@@ -21,5 +78,71 @@ func LoadConfig(configPath string) (*Config, error) {
 	if cfg.Socket == "" {
 		cfg.Socket = cfg.SocketPath
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
+
+// isHttpUrl returns true if s looks like an http:// or https:// URL
+func isHttpUrl(s string) bool {
+	lower := strings.ToLower(s)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// isTLS returns true if the transaction manager is reached over https
+func (c *Config) isTLS() bool {
+	return strings.HasPrefix(strings.ToLower(c.HttpUrl), "https://")
+}
+
+func (c *Config) validate() error {
+	if c.HttpUrl == "" {
+		if c.Socket == "" {
+			return fmt.Errorf("either socket or httpUrl must be specified")
+		}
+		return nil
+	}
+	if !isHttpUrl(c.HttpUrl) {
+		return fmt.Errorf("httpUrl must start with http:// or https://: %s", c.HttpUrl)
+	}
+	if _, err := url.Parse(c.HttpUrl); err != nil {
+		return fmt.Errorf("invalid httpUrl: %v", err)
+	}
+	if (c.TLSClientCert == "") != (c.TLSClientKey == "") {
+		return fmt.Errorf("tlsClientCert and tlsClientKey must be specified together")
+	}
+	if !c.isTLS() && (c.TLSRootCA != "" || c.TLSClientCert != "") {
+		return fmt.Errorf("TLS settings require an https:// httpUrl")
+	}
+	return nil
+}
+
+func (c *Config) dialTimeout() time.Duration {
+	return secondsOrDefault(c.DialTimeout, DefaultDialTimeout)
+}
+
+func (c *Config) requestTimeout() time.Duration {
+	return secondsOrDefault(c.RequestTimeout, DefaultRequestTimeout)
+}
+
+func (c *Config) responseHeaderTimeout() time.Duration {
+	return secondsOrDefault(c.ResponseHeaderTimeout, DefaultResponseHeaderTimeout)
+}
+
+func (c *Config) retryBackoff() time.Duration {
+	if c.RetryBackoff == 0 {
+		return DefaultRetryBackoff
+	}
+	return time.Duration(c.RetryBackoff) * time.Millisecond
+}
+
+func (c *Config) circuitBreakerCooldown() time.Duration {
+	return secondsOrDefault(c.CircuitBreakerCooldown, DefaultCircuitBreakerCooldown)
+}
+
+func secondsOrDefault(seconds uint, def time.Duration) time.Duration {
+	if seconds == 0 {
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
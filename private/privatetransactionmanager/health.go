@@ -0,0 +1,115 @@
+package privatetransactionmanager
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/private/engine"
+)
+
+var (
+	upGauge          = metrics.NewRegisteredGauge("ptm/up", nil)
+	circuitGauge     = metrics.NewRegisteredGauge("ptm/circuit/open", nil)
+	requestTimer     = metrics.NewRegisteredTimer("ptm/requests", nil)
+	failureMeter     = metrics.NewRegisteredMeter("ptm/failures", nil)
+	retryMeter       = metrics.NewRegisteredMeter("ptm/retries", nil)
+	rejectedMeter    = metrics.NewRegisteredMeter("ptm/circuit/rejected", nil)
+	upcheckFailMeter = metrics.NewRegisteredMeter("ptm/upcheck/failures", nil)
+)
+
+// errCircuitOpen is returned without contacting the transaction manager while
+// the circuit breaker is open
+var errCircuitOpen = errors.New("circuit breaker is open after repeated failures")
+
+// health tracks the outcome of the requests to the transaction manager and
+// implements a circuit breaker on top of it. The circuit opens after
+// threshold consecutive failures, then lets a single request through every
+// cooldown until one succeeds.
+type health struct {
+	mu        sync.Mutex
+	threshold uint64 // zero disables the circuit breaker
+	cooldown  time.Duration
+
+	up          bool
+	lastChecked time.Time
+	lastErr     error
+	failures    uint64
+	openedAt    time.Time
+}
+
+func newHealth(threshold uint, cooldown time.Duration) *health {
+	return &health{
+		threshold: uint64(threshold),
+		cooldown:  cooldown,
+	}
+}
+
+func (h *health) isOpen() bool {
+	return h.threshold > 0 && h.failures >= h.threshold
+}
+
+// allow returns false if a request must fail fast
+func (h *health) allow() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.isOpen() {
+		return true
+	}
+	if time.Since(h.openedAt) < h.cooldown {
+		return false
+	}
+	// half-open, the next trial is only allowed after another cooldown
+	h.openedAt = time.Now()
+	return true
+}
+
+// record updates the health with the outcome of a request, err being nil if
+// the transaction manager responded without a server error
+func (h *health) record(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	wasUp := h.up
+	h.up, h.lastChecked, h.lastErr = err == nil, time.Now(), err
+	if err == nil {
+		h.failures = 0
+		if !wasUp {
+			log.Info("Private transaction manager is up")
+		}
+	} else {
+		h.failures++
+		failureMeter.Mark(1)
+		if h.isOpen() {
+			h.openedAt = h.lastChecked
+		}
+		if wasUp {
+			log.Warn("Private transaction manager is down", "err", err)
+		}
+	}
+	upGauge.Update(boolToInt64(h.up))
+	circuitGauge.Update(boolToInt64(h.isOpen()))
+}
+
+func (h *health) status() engine.HealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := engine.HealthStatus{
+		Up:                  h.up,
+		LastChecked:         h.lastChecked,
+		ConsecutiveFailures: h.failures,
+		CircuitOpen:         h.isOpen(),
+	}
+	if h.lastErr != nil {
+		s.LastError = h.lastErr.Error()
+	}
+	return s
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -2,21 +2,27 @@ package privatetransactionmanager
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/private/engine"
 
 	"github.com/tv42/httpunix"
 )
@@ -30,6 +36,42 @@ type storeRawResp struct {
 	Key string `json:"key"`
 }
 
+// sendReq is the request of the JSON send API, which unlike sendraw also
+// stores the extra metadata used for privacy enhancements
+type sendReq struct {
+	Payload                      []byte                 `json:"payload"`
+	From                         string                 `json:"from,omitempty"`
+	To                           []string               `json:"to"`
+	AffectedContractTransactions []string               `json:"affectedContractTransactions"`
+	PrivacyFlag                  engine.PrivacyFlagType `json:"privacyFlag"`
+	MandatoryRecipients          []string               `json:"mandatoryRecipients,omitempty"`
+	Participants                 []string               `json:"participants,omitempty"`
+}
+
+type sendSignedTxReq struct {
+	Hash                         []byte                 `json:"hash"`
+	To                           []string               `json:"to"`
+	AffectedContractTransactions []string               `json:"affectedContractTransactions"`
+	PrivacyFlag                  engine.PrivacyFlagType `json:"privacyFlag"`
+	MandatoryRecipients          []string               `json:"mandatoryRecipients,omitempty"`
+	Participants                 []string               `json:"participants,omitempty"`
+}
+
+type sendResp struct {
+	Key string `json:"key"`
+}
+
+type receiveResp struct {
+	Payload                      []byte                 `json:"payload"`
+	AffectedContractTransactions []string               `json:"affectedContractTransactions"`
+	PrivacyFlag                  engine.PrivacyFlagType `json:"privacyFlag"`
+	MandatoryRecipients          []string               `json:"mandatoryRecipients,omitempty"`
+	Participants                 []string               `json:"participants,omitempty"`
+}
+
+// maxRetryBackoff caps the exponential backoff between retries
+const maxRetryBackoff = 5 * time.Second
+
 func launchNode(cfgPath string) (*exec.Cmd, error) {
 	cmd := exec.Command("constellation-node", cfgPath)
 	stderr, err := cmd.StderrPipe()
@@ -46,9 +88,9 @@ func launchNode(cfgPath string) (*exec.Cmd, error) {
 
 func unixTransport(socketPath string) *httpunix.Transport {
 	t := &httpunix.Transport{
-		DialTimeout:           1 * time.Second,
-		RequestTimeout:        5 * time.Second,
-		ResponseHeaderTimeout: 5 * time.Second,
+		DialTimeout:           DefaultDialTimeout,
+		RequestTimeout:        DefaultRequestTimeout,
+		ResponseHeaderTimeout: DefaultResponseHeaderTimeout,
 	}
 	t.RegisterLocation("c", socketPath)
 	return t
@@ -60,20 +102,161 @@ func unixClient(socketPath string) *http.Client {
 	}
 }
 
+// httpTransport returns a transport for a transaction manager reached
+// over http(s), configuring server verification and client certificates
+// from the given config
+func httpTransport(cfg *Config) (*http.Transport, error) {
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.dialTimeout(),
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ResponseHeaderTimeout: cfg.responseHeaderTimeout(),
+		TLSHandshakeTimeout:   cfg.dialTimeout() + cfg.requestTimeout(),
+		IdleConnTimeout:       90 * time.Second,
+		// keep the connections used by batches of concurrent requests
+		MaxIdleConnsPerHost: engine.DefaultBatchConcurrency,
+	}
+	if !cfg.isTLS() {
+		return t, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+	if cfg.TLSRootCA != "" {
+		pem, err := ioutil.ReadFile(cfg.TLSRootCA)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read root CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in root CA file %s", cfg.TLSRootCA)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.TLSClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSClientCert, cfg.TLSClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
+
 func RunNode(socketPath string) error {
-	c := unixClient(socketPath)
-	res, err := c.Get("http+unix://c/upcheck")
+	c, err := NewClient(socketPath)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode == 200 {
-		return nil
-	}
-	return errors.New("private transaction manager did not respond to upcheck request")
+	return c.Upcheck()
 }
 
 type Client struct {
 	httpClient *http.Client
+	// baseURL is prepended to every API path, either "http+unix://c" for
+	// a socket or the configured http(s) URL without trailing slash
+	baseURL string
+
+	maxRetries   int
+	retryBackoff time.Duration
+	health       *health
+}
+
+func (c *Client) url(path string) string {
+	return c.baseURL + "/" + path
+}
+
+// Upcheck verifies that the transaction manager is up and responding. It is
+// never retried nor rejected by the circuit breaker, and a successful upcheck
+// closes the circuit.
+func (c *Client) Upcheck() error {
+	res, err := c.httpClient.Get(c.url("upcheck"))
+	if err == nil {
+		res.Body.Close()
+		if res.StatusCode != 200 {
+			err = errors.New("private transaction manager did not respond to upcheck request")
+		}
+	}
+	if err != nil {
+		upcheckFailMeter.Mark(1)
+	}
+	c.health.record(err)
+	return err
+}
+
+// Health returns the availability of the transaction manager as observed by
+// the latest request or upcheck
+func (c *Client) Health() engine.HealthStatus {
+	return c.health.status()
+}
+
+// do sends the request, retrying it on transport errors and 5xx responses as
+// configured. Requests fail fast while the circuit breaker is open.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if !c.health.allow() {
+		rejectedMeter.Mark(1)
+		return nil, errCircuitOpen
+	}
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		res, err := c.httpClient.Do(req)
+		requestTimer.UpdateSince(start)
+
+		failure := err
+		if err == nil && res.StatusCode >= 500 {
+			failure = fmt.Errorf("%s status code", res.Status)
+		}
+		if failure == nil || attempt >= c.maxRetries || !canRetry(req, err) {
+			c.health.record(failure)
+			return res, err
+		}
+		if res != nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		if req, err = rewind(req); err != nil {
+			c.health.record(failure)
+			return nil, err
+		}
+		retryMeter.Mark(1)
+		log.Debug("Retrying private transaction manager request", "url", req.URL, "attempt", attempt+1, "err", failure)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// canRetry returns true if the failed request can be sent again. Requests
+// which may change the state of the transaction manager are only retried if
+// it never received them.
+func canRetry(req *http.Request, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if req.Method == http.MethodGet {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// rewind returns a copy of the request ready to be sent again
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
 }
 
 func (c *Client) doJson(path string, apiReq interface{}) (*http.Response, error) {
@@ -82,13 +265,14 @@ func (c *Client) doJson(path string, apiReq interface{}) (*http.Response, error)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", "http+unix://c/"+path, buf)
+	req, err := http.NewRequest("POST", c.url(path), buf)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 	if err == nil && res.StatusCode != 200 {
+		res.Body.Close()
 		return nil, fmt.Errorf("Non-200 status code: %+v", res)
 	}
 	return res, err
@@ -96,7 +280,7 @@ func (c *Client) doJson(path string, apiReq interface{}) (*http.Response, error)
 
 func (c *Client) SendPayload(pl []byte, b64From string, b64To []string) ([]byte, error) {
 	buf := bytes.NewBuffer(pl)
-	req, err := http.NewRequest("POST", "http+unix://c/sendraw", buf)
+	req, err := http.NewRequest("POST", c.url("sendraw"), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +289,7 @@ func (c *Client) SendPayload(pl []byte, b64From string, b64To []string) ([]byte,
 	}
 	req.Header.Set("c11n-to", strings.Join(b64To, ","))
 	req.Header.Set("Content-Type", "application/octet-stream")
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 
 	if res != nil {
 		defer res.Body.Close()
@@ -129,13 +313,19 @@ func (c *Client) StorePayload(pl []byte, b64From string) ([]byte, error) {
 	if err := json.NewEncoder(buf).Encode(storeRawReq); err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", "http+unix://c/storeraw", buf)
+	req, err := http.NewRequest("POST", c.url("storeraw"), buf)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 
+	if res != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
 	if res.StatusCode == http.StatusNotFound {
 		return nil, fmt.Errorf("Non-200 status code, verify that tessera is running and version is 0.10.5+: %v", res)
 	}
@@ -157,14 +347,14 @@ func (c *Client) StorePayload(pl []byte, b64From string) ([]byte, error) {
 
 func (c *Client) SendSignedPayload(signedPayload []byte, b64To []string) ([]byte, error) {
 	buf := bytes.NewBuffer(signedPayload)
-	req, err := http.NewRequest("POST", "http+unix://c/sendsignedtx", buf)
+	req, err := http.NewRequest("POST", c.url("sendsignedtx"), buf)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("c11n-to", strings.Join(b64To, ","))
 	req.Header.Set("Content-Type", "application/octet-stream")
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 
 	if res != nil {
 		defer res.Body.Close()
@@ -180,78 +370,178 @@ func (c *Client) SendSignedPayload(signedPayload []byte, b64To []string) ([]byte
 }
 
 func (c *Client) ReceivePayload(key []byte) ([]byte, error) {
-	req, err := http.NewRequest("GET", "http+unix://c/receiveraw", nil)
+	req, err := http.NewRequest("GET", c.url("receiveraw"), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("c11n-key", base64.StdEncoding.EncodeToString(key))
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 
 	if res != nil {
 		defer res.Body.Close()
 	}
 	if err != nil {
-		return nil, err
+		return nil, &engine.TransportError{Op: "receiveraw", Err: err}
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return nil, engine.ErrPayloadNotFound
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 status code: %+v", res)
+		return nil, &engine.TransportError{Op: "receiveraw", Err: fmt.Errorf("Non-200 status code: %+v", res)}
+	}
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, &engine.TransportError{Op: "receiveraw", Err: err}
+	}
+	return data, nil
+}
+
+// SendPayloadWithExtra sends the payload together with its extra metadata,
+// using the JSON send API
+func (c *Client) SendPayloadWithExtra(pl []byte, b64From string, b64To []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	res, err := c.doJson("send", &sendReq{
+		Payload:                      pl,
+		From:                         b64From,
+		To:                           b64To,
+		AffectedContractTransactions: extra.ACHashes.ToBase64s(),
+		PrivacyFlag:                  extra.PrivacyFlag,
+		MandatoryRecipients:          extra.MandatoryRecipients,
+		Participants:                 extra.Participants,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return decodeKey(res.Body)
+}
+
+// SendSignedPayloadWithExtra is the JSON counterpart of SendSignedPayload which
+// also carries the extra metadata
+func (c *Client) SendSignedPayloadWithExtra(signedPayload []byte, b64To []string, extra *engine.ExtraMetadata) ([]byte, error) {
+	res, err := c.doJson("sendsignedtx", &sendSignedTxReq{
+		Hash:                         signedPayload,
+		To:                           b64To,
+		AffectedContractTransactions: extra.ACHashes.ToBase64s(),
+		PrivacyFlag:                  extra.PrivacyFlag,
+		MandatoryRecipients:          extra.MandatoryRecipients,
+		Participants:                 extra.Participants,
+	})
+	if err != nil {
+		return nil, err
 	}
+	defer res.Body.Close()
+	return decodeKey(res.Body)
+}
 
-	return ioutil.ReadAll(res.Body)
+func decodeKey(body io.Reader) ([]byte, error) {
+	var resp sendResp
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Key)
+}
+
+// ReceivePayloadWithExtra retrieves the payload together with the extra
+// metadata stored with it. Transaction managers which don't expose the JSON
+// API are served by receiveraw and the payload is then treated as standard
+// private.
+func (c *Client) ReceivePayloadWithExtra(key []byte) ([]byte, *engine.ExtraMetadata, error) {
+	b64Key := base64.StdEncoding.EncodeToString(key)
+	req, err := http.NewRequest("GET", c.url("transaction/"+url.PathEscape(b64Key)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.do(req)
+
+	if res != nil {
+		defer res.Body.Close()
+	}
+	if err != nil {
+		return nil, nil, &engine.TransportError{Op: "receive", Err: err}
+	}
+	if res.StatusCode == http.StatusNotFound {
+		data, err := c.ReceivePayload(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		return data, &engine.ExtraMetadata{
+			ACHashes:    make(common.EncryptedPayloadHashes),
+			PrivacyFlag: engine.PrivacyFlagStandardPrivate,
+		}, nil
+	}
+	if res.StatusCode != 200 {
+		return nil, nil, &engine.TransportError{Op: "receive", Err: fmt.Errorf("Non-200 status code: %+v", res)}
+	}
+	var resp receiveResp
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, nil, &engine.TransportError{Op: "receive", Err: err}
+	}
+	acHashes, err := common.Base64sToEncryptedPayloadHashes(resp.AffectedContractTransactions)
+	if err != nil {
+		return nil, nil, &engine.TransportError{Op: "receive", Err: err}
+	}
+	return resp.Payload, &engine.ExtraMetadata{
+		ACHashes:            acHashes,
+		PrivacyFlag:         resp.PrivacyFlag,
+		MandatoryRecipients: resp.MandatoryRecipients,
+		Participants:        resp.Participants,
+	}, nil
 }
 
 func (c *Client) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
-	req, err := http.NewRequest("GET", "http+unix://c/transaction/"+url.PathEscape(txHash.ToBase64())+"/isSender", nil)
+	req, err := http.NewRequest("GET", c.url("transaction/"+url.PathEscape(txHash.ToBase64())+"/isSender"), nil)
 	if err != nil {
 		return false, err
 	}
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 
 	if res != nil {
 		defer res.Body.Close()
 	}
 
 	if err != nil {
-		return false, err
+		return false, &engine.TransportError{Op: "isSender", Err: err}
 	}
 
 	if res.StatusCode != 200 {
-		return false, fmt.Errorf("non-200 status code: %+v", res)
+		return false, &engine.TransportError{Op: "isSender", Err: fmt.Errorf("non-200 status code: %+v", res)}
 	}
 
 	out, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return false, err
+		return false, &engine.TransportError{Op: "isSender", Err: err}
 	}
 
 	return strconv.ParseBool(string(out))
 }
 
 func (c *Client) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
-	requestUrl := "http+unix://c/transaction/" + url.PathEscape(txHash.ToBase64()) + "/participants"
+	requestUrl := c.url("transaction/" + url.PathEscape(txHash.ToBase64()) + "/participants")
 	req, err := http.NewRequest("GET", requestUrl, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 
 	if res != nil {
 		defer res.Body.Close()
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, &engine.TransportError{Op: "participants", Err: err}
 	}
 
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("Non-200 status code: %+v", res)
+		return nil, &engine.TransportError{Op: "participants", Err: fmt.Errorf("Non-200 status code: %+v", res)}
 	}
 
 	out, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, &engine.TransportError{Op: "participants", Err: err}
 	}
 
 	split := strings.Split(string(out), ",")
@@ -262,5 +552,42 @@ func (c *Client) GetParticipants(txHash common.EncryptedPayloadHash) ([]string,
 func NewClient(socketPath string) (*Client, error) {
 	return &Client{
 		httpClient: unixClient(socketPath),
+		baseURL:    "http+unix://c",
+		health:     newHealth(0, 0),
 	}, nil
 }
+
+// NewClientFromConfig creates a client for either a socket or an http(s)
+// transaction manager, depending on whether HttpUrl is set
+func NewClientFromConfig(cfg *Config) (*Client, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.HttpUrl == "" {
+		socketPath := filepath.Join(cfg.WorkDir, cfg.Socket)
+		t := unixTransport(socketPath)
+		t.DialTimeout = cfg.dialTimeout()
+		t.RequestTimeout = cfg.requestTimeout()
+		t.ResponseHeaderTimeout = cfg.responseHeaderTimeout()
+		return newClientWithConfig(&http.Client{Transport: t}, "http+unix://c", cfg), nil
+	}
+	t, err := httpTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{
+		Transport: t,
+		Timeout:   cfg.requestTimeout(),
+	}
+	return newClientWithConfig(httpClient, strings.TrimRight(cfg.HttpUrl, "/"), cfg), nil
+}
+
+func newClientWithConfig(httpClient *http.Client, baseURL string, cfg *Config) *Client {
+	return &Client{
+		httpClient:   httpClient,
+		baseURL:      baseURL,
+		maxRetries:   int(cfg.MaxRetries),
+		retryBackoff: cfg.retryBackoff(),
+		health:       newHealth(cfg.CircuitBreakerThreshold, cfg.circuitBreakerCooldown()),
+	}
+}
@@ -0,0 +1,456 @@
+package privatetransactionmanager
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/private/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+var arbitraryHash = common.BytesToEncryptedPayloadHash([]byte("arbitrary key"))
+
+func newTestServer() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/receiveraw", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("arbitrary payload"))
+	})
+	return mux
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{"socket", Config{Socket: "tm.ipc"}, true},
+		{"nothing", Config{}, false},
+		{"http", Config{HttpUrl: "http://localhost:9101"}, true},
+		{"bad scheme", Config{HttpUrl: "ftp://localhost:9101"}, false},
+		{"cert without key", Config{HttpUrl: "https://localhost:9101", TLSClientCert: "cert.pem"}, false},
+		{"mutual tls", Config{HttpUrl: "https://localhost:9101", TLSClientCert: "cert.pem", TLSClientKey: "key.pem"}, true},
+		{"tls over http", Config{HttpUrl: "http://localhost:9101", TLSRootCA: "ca.pem"}, false},
+	}
+	for _, tc := range testCases {
+		err := tc.cfg.validate()
+		assert.Equal(t, tc.valid, err == nil, "%s: unexpected validation result %v", tc.name, err)
+	}
+}
+
+func TestNew_whenHttpUrl(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+
+	ptm, err := New(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	actual, _, err := ptm.Receive(arbitraryHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "arbitrary payload", string(actual))
+}
+
+func TestNew_whenHttpUrlDown(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	server.Close()
+
+	_, err := New(server.URL)
+
+	assert.Error(t, err)
+}
+
+func TestNewFromConfig_whenHttpsWithRootCA(t *testing.T) {
+	server := httptest.NewTLSServer(newTestServer())
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ptm-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.pem")
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(caFile, caPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewFromConfig(&Config{HttpUrl: server.URL})
+	assert.Error(t, err, "server certificate must not be trusted without root CA")
+
+	ptm, err := NewFromConfig(&Config{HttpUrl: server.URL, TLSRootCA: caFile})
+	if !assert.NoError(t, err) {
+		return
+	}
+	actual, _, err := ptm.Receive(arbitraryHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "arbitrary payload", string(actual))
+}
+
+func TestLoadConfig_whenHttpUrl(t *testing.T) {
+	f, err := ioutil.TempFile("", "ptm-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString(`
+httpUrl = "https://tessera:9101"
+tlsRootCA = "/certs/ca.pem"
+tlsClientCert = "/certs/client.pem"
+tlsClientKey = "/certs/client.key"
+requestTimeout = 10
+`)
+	_ = f.Close()
+
+	cfg, err := LoadConfig(f.Name())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://tessera:9101", cfg.HttpUrl)
+	assert.Equal(t, "/certs/client.key", cfg.TLSClientKey)
+	assert.Equal(t, DefaultDialTimeout, cfg.dialTimeout())
+	assert.Equal(t, 10*DefaultDialTimeout, cfg.requestTimeout())
+}
+
+func TestSendAndReceive_whenPrivacyFlagSet(t *testing.T) {
+	var stored sendReq
+	mux := newTestServer()
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&stored)
+		_ = json.NewEncoder(w).Encode(&sendResp{Key: arbitraryHash.ToBase64()})
+	})
+	mux.HandleFunc("/transaction/", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&receiveResp{
+			Payload:                      stored.Payload,
+			AffectedContractTransactions: stored.AffectedContractTransactions,
+			PrivacyFlag:                  stored.PrivacyFlag,
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := New(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	acHashes := common.EncryptedPayloadHashes{}
+	acHashes.Add(common.BytesToEncryptedPayloadHash([]byte("creation")))
+
+	hash, err := ptm.Send([]byte("private payload"), "", []string{"B"}, &engine.ExtraMetadata{
+		ACHashes:    acHashes,
+		PrivacyFlag: engine.PrivacyFlagPartyProtection,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, arbitraryHash, hash)
+	assert.Equal(t, engine.PrivacyFlagPartyProtection, stored.PrivacyFlag)
+
+	// bypass the cache filled by Send
+	ptm.c.Flush()
+	data, extra, err := ptm.Receive(hash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "private payload", string(data))
+	assert.Equal(t, engine.PrivacyFlagPartyProtection, extra.PrivacyFlag)
+	assert.Equal(t, acHashes, extra.ACHashes)
+}
+
+func TestReceive_whenPayloadNotFound(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/receiveraw", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.NotFound(w, r)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := New(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	unknownHash := common.BytesToEncryptedPayloadHash([]byte("unknown key"))
+
+	data, extra, err := ptm.Receive(unknownHash)
+
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	assert.Nil(t, extra)
+
+	_, _, _ = ptm.Receive(unknownHash)
+	assert.Equal(t, 1, calls, "not found must be cached")
+}
+
+func TestGetParticipants_whenCached(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/transaction/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("A,B"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := New(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	txHash := common.BytesToEncryptedPayloadHash([]byte("some key"))
+
+	participants, err := ptm.GetParticipants(txHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, participants)
+
+	_, _ = ptm.GetParticipants(txHash)
+	assert.Equal(t, 1, calls, "participants must be cached")
+}
+
+func TestReceive_whenTransactionManagerFails(t *testing.T) {
+	failing := true
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/receiveraw", func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			http.Error(w, "database unavailable", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("arbitrary payload"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := New(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, _, err = ptm.Receive(arbitraryHash)
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+
+	failing = false
+	data, _, err := ptm.Receive(arbitraryHash)
+
+	assert.NoError(t, err, "failures must not be cached")
+	assert.Equal(t, "arbitrary payload", string(data))
+}
+
+func TestReceive_whenAffectedContractsMalformed(t *testing.T) {
+	mux := newTestServer()
+	mux.HandleFunc("/transaction/", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&receiveResp{
+			Payload:                      []byte("arbitrary payload"),
+			AffectedContractTransactions: []string{"not base64!"},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := New(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, _, err = ptm.Receive(arbitraryHash)
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+}
+
+func TestReceive_whenTransactionManagerRecoversWithinRetries(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/receiveraw", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "restarting", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("arbitrary payload"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := NewFromConfig(&Config{HttpUrl: server.URL, MaxRetries: 2, RetryBackoff: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, _, err := ptm.Receive(arbitraryHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "arbitrary payload", string(data))
+	assert.Equal(t, 3, calls)
+	assert.True(t, ptm.Health().Up)
+}
+
+func TestReceive_whenFetchInFlight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/receiveraw", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		_, _ = w.Write([]byte("arbitrary payload"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := NewFromConfig(&Config{HttpUrl: server.URL})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	results := make(chan []byte, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			data, _, _ := ptm.Receive(arbitraryHash)
+			results <- data
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "arbitrary payload", string(<-results))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "concurrent receivers must share the fetch of the payload")
+}
+
+func TestSendPayload_whenServerErrorIsNotRetried(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sendraw", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "failed", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	c, err := NewClientFromConfig(&Config{HttpUrl: server.URL, MaxRetries: 2, RetryBackoff: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = c.SendPayload([]byte("arbitrary payload"), "", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "a request which may have been processed must not be sent again")
+}
+
+type refusingTransport struct {
+	calls int
+}
+
+func (t *refusingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+}
+
+func TestSendPayload_whenConnectionRefusedIsRetried(t *testing.T) {
+	c, err := NewClientFromConfig(&Config{HttpUrl: "http://localhost:9101", MaxRetries: 2, RetryBackoff: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+	transport := &refusingTransport{}
+	c.httpClient.Transport = transport
+
+	_, err = c.SendPayload([]byte("arbitrary payload"), "", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, transport.calls)
+}
+
+func TestClient_whenCircuitBreakerOpen(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/receiveraw", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "database unavailable", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	c, err := NewClientFromConfig(&Config{HttpUrl: server.URL, CircuitBreakerThreshold: 2, CircuitBreakerCooldown: 60})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, _ = c.ReceivePayload(arbitraryHash.Bytes())
+	_, _ = c.ReceivePayload(arbitraryHash.Bytes())
+	_, err = c.ReceivePayload(arbitraryHash.Bytes())
+
+	assert.True(t, engine.IsTransportError(err), "unexpected error %v", err)
+	assert.Equal(t, 2, calls, "requests must fail fast while the circuit is open")
+	status := c.Health()
+	assert.False(t, status.Up)
+	assert.True(t, status.CircuitOpen)
+	assert.Equal(t, uint64(2), status.ConsecutiveFailures)
+
+	assert.NoError(t, c.Upcheck())
+
+	status = c.Health()
+	assert.True(t, status.Up)
+	assert.False(t, status.CircuitOpen, "a successful upcheck must close the circuit")
+}
+
+func TestPrivateTransactionManager_whenUpcheckRestarted(t *testing.T) {
+	upchecks := make(chan struct{}, 100)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		upchecks <- struct{}{}
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ptm, err := NewFromConfig(&Config{HttpUrl: server.URL, UpcheckInterval: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+	<-upchecks
+	ptm.upcheckInterval = 10 * time.Millisecond
+
+	expectUpcheck := func(msg string) {
+		select {
+		case <-upchecks:
+		case <-time.After(time.Second):
+			t.Fatal(msg)
+		}
+	}
+	assert.NoError(t, ptm.Start())
+	expectUpcheck("the probe must run once started")
+	assert.NoError(t, ptm.Stop())
+	time.Sleep(50 * time.Millisecond)
+	for len(upchecks) > 0 {
+		<-upchecks
+	}
+	select {
+	case <-upchecks:
+		t.Fatal("the probe must not run once stopped")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.NoError(t, ptm.Start())
+	expectUpcheck("the probe must run again once restarted")
+	assert.NoError(t, ptm.Stop())
+}
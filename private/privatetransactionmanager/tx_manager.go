@@ -3,26 +3,58 @@ package privatetransactionmanager
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/private/cache"
-	gocache "github.com/patrickmn/go-cache"
+	"github.com/ethereum/go-ethereum/private/engine"
 )
 
 type PrivateTransactionManager struct {
 	node *Client
-	c    *gocache.Cache
+	c    *cache.Cache
+	// persist enables storing received payloads in the database given to
+	// SetCacheDatabase
+	persist bool
+
+	upcheckInterval time.Duration
+	mu              sync.Mutex
+	quit            chan struct{} // closed to stop the upcheck probe, nil if not running
+
+	// the payloads being fetched from the transaction manager, which the
+	// concurrent receivers of the same payload wait for
+	fetches   map[common.EncryptedPayloadHash]*payloadFetch
+	fetchesMu sync.Mutex
+}
+
+// payloadFetch is a payload being fetched from the transaction manager
+type payloadFetch struct {
+	done  chan struct{} // closed once fetched
+	data  []byte
+	extra *engine.ExtraMetadata
+	err   error
 }
 
-func (g *PrivateTransactionManager) Send(data []byte, from string, to []string) (out common.EncryptedPayloadHash, err error) {
+// isStandardPrivate returns true if extra carries nothing the raw APIs, which
+// predate the privacy enhancements, couldn't store
+func isStandardPrivate(extra *engine.ExtraMetadata) bool {
+	return extra == nil || (extra.PrivacyFlag.IsStandardPrivate() && len(extra.ACHashes) == 0 && len(extra.MandatoryRecipients) == 0 && len(extra.Participants) == 0)
+}
+
+func (g *PrivateTransactionManager) Send(data []byte, from string, to []string, extra *engine.ExtraMetadata) (out common.EncryptedPayloadHash, err error) {
 	var b []byte
-	b, err = g.node.SendPayload(data, from, to)
+	if isStandardPrivate(extra) {
+		b, err = g.node.SendPayload(data, from, to)
+	} else {
+		b, err = g.node.SendPayloadWithExtra(data, from, to, extra)
+	}
 	if err != nil {
 		return common.EncryptedPayloadHash{}, err
 	}
-	g.c.Set(string(b), data, cache.DefaultExpiration)
 	out = common.BytesToEncryptedPayloadHash(b)
+	g.c.Set(out, cache.Item{Data: data, Extra: extra})
 	return
 }
 
@@ -32,35 +64,80 @@ func (g *PrivateTransactionManager) StoreRaw(data []byte, from string) (out comm
 	if err != nil {
 		return common.EncryptedPayloadHash{}, err
 	}
-	g.c.Set(string(b), data, cache.DefaultExpiration)
 	out = common.BytesToEncryptedPayloadHash(b)
+	g.c.Set(out, cache.Item{Data: data})
 	return out, nil
 }
 
-func (g *PrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string) (out []byte, err error) {
-	out, err = g.node.SendSignedPayload(txHash.Bytes(), to)
+func (g *PrivateTransactionManager) SendSignedTx(txHash common.EncryptedPayloadHash, to []string, extra *engine.ExtraMetadata) (out []byte, err error) {
+	if isStandardPrivate(extra) {
+		out, err = g.node.SendSignedPayload(txHash.Bytes(), to)
+	} else {
+		out, err = g.node.SendSignedPayloadWithExtra(txHash.Bytes(), to, extra)
+	}
 	if err != nil {
 		return nil, err
 	}
+	// the payload cached by StoreRaw doesn't know about the extra metadata yet
+	if item, found := g.c.Get(txHash); found {
+		g.c.Set(txHash, cache.Item{Data: item.Data, Extra: extra})
+	}
 	return out, nil
 }
 
-func (g *PrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, error) {
+func (g *PrivateTransactionManager) Receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
 	if common.EmptyEncryptedPayloadHash(txHash) {
-		return []byte{}, nil
+		return []byte{}, nil, nil
+	}
+	if item, found := g.c.Get(txHash); found {
+		return item.Data, item.Extra, nil
+	}
+	// join the fetch of the payload in flight, e.g. by the prefetching of the
+	// payloads of a block
+	g.fetchesMu.Lock()
+	if fetch, ok := g.fetches[txHash]; ok {
+		g.fetchesMu.Unlock()
+		<-fetch.done
+		return fetch.data, fetch.extra, fetch.err
+	}
+	// the payload may have been cached by a fetch which just completed
+	if item, found := g.c.Get(txHash); found {
+		g.fetchesMu.Unlock()
+		return item.Data, item.Extra, nil
+	}
+	fetch := &payloadFetch{done: make(chan struct{})}
+	g.fetches[txHash] = fetch
+	g.fetchesMu.Unlock()
+
+	fetch.data, fetch.extra, fetch.err = g.receive(txHash)
+
+	g.fetchesMu.Lock()
+	delete(g.fetches, txHash)
+	g.fetchesMu.Unlock()
+	close(fetch.done)
+	return fetch.data, fetch.extra, fetch.err
+}
+
+// receive fetches the payload from the transaction manager and caches it
+func (g *PrivateTransactionManager) receive(txHash common.EncryptedPayloadHash) ([]byte, *engine.ExtraMetadata, error) {
+	pl, extra, err := g.node.ReceivePayloadWithExtra(txHash.Bytes())
+	if err == engine.ErrPayloadNotFound {
+		// not being a recipient of a payload isn't an error
+		g.c.Set(txHash, cache.Item{})
+		return nil, nil, nil
 	}
-	// Ignore this error since not being a recipient of
-	// a payload isn't an error.
-	// TODO: Return an error if it's anything OTHER than
-	// 'you are not a recipient.'
-	dataStr := string(txHash.Bytes())
-	x, found := g.c.Get(dataStr)
-	if found {
-		return x.([]byte), nil
+	if err != nil {
+		// failures are never cached so the payload is fetched again next time
+		return nil, nil, err
 	}
-	pl, _ := g.node.ReceivePayload(txHash.Bytes())
-	g.c.Set(dataStr, pl, cache.DefaultExpiration)
-	return pl, nil
+	g.c.Set(txHash, cache.Item{Data: pl, Extra: extra})
+	return pl, extra, nil
+}
+
+// ReceiveBatch receives the payloads which aren't cached concurrently, as the
+// transaction manager API has no batch operation
+func (g *PrivateTransactionManager) ReceiveBatch(txHashes []common.EncryptedPayloadHash) ([][]byte, []*engine.ExtraMetadata, error) {
+	return engine.ReceiveConcurrently(txHashes, engine.DefaultBatchConcurrency, g.Receive)
 }
 
 func (g *PrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash) (bool, error) {
@@ -68,39 +145,119 @@ func (g *PrivateTransactionManager) IsSender(txHash common.EncryptedPayloadHash)
 }
 
 func (g *PrivateTransactionManager) GetParticipants(txHash common.EncryptedPayloadHash) ([]string, error) {
-
-	return g.node.GetParticipants(txHash)
+	if participants, found := g.c.GetParticipants(txHash); found {
+		return participants, nil
+	}
+	participants, err := g.node.GetParticipants(txHash)
+	if err != nil {
+		return nil, err
+	}
+	g.c.SetParticipants(txHash, participants)
+	return participants, nil
 }
 
+// New creates a PrivateTransactionManager from path, which is either the
+// transaction manager's unix socket, its http:// or https:// URL, or a TOML
+// configuration file pointing at one of those.
 func New(path string) (*PrivateTransactionManager, error) {
-	info, err := os.Lstat(path)
+	cfg, err := configFromPath(path)
 	if err != nil {
 		return nil, err
 	}
-	// We accept either the socket or a configuration file that points to
-	// a socket.
-	isSocket := info.Mode()&os.ModeSocket != 0
-	if !isSocket {
-		cfg, err := LoadConfig(path)
-		if err != nil {
-			return nil, err
-		}
-		path = filepath.Join(cfg.WorkDir, cfg.Socket)
-	}
-	err = RunNode(path)
+	return NewFromConfig(cfg)
+}
+
+// NewFromConfig creates a PrivateTransactionManager from a loaded config and
+// verifies that the transaction manager is reachable
+func NewFromConfig(cfg *Config) (*PrivateTransactionManager, error) {
+	n, err := NewClientFromConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
-	n, err := NewClient(path)
-	if err != nil {
+	if err := n.Upcheck(); err != nil {
 		return nil, err
 	}
 	return &PrivateTransactionManager{
-		node: n,
-		c:    cache.NewDefaultCache(),
+		node:            n,
+		c:               cache.New(int(cfg.CacheSize)*1024*1024, time.Duration(cfg.CacheTTL)*time.Second),
+		persist:         cfg.CachePersist,
+		upcheckInterval: time.Duration(cfg.UpcheckInterval) * time.Second,
+		fetches:         make(map[common.EncryptedPayloadHash]*payloadFetch),
 	}, nil
 }
 
+// Start starts the periodic upcheck probe, if configured. It can be started
+// again once stopped.
+func (g *PrivateTransactionManager) Start() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.upcheckInterval == 0 || g.quit != nil {
+		return nil
+	}
+	g.quit = make(chan struct{})
+	go g.upcheckLoop(g.upcheckInterval, g.quit)
+	return nil
+}
+
+// Stop stops the periodic upcheck probe
+func (g *PrivateTransactionManager) Stop() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.quit != nil {
+		close(g.quit)
+		g.quit = nil
+	}
+	return nil
+}
+
+// upcheckLoop probes the transaction manager periodically, so that its health
+// is known and the circuit breaker closes without waiting for a request
+func (g *PrivateTransactionManager) upcheckLoop(interval time.Duration, quit chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// failures are recorded and logged by the health tracking
+			_ = g.node.Upcheck()
+		case <-quit:
+			return
+		}
+	}
+}
+
+// Health returns the availability of the transaction manager
+func (g *PrivateTransactionManager) Health() engine.HealthStatus {
+	return g.node.Health()
+}
+
+// SetCacheDatabase provides the database in which received payloads are kept,
+// if the persistent cache is enabled
+func (g *PrivateTransactionManager) SetCacheDatabase(db ethdb.KeyValueStore) {
+	if g.persist {
+		g.c.SetDatabase(db)
+	}
+}
+
+func configFromPath(path string) (*Config, error) {
+	if isHttpUrl(path) {
+		return &Config{HttpUrl: path}, nil
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	// We accept either the socket or a configuration file that points to
+	// a socket or a URL.
+	isSocket := info.Mode()&os.ModeSocket != 0
+	if isSocket {
+		return &Config{Socket: path}, nil
+	}
+	return LoadConfig(path)
+}
+
 func MustNew(path string) *PrivateTransactionManager {
 	g, err := New(path)
 	if err != nil {
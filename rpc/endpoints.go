@@ -27,8 +27,9 @@ import (
 )
 
 // StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-// Quorum: tlsConfigSource and authManager are introduced to secure the HTTP endpoint
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts, tlsConfigSource security.TLSConfigurationSource, authManager security.AuthenticationManager) (net.Listener, *Server, bool, error) {
+// Quorum: tlsConfigSource and authManager are introduced to secure the HTTP endpoint,
+// accessChecker optionally authorizes the authenticated callers against their permission account
+func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts, tlsConfigSource security.TLSConfigurationSource, authManager security.AuthenticationManager, accessChecker AccountAccessChecker) (net.Listener, *Server, bool, error) {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -36,6 +37,7 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 	}
 	// Register all the APIs exposed by the services
 	handler := NewProtectedServer(authManager)
+	handler.accountAccessChecker = accessChecker
 	for _, api := range apis {
 		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
@@ -86,8 +88,9 @@ func startListener(endpoint string, tlsConfigSource security.TLSConfigurationSou
 }
 
 // StartWSEndpoint starts a websocket endpoint
-// Quorum: tlsConfigSource and authManager are introduced to secure the WS endpoint
-func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool, tlsConfigSource security.TLSConfigurationSource, authManager security.AuthenticationManager) (net.Listener, *Server, bool, error) {
+// Quorum: tlsConfigSource and authManager are introduced to secure the WS endpoint,
+// accessChecker optionally authorizes the authenticated callers against their permission account
+func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool, tlsConfigSource security.TLSConfigurationSource, authManager security.AuthenticationManager, accessChecker AccountAccessChecker) (net.Listener, *Server, bool, error) {
 
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
@@ -96,6 +99,7 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []
 	}
 	// Register all the APIs exposed by the services
 	handler := NewProtectedServer(authManager)
+	handler.accountAccessChecker = accessChecker
 	for _, api := range apis {
 		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
@@ -318,6 +318,7 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		if err := secureCall(r, msg); err != nil {
 			return securityErrorMessage(msg, err)
 		}
+		cp.ctx = withPrivateStateIdentifier(cp.ctx, r.Resolve())
 	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/jpmorganchase/quorum-security-plugin-sdk-go/proto"
@@ -22,8 +23,24 @@ const (
 	// keys used to save values in request context
 	ctxAuthenticationError   = securityContextKey("AUTHENTICATION_ERROR")   // key to save error during authentication before processing the request body
 	ctxPreauthenticatedToken = securityContextKey("PREAUTHENTICATED_TOKEN") // key to save the preauthenticated token once authenticated
+	// key to pass the private state granted to the caller down to the method being called
+	ctxPrivateStateIdentifier = securityContextKey("PRIVATE_STATE_IDENTIFIER")
+	// key to save the checker of the permission account of the caller, if enabled
+	ctxAccountAccessChecker = securityContextKey("ACCOUNT_ACCESS_CHECKER")
+
+	// scheme of the raw granted authority which selects the private state of the caller, e.g.: psi://tenantA
+	privateStateAuthorityScheme = "psi://"
+	// scheme of the raw granted authority which maps the caller to a permission account, e.g.: account://0xed9d02e382b34818e88b88a309c7fe71e65f419d
+	permissionAccountAuthorityScheme = "account://"
 )
 
+// AccountAccessChecker authorizes the calls of an authenticated caller against
+// the permission account the caller is mapped to, e.g. its org status and the
+// access of its role
+type AccountAccessChecker interface {
+	CheckAccountAccess(account common.Address, service, method string) error
+}
+
 type securityContextConfigurer interface {
 	Configure(secCtx securityContext)
 }
@@ -98,11 +115,81 @@ func secureCall(resolver securityContextResolver, msg *jsonrpcMessage) error {
 			log.Warn("unsupported method when performing authorization check", "method", msg.Method)
 		} else if err := verifyAccess(elem[0], elem[1], authToken.Authorities); err != nil {
 			return err
+		} else if checker, ok := secCtx.Value(ctxAccountAccessChecker).(AccountAccessChecker); ok {
+			if err := verifyAccountAccess(checker, elem[0], elem[1], authToken.Authorities); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// verifyAccountAccess checks the call against the permission account granted
+// to the caller. callers not mapped to a permission account are denied.
+func verifyAccountAccess(checker AccountAccessChecker, service, method string, authorities []*proto.GrantedAuthority) error {
+	account, ok := permissionAccount(authorities)
+	if !ok {
+		return &securityError{fmt.Sprintf("%s%s%s - access denied: no permission account granted", service, serviceMethodSeparator, method)}
+	}
+	if err := checker.CheckAccountAccess(account, service, method); err != nil {
+		return &securityError{fmt.Sprintf("%s%s%s - access denied: %v", service, serviceMethodSeparator, method, err)}
+	}
+	return nil
+}
+
+// permissionAccount returns the first permission account granted in the
+// authorities
+func permissionAccount(authorities []*proto.GrantedAuthority) (common.Address, bool) {
+	for _, authority := range authorities {
+		if strings.HasPrefix(authority.Raw, permissionAccountAuthorityScheme) {
+			account := strings.TrimPrefix(authority.Raw, permissionAccountAuthorityScheme)
+			if common.IsHexAddress(account) {
+				return common.HexToAddress(account), true
+			}
+		}
+	}
+	return common.Address{}, false
+}
+
+// withPrivateStateIdentifier passes the private state granted to the authenticated
+// caller in the security context down to the method being called
+func withPrivateStateIdentifier(ctx context.Context, secCtx securityContext) context.Context {
+	if secCtx == nil || ctx.Value(ctxPrivateStateIdentifier) != nil {
+		return ctx
+	}
+	authToken, isPreauthenticated := secCtx.Value(ctxPreauthenticatedToken).(*proto.PreAuthenticatedAuthenticationToken)
+	if !isPreauthenticated {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxPrivateStateIdentifier, privateStateIdentifier(authToken.Authorities))
+}
+
+// privateStateIdentifier returns the first private state granted in the authorities,
+// empty if there is none
+func privateStateIdentifier(authorities []*proto.GrantedAuthority) string {
+	for _, authority := range authorities {
+		if strings.HasPrefix(authority.Raw, privateStateAuthorityScheme) {
+			return strings.TrimPrefix(authority.Raw, privateStateAuthorityScheme)
+		}
+	}
+	return ""
+}
+
+// PrivateStateIdentifierFromContext returns the private state granted to the
+// authenticated caller of a method, empty if it isn't granted any. ok is false
+// if the caller isn't authenticated, e.g. when security is disabled or over IPC.
+func PrivateStateIdentifierFromContext(ctx context.Context) (psi string, ok bool) {
+	psi, ok = ctx.Value(ctxPrivateStateIdentifier).(string)
+	return
+}
+
+// ContextWithPrivateStateIdentifier returns a copy of ctx in which the caller is
+// authenticated and granted the given private state, e.g. for callers in the
+// same process which are authenticated by other means
+func ContextWithPrivateStateIdentifier(ctx context.Context, psi string) context.Context {
+	return context.WithValue(ctx, ctxPrivateStateIdentifier, psi)
+}
+
 // construct JSON RPC error message which has the ID of the request
 func securityErrorMessage(forMsg *jsonrpcMessage, err error) *jsonrpcMessage {
 	msg := &jsonrpcMessage{Version: vsn, ID: forMsg.ID, Error: &jsonError{
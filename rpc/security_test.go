@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/jpmorganchase/quorum-security-plugin-sdk-go/proto"
 	testifyassert "github.com/stretchr/testify/assert"
@@ -202,6 +203,125 @@ func TestSecureCall_whenMethodInJSONMessageIsNotSupported(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestSecureCall_whenAccountAccessGranted(t *testing.T) {
+	assert := testifyassert.New(t)
+	expiredAt, _ := ptypes.TimestampProto(time.Now().Add(1 * time.Hour))
+	checker := &stubAccountAccessChecker{}
+	stubSecurityContextResolver := newStubSecurityContextResolver([]struct{ k, v interface{} }{
+		{ctxPreauthenticatedToken, &proto.PreAuthenticatedAuthenticationToken{
+			ExpiredAt: expiredAt,
+			Authorities: []*proto.GrantedAuthority{
+				{
+					Service: "eth",
+					Method:  "blockNumber",
+				},
+				{
+					Raw: "account://0xed9d02e382b34818e88b88a309c7fe71e65f419d",
+				},
+			},
+		}},
+		{ctxAccountAccessChecker, checker},
+	})
+
+	err := secureCall(stubSecurityContextResolver, &jsonrpcMessage{Method: "eth_blockNumber"})
+
+	assert.NoError(err)
+	assert.Equal(common.HexToAddress("0xed9d02e382b34818e88b88a309c7fe71e65f419d"), checker.account)
+	assert.Equal("eth", checker.service)
+	assert.Equal("blockNumber", checker.method)
+}
+
+func TestSecureCall_whenAccountAccessDenied(t *testing.T) {
+	assert := testifyassert.New(t)
+	expiredAt, _ := ptypes.TimestampProto(time.Now().Add(1 * time.Hour))
+	stubSecurityContextResolver := newStubSecurityContextResolver([]struct{ k, v interface{} }{
+		{ctxPreauthenticatedToken, &proto.PreAuthenticatedAuthenticationToken{
+			ExpiredAt: expiredAt,
+			Authorities: []*proto.GrantedAuthority{
+				{
+					Service: "eth",
+					Method:  "blockNumber",
+				},
+				{
+					Raw: "account://0xed9d02e382b34818e88b88a309c7fe71e65f419d",
+				},
+			},
+		}},
+		{ctxAccountAccessChecker, &stubAccountAccessChecker{err: errors.New("org suspended")}},
+	})
+
+	err := secureCall(stubSecurityContextResolver, &jsonrpcMessage{Method: "eth_blockNumber"})
+
+	assert.EqualError(err, "eth_blockNumber - access denied: org suspended")
+}
+
+func TestSecureCall_whenNoAccountGranted(t *testing.T) {
+	assert := testifyassert.New(t)
+	expiredAt, _ := ptypes.TimestampProto(time.Now().Add(1 * time.Hour))
+	stubSecurityContextResolver := newStubSecurityContextResolver([]struct{ k, v interface{} }{
+		{ctxPreauthenticatedToken, &proto.PreAuthenticatedAuthenticationToken{
+			ExpiredAt: expiredAt,
+			Authorities: []*proto.GrantedAuthority{
+				{
+					Service: "eth",
+					Method:  "blockNumber",
+				},
+				{
+					Raw: "account://invalid",
+				},
+			},
+		}},
+		{ctxAccountAccessChecker, &stubAccountAccessChecker{}},
+	})
+
+	err := secureCall(stubSecurityContextResolver, &jsonrpcMessage{Method: "eth_blockNumber"})
+
+	assert.EqualError(err, "eth_blockNumber - access denied: no permission account granted")
+}
+
+func TestWithPrivateStateIdentifier_whenGranted(t *testing.T) {
+	assert := testifyassert.New(t)
+	stubSecurityContextResolver := newStubSecurityContextResolver([]struct{ k, v interface{} }{
+		{ctxPreauthenticatedToken, &proto.PreAuthenticatedAuthenticationToken{
+			Authorities: []*proto.GrantedAuthority{
+				{
+					Service: "eth",
+					Method:  "*",
+				},
+				{
+					Raw: "psi://tenantA",
+				},
+			},
+		}},
+	})
+
+	psi, ok := PrivateStateIdentifierFromContext(withPrivateStateIdentifier(context.Background(), stubSecurityContextResolver.Resolve()))
+
+	assert.True(ok)
+	assert.Equal("tenantA", psi)
+}
+
+func TestWithPrivateStateIdentifier_whenNotGranted(t *testing.T) {
+	assert := testifyassert.New(t)
+	stubSecurityContextResolver := newStubSecurityContextResolver([]struct{ k, v interface{} }{
+		{ctxPreauthenticatedToken, &proto.PreAuthenticatedAuthenticationToken{}},
+	})
+
+	psi, ok := PrivateStateIdentifierFromContext(withPrivateStateIdentifier(context.Background(), stubSecurityContextResolver.Resolve()))
+
+	assert.True(ok)
+	assert.Empty(psi)
+}
+
+func TestWithPrivateStateIdentifier_whenNotAuthenticated(t *testing.T) {
+	assert := testifyassert.New(t)
+	stubSecurityContextResolver := newStubSecurityContextResolver(nil)
+
+	_, ok := PrivateStateIdentifierFromContext(withPrivateStateIdentifier(context.Background(), stubSecurityContextResolver.Resolve()))
+
+	assert.False(ok)
+}
+
 type stubSecurityContextResolver struct {
 	ctx securityContext
 }
@@ -217,3 +337,15 @@ func newStubSecurityContextResolver(ctx []struct{ k, v interface{} }) *stubSecur
 func (sr *stubSecurityContextResolver) Resolve() securityContext {
 	return sr.ctx
 }
+
+type stubAccountAccessChecker struct {
+	err     error
+	account common.Address
+	service string
+	method  string
+}
+
+func (c *stubAccountAccessChecker) CheckAccountAccess(account common.Address, service, method string) error {
+	c.account, c.service, c.method = account, service, method
+	return c.err
+}
@@ -52,6 +52,8 @@ type Server struct {
 	// Quorum
 	// The implementation would authenticate the token coming from a request
 	authenticationManager security.AuthenticationManager
+	// Optionally authorizes the authenticated callers against their permission account
+	accountAccessChecker AccountAccessChecker
 }
 
 // Quorum
@@ -165,6 +167,9 @@ func (s *Server) authenticateHttpRequest(r *http.Request, cfg securityContextCon
 			securityContext = context.WithValue(securityContext, ctxAuthenticationError, &securityError{err.Error()})
 		} else {
 			securityContext = context.WithValue(securityContext, ctxPreauthenticatedToken, authToken)
+			if s.accountAccessChecker != nil {
+				securityContext = context.WithValue(securityContext, ctxAccountAccessChecker, s.accountAccessChecker)
+			}
 		}
 	} else {
 		securityContext = context.WithValue(securityContext, ctxAuthenticationError, &securityError{"missing access token"})
@@ -186,6 +186,19 @@ func TestAuthenticateHttpRequest_whenTypical(t *testing.T) {
 	assert.True(t, hasAuthToken, "must be preauthenticated")
 }
 
+func TestAuthenticateHttpRequest_whenAccountAccessCheckerIsSet(t *testing.T) {
+	protectedServer := NewProtectedServer(&stubAuthenticationManager{true, nil})
+	protectedServer.accountAccessChecker = &stubAccountAccessChecker{}
+	arbitraryRequest, _ := http.NewRequest("POST", "https://arbitraryUrl", nil)
+	arbitraryRequest.Header.Set(HttpAuthorizationHeader, "arbitrary value")
+	captor := &securityContextConfigurerCaptor{}
+
+	protectedServer.authenticateHttpRequest(arbitraryRequest, captor)
+
+	_, hasChecker := captor.context.Value(ctxAccountAccessChecker).(AccountAccessChecker)
+	assert.True(t, hasChecker, "must have account access checker")
+}
+
 func TestAuthenticateHttpRequest_whenAuthenticationManagerIsDisabled(t *testing.T) {
 	protectedServer := NewProtectedServer(&stubAuthenticationManager{false, nil})
 	arbitraryRequest, _ := http.NewRequest("POST", "https://arbitraryUrl", nil)